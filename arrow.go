@@ -0,0 +1,529 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ArrowFormat enumerates the formats of Arrow IPC data written by ArrowWriter.
+type ArrowFormat int
+
+const (
+	// ArrowStream is the Arrow IPC streaming format, a sequence of messages
+	// terminated by an end-of-stream marker.
+	ArrowStream ArrowFormat = iota
+	// ArrowFile is the Arrow IPC file format, also known as Feather V2, which
+	// wraps the streaming format with magic bytes and a footer indexing the
+	// record batches for random access.
+	ArrowFile
+)
+
+// DefaultArrowBatchSize is the number of rows buffered by ArrowWriter before
+// they are written as a record batch.
+const DefaultArrowBatchSize = 64 * 1024
+
+// ArrowWriter writes parquet rows in the Arrow IPC formats, for example to hand
+// off the content of parquet files to Python or R programs without going
+// through intermediary formats like CSV:
+//
+//	writer, err := parquet.NewArrowWriter(output, reader.Schema(), parquet.ArrowFile)
+//	if err != nil {
+//		...
+//	}
+//	if _, err := parquet.CopyRows(writer, reader); err != nil {
+//		...
+//	}
+//	if err := writer.Close(); err != nil {
+//		...
+//	}
+//
+// Columns and rows are selected by the readers that rows are copied from, for
+// example readers of a projected schema or wrapped with FilterRowReader.
+//
+// The schema must be flat: all its columns must be top-level leaf columns,
+// which may be optional but not repeated. Optional columns become nullable
+// Arrow fields.
+// Columns are mapped to the Arrow type of their logical type when it has one
+// (strings, dates, timestamps, and integers of various widths), and to the
+// Arrow type of their physical type otherwise.
+type ArrowWriter struct {
+	output    io.Writer
+	format    ArrowFormat
+	schema    *fbTable
+	columns   []arrowColumn
+	batchSize int
+	numRows   int
+	offset    int64
+	blocks    []byte
+	closed    bool
+	buffer    []byte
+}
+
+// NewArrowWriter constructs a writer of rows of schema to output in the given
+// Arrow IPC format. The schema of the Arrow data is written immediately.
+//
+// The function returns an error if the schema cannot be represented in the
+// Arrow format supported by the writer.
+func NewArrowWriter(output io.Writer, schema *Schema, format ArrowFormat) (*ArrowWriter, error) {
+	w := &ArrowWriter{
+		output:    output,
+		format:    format,
+		batchSize: DefaultArrowBatchSize,
+	}
+
+	fields := make([]*fbTable, 0, len(schema.Fields()))
+	for _, field := range schema.Fields() {
+		if !field.Leaf() || field.Repeated() {
+			return nil, fmt.Errorf("cannot write column %q to arrow: only flat schemas of non-repeated leaf columns are supported", field.Name())
+		}
+		column, typeID, typ := arrowColumnOf(field.Type())
+		w.columns = append(w.columns, column)
+		fields = append(fields, &fbTable{
+			0: fbString(field.Name()),
+			1: fbBool(field.Optional()),
+			2: fbUint8(typeID),
+			3: typ,
+			5: fbTables{},
+		})
+	}
+	w.schema = &fbTable{
+		0: fbInt16(0), // little endian
+		1: fbTables(fields),
+	}
+
+	if format == ArrowFile {
+		if err := w.write(arrowMagic[:]); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.writeMessage(arrowMessageSchema, w.schema, nil); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// WriteRows writes rows to w, flushing a record batch each time the number of
+// buffered rows reaches DefaultArrowBatchSize.
+func (w *ArrowWriter) WriteRows(rows []Row) (int, error) {
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	for n, row := range rows {
+		for i := range w.columns {
+			w.columns[i].appended = false
+		}
+		for _, v := range row {
+			if i := v.Column(); i >= 0 && i < len(w.columns) && !w.columns[i].appended {
+				w.columns[i].append(v)
+			}
+		}
+		for i := range w.columns {
+			if !w.columns[i].appended {
+				w.columns[i].append(Value{})
+			}
+		}
+		if w.numRows++; w.numRows == w.batchSize {
+			if err := w.Flush(); err != nil {
+				return n + 1, err
+			}
+		}
+	}
+	return len(rows), nil
+}
+
+// Flush writes the buffered rows to the output as a record batch.
+func (w *ArrowWriter) Flush() error {
+	if w.numRows == 0 {
+		return nil
+	}
+
+	nodes := make([]byte, 0, 16*len(w.columns))
+	buffers := make([]byte, 0, 48*len(w.columns))
+	body := w.buffer[:0]
+
+	appendBuffer := func(data []byte) {
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(body)))
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(data)))
+		body = append(body, data...)
+		body = append(body, make([]byte, align8(len(data))-len(data))...)
+	}
+
+	for i := range w.columns {
+		c := &w.columns[i]
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(w.numRows))
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(c.numNulls))
+		if c.numNulls > 0 {
+			appendBuffer(c.validity)
+		} else {
+			appendBuffer(nil)
+		}
+		if c.variable {
+			appendBuffer(c.offsets)
+		}
+		appendBuffer(c.data)
+		c.reset()
+	}
+
+	batch := &fbTable{
+		0: fbInt64(w.numRows),
+		1: fbStructs{size: 16, data: nodes},
+		2: fbStructs{size: 16, data: buffers},
+	}
+	w.numRows = 0
+	w.buffer = body
+	return w.writeMessage(arrowMessageRecordBatch, batch, body)
+}
+
+// Close flushes the buffered rows and terminates the Arrow data. It does not
+// close the underlying output.
+func (w *ArrowWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	w.closed = true
+
+	// End-of-stream marker.
+	if err := w.write([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}); err != nil {
+		return err
+	}
+	if w.format != ArrowFile {
+		return nil
+	}
+
+	footer := fbBuild(&fbTable{
+		0: fbInt16(arrowMetadataVersion),
+		1: w.schema,
+		2: fbStructs{size: 24},
+		3: fbStructs{size: 24, data: w.blocks},
+	})
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	footer = append(footer, arrowMagic[:6]...)
+	return w.write(footer)
+}
+
+func (w *ArrowWriter) write(b []byte) error {
+	n, err := w.output.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+// writeMessage writes a message with the encapsulation of the Arrow IPC format:
+// a continuation marker, the length of the flatbuffer metadata padded to 8
+// bytes, the metadata, and the body of the message.
+func (w *ArrowWriter) writeMessage(headerType uint8, header *fbTable, body []byte) error {
+	metadata := fbBuild(&fbTable{
+		0: fbInt16(arrowMetadataVersion),
+		1: fbUint8(headerType),
+		2: header,
+		3: fbInt64(len(body)),
+	})
+	metadata = append(metadata, make([]byte, align8(len(metadata))-len(metadata))...)
+
+	if headerType == arrowMessageRecordBatch {
+		w.blocks = binary.LittleEndian.AppendUint64(w.blocks, uint64(w.offset))
+		w.blocks = binary.LittleEndian.AppendUint32(w.blocks, uint32(8+len(metadata)))
+		w.blocks = binary.LittleEndian.AppendUint32(w.blocks, 0)
+		w.blocks = binary.LittleEndian.AppendUint64(w.blocks, uint64(len(body)))
+	}
+
+	prefix := [8]byte{0xFF, 0xFF, 0xFF, 0xFF}
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(metadata)))
+	if err := w.write(prefix[:]); err != nil {
+		return err
+	}
+	if err := w.write(metadata); err != nil {
+		return err
+	}
+	return w.write(body)
+}
+
+const (
+	arrowMetadataVersion    = 4 // V5
+	arrowMessageSchema      = 1
+	arrowMessageRecordBatch = 3
+
+	arrowTypeInt             = 2
+	arrowTypeFloatingPoint   = 3
+	arrowTypeBinary          = 4
+	arrowTypeUtf8            = 5
+	arrowTypeBool            = 6
+	arrowTypeDate            = 8
+	arrowTypeTimestamp       = 10
+	arrowTypeFixedSizeBinary = 15
+)
+
+var arrowMagic = [8]byte{'A', 'R', 'R', 'O', 'W', '1', 0, 0}
+
+type arrowColumn struct {
+	kind     Kind
+	width    int // in bytes, 0 for booleans and variable length values
+	variable bool
+	appended bool
+	numNulls int
+	length   int
+	validity []byte
+	offsets  []byte
+	data     []byte
+}
+
+// arrowColumnOf returns the column writing values of t, and the Arrow type
+// that it maps to.
+func arrowColumnOf(t Type) (arrowColumn, uint8, *fbTable) {
+	c := arrowColumn{kind: t.Kind()}
+	lt := t.LogicalType()
+
+	switch c.kind {
+	case Boolean:
+		return c, arrowTypeBool, &fbTable{}
+	case Int32:
+		c.width = 4
+		switch {
+		case lt != nil && lt.Date != nil:
+			return c, arrowTypeDate, &fbTable{0: fbInt16(0)} // days
+		case lt != nil && lt.Integer != nil:
+			c.width = int(lt.Integer.BitWidth) / 8
+			return c, arrowTypeInt, &fbTable{0: fbInt32(lt.Integer.BitWidth), 1: fbBool(lt.Integer.IsSigned)}
+		}
+		return c, arrowTypeInt, &fbTable{0: fbInt32(32), 1: fbBool(true)}
+	case Int64:
+		c.width = 8
+		switch {
+		case lt != nil && lt.Timestamp != nil:
+			unit := fbInt16(2) // microseconds
+			switch {
+			case lt.Timestamp.Unit.Millis != nil:
+				unit = 1
+			case lt.Timestamp.Unit.Nanos != nil:
+				unit = 3
+			}
+			typ := &fbTable{0: unit}
+			if lt.Timestamp.IsAdjustedToUTC {
+				(*typ)[1] = fbString("UTC")
+			}
+			return c, arrowTypeTimestamp, typ
+		case lt != nil && lt.Integer != nil:
+			return c, arrowTypeInt, &fbTable{0: fbInt32(64), 1: fbBool(lt.Integer.IsSigned)}
+		}
+		return c, arrowTypeInt, &fbTable{0: fbInt32(64), 1: fbBool(true)}
+	case Float:
+		c.width = 4
+		return c, arrowTypeFloatingPoint, &fbTable{0: fbInt16(1)}
+	case Double:
+		c.width = 8
+		return c, arrowTypeFloatingPoint, &fbTable{0: fbInt16(2)}
+	case ByteArray:
+		c.variable = true
+		c.offsets = make([]byte, 4)
+		if isUTF8Type(t) {
+			return c, arrowTypeUtf8, &fbTable{}
+		}
+		return c, arrowTypeBinary, &fbTable{}
+	default: // FixedLenByteArray, Int96
+		c.width = t.Length()
+		if c.kind == Int96 {
+			c.width = 12
+		}
+		return c, arrowTypeFixedSizeBinary, &fbTable{0: fbInt32(c.width)}
+	}
+}
+
+func (c *arrowColumn) append(v Value) {
+	c.appended = true
+	i := c.length
+	c.length++
+
+	if i%8 == 0 {
+		c.validity = append(c.validity, 0)
+		if c.kind == Boolean {
+			c.data = append(c.data, 0)
+		}
+	}
+	if v.IsNull() {
+		c.numNulls++
+	} else {
+		c.validity[i/8] |= 1 << (i % 8)
+	}
+
+	switch {
+	case c.kind == Boolean:
+		if !v.IsNull() && v.boolean() {
+			c.data[i/8] |= 1 << (i % 8)
+		}
+	case c.variable:
+		if !v.IsNull() {
+			c.data = append(c.data, v.byteArray()...)
+		}
+		c.offsets = binary.LittleEndian.AppendUint32(c.offsets, uint32(len(c.data)))
+	case v.IsNull():
+		c.data = append(c.data, make([]byte, c.width)...)
+	case c.kind == Int32 || c.kind == Int64 || c.kind == Float || c.kind == Double:
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], v.uint64())
+		if c.kind == Int32 || c.kind == Float {
+			binary.LittleEndian.PutUint32(b[:], v.uint32())
+		}
+		c.data = append(c.data, b[:c.width]...)
+	default:
+		c.data = append(c.data, v.byteArray()...)
+	}
+}
+
+func (c *arrowColumn) reset() {
+	c.numNulls = 0
+	c.length = 0
+	c.validity = c.validity[:0]
+	c.data = c.data[:0]
+	if c.variable {
+		c.offsets = c.offsets[:4]
+	}
+}
+
+func align8(n int) int { return (n + 7) &^ 7 }
+
+// The types below implement a minimal flatbuffers builder for the metadata of
+// Arrow IPC messages. Tables are represented as maps of field ids to values,
+// and serialized front to back: children are written after their parents, so
+// the unsigned offsets referencing them always point forward as required by
+// the flatbuffers format.
+type (
+	fbTable   map[int]any
+	fbTables  []*fbTable
+	fbString  string
+	fbBool    bool
+	fbUint8   uint8
+	fbInt16   int16
+	fbInt32   int32
+	fbInt64   int64
+	fbStructs struct {
+		size int
+		data []byte
+	}
+)
+
+// fbBuild returns the flatbuffer holding the given root table. The buffer is
+// expected to be written at an offset aligned on 8 bytes.
+func fbBuild(root *fbTable) []byte {
+	b := &fbBuilder{buf: make([]byte, 4, 256)}
+	binary.LittleEndian.PutUint32(b.buf, uint32(b.table(root)))
+	return b.buf
+}
+
+type fbBuilder struct{ buf []byte }
+
+func (b *fbBuilder) align(n, mod int) {
+	for len(b.buf)%n != mod {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+func (b *fbBuilder) table(t *fbTable) int {
+	ids := make([]int, 0, len(*t))
+	numFields := 0
+	for id := range *t {
+		ids = append(ids, id)
+		if id >= numFields {
+			numFields = id + 1
+		}
+	}
+	// Fields are laid out by decreasing size to keep them aligned without
+	// padding, the table starting at an offset where its 8 bytes fields are
+	// aligned.
+	sort.Slice(ids, func(i, j int) bool {
+		si, sj := fbSizeOf((*t)[ids[i]]), fbSizeOf((*t)[ids[j]])
+		return si > sj || (si == sj && ids[i] < ids[j])
+	})
+
+	b.align(2, 0)
+	vtable := len(b.buf)
+	b.buf = append(b.buf, make([]byte, 4+2*numFields)...)
+	b.align(8, 4)
+	table := len(b.buf)
+	b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(table-vtable))
+
+	type reference struct {
+		offset int
+		value  any
+	}
+	var references []reference
+
+	for _, id := range ids {
+		offset := len(b.buf)
+		binary.LittleEndian.PutUint16(b.buf[vtable+4+2*id:], uint16(offset-table))
+		switch v := (*t)[id].(type) {
+		case fbBool:
+			if v {
+				b.buf = append(b.buf, 1)
+			} else {
+				b.buf = append(b.buf, 0)
+			}
+		case fbUint8:
+			b.buf = append(b.buf, byte(v))
+		case fbInt16:
+			b.buf = binary.LittleEndian.AppendUint16(b.buf, uint16(v))
+		case fbInt32:
+			b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(v))
+		case fbInt64:
+			b.buf = binary.LittleEndian.AppendUint64(b.buf, uint64(v))
+		default:
+			references = append(references, reference{offset: offset, value: v})
+			b.buf = append(b.buf, 0, 0, 0, 0)
+		}
+	}
+
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(4+2*numFields))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(len(b.buf)-table))
+
+	for _, ref := range references {
+		var child int
+		switch v := ref.value.(type) {
+		case *fbTable:
+			child = b.table(v)
+		case fbTables:
+			child = b.tables(v)
+		case fbString:
+			b.align(4, 0)
+			child = len(b.buf)
+			b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(v)))
+			b.buf = append(b.buf, v...)
+			b.buf = append(b.buf, 0)
+		case fbStructs:
+			b.align(8, 4)
+			child = len(b.buf)
+			b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(v.data)/v.size))
+			b.buf = append(b.buf, v.data...)
+		}
+		binary.LittleEndian.PutUint32(b.buf[ref.offset:], uint32(child-ref.offset))
+	}
+	return table
+}
+
+func (b *fbBuilder) tables(tables fbTables) int {
+	b.align(4, 0)
+	vector := len(b.buf)
+	b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(tables)))
+	b.buf = append(b.buf, make([]byte, 4*len(tables))...)
+	for i, t := range tables {
+		offset := vector + 4 + 4*i
+		child := b.table(t)
+		binary.LittleEndian.PutUint32(b.buf[offset:], uint32(child-offset))
+	}
+	return vector
+}
+
+func fbSizeOf(v any) int {
+	switch v.(type) {
+	case fbBool, fbUint8:
+		return 1
+	case fbInt16:
+		return 2
+	case fbInt64:
+		return 8
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,408 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestArrowWriter(t *testing.T) {
+	type Row struct {
+		ID    int64     `parquet:"id"`
+		Name  string    `parquet:"name"`
+		Score *float64  `parquet:"score,optional"`
+		OK    bool      `parquet:"ok"`
+		Time  time.Time `parquet:"time,timestamp(millisecond)"`
+		Data  []byte    `parquet:"data,optional"`
+	}
+
+	score := 1.5
+	rows := []Row{
+		{ID: 1, Name: "a", Score: &score, OK: true, Time: time.UnixMilli(1000), Data: []byte("xy")},
+		{ID: 2, Name: "bcd", Time: time.UnixMilli(2000)},
+		{ID: 3, Score: &score, OK: true, Time: time.UnixMilli(3000)},
+	}
+	buffer := parquet.NewGenericBuffer[Row]()
+	if _, err := buffer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(format parquet.ArrowFormat) []byte {
+		output := new(bytes.Buffer)
+		writer, err := parquet.NewArrowWriter(output, buffer.Schema(), format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := parquet.CopyRows(writer, buffer.Rows()); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return output.Bytes()
+	}
+
+	stream := write(parquet.ArrowStream)
+	if len(stream)%8 != 0 {
+		t.Errorf("arrow stream is not padded to 8 bytes: %d", len(stream))
+	}
+	if binary.LittleEndian.Uint32(stream) != 0xFFFFFFFF {
+		t.Error("arrow stream does not start with a continuation marker")
+	}
+	if size := binary.LittleEndian.Uint32(stream[4:]); size == 0 || size%8 != 0 {
+		t.Errorf("wrong size of the schema message metadata: %d", size)
+	}
+	if !bytes.HasSuffix(stream, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}) {
+		t.Error("arrow stream does not end with an end-of-stream marker")
+	}
+	for _, s := range []string{"id", "name", "score", "ok", "time", "data", "bcd", "xy"} {
+		if !bytes.Contains(stream, []byte(s)) {
+			t.Errorf("arrow stream does not contain %q", s)
+		}
+	}
+
+	file := write(parquet.ArrowFile)
+	if !bytes.HasPrefix(file, []byte("ARROW1\x00\x00")) || !bytes.HasSuffix(file, []byte("ARROW1")) {
+		t.Fatal("arrow file does not start and end with magic bytes")
+	}
+	footerSize := int(binary.LittleEndian.Uint32(file[len(file)-10:]))
+	if !bytes.Equal(file[8:len(file)-10-footerSize], stream) {
+		t.Error("messages of the arrow file differ from the arrow stream")
+	}
+
+	type Nested struct {
+		Tags []string `parquet:"tags,list"`
+	}
+	if _, err := parquet.NewArrowWriter(new(bytes.Buffer), parquet.SchemaOf(Nested{}), parquet.ArrowStream); err == nil {
+		t.Error("expected an error when writing a nested schema to arrow")
+	}
+}
+
+func TestArrowWriterMessages(t *testing.T) {
+	type Row struct {
+		ID    int64     `parquet:"id"`
+		Name  string    `parquet:"name"`
+		Score *float64  `parquet:"score,optional"`
+		OK    bool      `parquet:"ok"`
+		Time  time.Time `parquet:"time,timestamp(millisecond)"`
+		Data  []byte    `parquet:"data,optional"`
+	}
+
+	score := 1.5
+	rows := []Row{
+		{ID: 1, Name: "a", Score: &score, OK: true, Time: time.UnixMilli(1000), Data: []byte("xy")},
+		{ID: 2, Name: "bcd", Time: time.UnixMilli(2000)},
+		{ID: 3, Score: &score, OK: true, Time: time.UnixMilli(3000)},
+	}
+	buffer := parquet.NewGenericBuffer[Row]()
+	if _, err := buffer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+
+	output := new(bytes.Buffer)
+	writer, err := parquet.NewArrowWriter(output, buffer.Schema(), parquet.ArrowFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parquet.CopyRows(writer, buffer.Rows()); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file := output.Bytes()
+
+	footerSize := int(binary.LittleEndian.Uint32(file[len(file)-10:]))
+	footer := fbRoot(file[len(file)-10-footerSize : len(file)-10])
+	messages := decodeArrowMessages(t, file[8:len(file)-10-footerSize])
+	if len(messages) != 2 {
+		t.Fatalf("wrong number of messages: want=2 got=%d", len(messages))
+	}
+
+	// Message.header_type of the Arrow format: 1 is Schema, 3 is RecordBatch.
+	schema, batch := messages[0], messages[1]
+	if headerType := schema.metadata.uint8(1); headerType != 1 {
+		t.Fatalf("first message is not a schema: header type %d", headerType)
+	}
+	if headerType := batch.metadata.uint8(1); headerType != 3 {
+		t.Fatalf("second message is not a record batch: header type %d", headerType)
+	}
+
+	type field struct {
+		name     string
+		nullable bool
+		typeID   uint8
+		params   []int64 // integer fields of the type table, in order
+		timezone string
+	}
+	fields := []field{
+		{name: "id", typeID: 2, params: []int64{64, 1}},                 // Int(64, signed)
+		{name: "name", typeID: 5},                                       // Utf8
+		{name: "score", nullable: true, typeID: 3, params: []int64{2}},  // FloatingPoint(DOUBLE)
+		{name: "ok", typeID: 6},                                         // Bool
+		{name: "time", typeID: 10, params: []int64{1}, timezone: "UTC"}, // Timestamp(MILLISECOND)
+		{name: "data", nullable: true, typeID: 4},                       // Binary
+	}
+
+	checkSchema := func(source string, schema fbTableReader) {
+		t.Helper()
+		got := schema.tables(1)
+		if len(got) != len(fields) {
+			t.Fatalf("%s: wrong number of fields: want=%d got=%d", source, len(fields), len(got))
+		}
+		for i, want := range fields {
+			f := got[i]
+			if name := f.string(0); name != want.name {
+				t.Errorf("%s: field %d: name mismatch: want=%q got=%q", source, i, want.name, name)
+			}
+			if nullable := f.bool(1); nullable != want.nullable {
+				t.Errorf("%s: field %q: nullable mismatch: want=%t got=%t", source, want.name, want.nullable, nullable)
+			}
+			if typeID := f.uint8(2); typeID != want.typeID {
+				t.Errorf("%s: field %q: type mismatch: want=%d got=%d", source, want.name, want.typeID, typeID)
+			}
+			typ := f.table(3)
+			switch want.typeID {
+			case 2: // Int
+				if bitWidth, signed := typ.int32(0), typ.bool(1); int64(bitWidth) != want.params[0] || signed != (want.params[1] != 0) {
+					t.Errorf("%s: field %q: int type mismatch: bitWidth=%d signed=%t", source, want.name, bitWidth, signed)
+				}
+			case 3, 10: // FloatingPoint, Timestamp
+				if param := typ.int16(0); int64(param) != want.params[0] {
+					t.Errorf("%s: field %q: type parameter mismatch: want=%d got=%d", source, want.name, want.params[0], param)
+				}
+			}
+			if want.timezone != "" {
+				if timezone := typ.string(1); timezone != want.timezone {
+					t.Errorf("%s: field %q: timezone mismatch: want=%q got=%q", source, want.name, want.timezone, timezone)
+				}
+			}
+		}
+	}
+	checkSchema("schema message", schema.metadata.table(2))
+	checkSchema("file footer", footer.table(1))
+
+	recordBatch := batch.metadata.table(2)
+	if length := recordBatch.int64(0); length != int64(len(rows)) {
+		t.Errorf("record batch length mismatch: want=%d got=%d", len(rows), length)
+	}
+	if bodyLength := batch.metadata.int64(3); bodyLength != int64(len(batch.body)) {
+		t.Errorf("record batch body length mismatch: want=%d got=%d", len(batch.body), bodyLength)
+	}
+
+	nodes := recordBatch.structs(1, 16)
+	wantNullCounts := []uint64{0, 0, 1, 0, 0, 2}
+	if len(nodes) != len(wantNullCounts) {
+		t.Fatalf("wrong number of field nodes: want=%d got=%d", len(wantNullCounts), len(nodes))
+	}
+	for i, node := range nodes {
+		length, nullCount := binary.LittleEndian.Uint64(node), binary.LittleEndian.Uint64(node[8:])
+		if length != uint64(len(rows)) || nullCount != wantNullCounts[i] {
+			t.Errorf("field node %d mismatch: want=(%d,%d) got=(%d,%d)", i, len(rows), wantNullCounts[i], length, nullCount)
+		}
+	}
+
+	var buffers [][]byte
+	end := uint64(0)
+	for i, b := range recordBatch.structs(2, 16) {
+		offset, length := binary.LittleEndian.Uint64(b), binary.LittleEndian.Uint64(b[8:])
+		if offset%8 != 0 || offset < end || offset+length > uint64(len(batch.body)) {
+			t.Fatalf("buffer %d: invalid offset and length: offset=%d length=%d end=%d body=%d", i, offset, length, end, len(batch.body))
+		}
+		end = offset + length
+		buffers = append(buffers, batch.body[offset:end])
+	}
+
+	int64s := func(values ...int64) (b []byte) {
+		for _, v := range values {
+			b = binary.LittleEndian.AppendUint64(b, uint64(v))
+		}
+		return b
+	}
+	int32s := func(values ...int32) (b []byte) {
+		for _, v := range values {
+			b = binary.LittleEndian.AppendUint32(b, uint32(v))
+		}
+		return b
+	}
+	want := [][]byte{
+		// id
+		{}, int64s(1, 2, 3),
+		// name
+		{}, int32s(0, 1, 4, 4), []byte("abcd"),
+		// score
+		{0b101}, int64s(int64(math.Float64bits(score)), 0, int64(math.Float64bits(score))),
+		// ok
+		{}, {0b101},
+		// time
+		{}, int64s(1000, 2000, 3000),
+		// data
+		{0b001}, int32s(0, 2, 2, 2), []byte("xy"),
+	}
+	if len(buffers) != len(want) {
+		t.Fatalf("wrong number of buffers: want=%d got=%d", len(want), len(buffers))
+	}
+	for i := range want {
+		if !bytes.Equal(buffers[i], want[i]) {
+			t.Errorf("buffer %d mismatch:\nwant: %v\ngot:  %v", i, want[i], buffers[i])
+		}
+	}
+
+	// The footer indexes the record batch message of the file.
+	blocks := footer.structs(3, 24)
+	if len(blocks) != 1 {
+		t.Fatalf("wrong number of record batch blocks: want=1 got=%d", len(blocks))
+	}
+	offset := binary.LittleEndian.Uint64(blocks[0])
+	metaDataLength := binary.LittleEndian.Uint32(blocks[0][8:])
+	bodyLength := binary.LittleEndian.Uint64(blocks[0][16:])
+	if offset != uint64(8+batch.offset) || metaDataLength != uint32(8+len(batch.metadata.buf)) || bodyLength != uint64(len(batch.body)) {
+		t.Errorf("record batch block mismatch: offset=%d metaDataLength=%d bodyLength=%d", offset, metaDataLength, bodyLength)
+	}
+}
+
+type arrowMessage struct {
+	offset   int
+	metadata fbTableReader
+	body     []byte
+}
+
+// decodeArrowMessages decodes the encapsulated messages of an Arrow IPC stream,
+// up to the end-of-stream marker.
+func decodeArrowMessages(t *testing.T, stream []byte) []arrowMessage {
+	t.Helper()
+	var messages []arrowMessage
+	for offset := 0; ; {
+		if len(stream)-offset < 8 || binary.LittleEndian.Uint32(stream[offset:]) != 0xFFFFFFFF {
+			t.Fatalf("missing continuation marker at offset %d", offset)
+		}
+		size := int(binary.LittleEndian.Uint32(stream[offset+4:]))
+		if size == 0 {
+			if offset+8 != len(stream) {
+				t.Fatalf("%d bytes after the end-of-stream marker", len(stream)-(offset+8))
+			}
+			return messages
+		}
+		if size%8 != 0 {
+			t.Fatalf("size of message metadata at offset %d is not a multiple of 8: %d", offset, size)
+		}
+		message := arrowMessage{offset: offset, metadata: fbRoot(stream[offset+8 : offset+8+size])}
+		bodyOffset := offset + 8 + size
+		bodyLength := int(message.metadata.int64(3))
+		message.body = stream[bodyOffset : bodyOffset+bodyLength]
+		messages = append(messages, message)
+		offset = bodyOffset + bodyLength
+	}
+}
+
+// fbTableReader reads the fields of a flatbuffers table, independently of the
+// builder used by ArrowWriter. Like the verifiers of flatbuffers libraries, it
+// panics when reading values which are not aligned on their size.
+type fbTableReader struct {
+	buf []byte
+	pos int
+}
+
+func fbAligned(pos, size int) int {
+	if pos%size != 0 {
+		panic(fmt.Sprintf("flatbuffer value of %d bytes at offset %d is not aligned", size, pos))
+	}
+	return pos
+}
+
+func fbRoot(buf []byte) fbTableReader {
+	return fbTableReader{buf: buf, pos: fbAligned(int(binary.LittleEndian.Uint32(buf)), 4)}
+}
+
+func (t fbTableReader) field(id int) (int, bool) {
+	vtable := fbAligned(t.pos-int(int32(binary.LittleEndian.Uint32(t.buf[t.pos:]))), 2)
+	vtableSize := int(binary.LittleEndian.Uint16(t.buf[vtable:]))
+	if 4+2*id >= vtableSize {
+		return 0, false
+	}
+	offset := int(binary.LittleEndian.Uint16(t.buf[vtable+4+2*id:]))
+	return t.pos + offset, offset != 0
+}
+
+func (t fbTableReader) deref(id int) (int, bool) {
+	pos, ok := t.field(id)
+	if !ok {
+		return 0, false
+	}
+	return fbAligned(pos+int(binary.LittleEndian.Uint32(t.buf[fbAligned(pos, 4):])), 4), true
+}
+
+func (t fbTableReader) bool(id int) bool { return t.uint8(id) != 0 }
+
+func (t fbTableReader) uint8(id int) uint8 {
+	if pos, ok := t.field(id); ok {
+		return t.buf[pos]
+	}
+	return 0
+}
+
+func (t fbTableReader) int16(id int) int16 {
+	if pos, ok := t.field(id); ok {
+		return int16(binary.LittleEndian.Uint16(t.buf[fbAligned(pos, 2):]))
+	}
+	return 0
+}
+
+func (t fbTableReader) int32(id int) int32 {
+	if pos, ok := t.field(id); ok {
+		return int32(binary.LittleEndian.Uint32(t.buf[fbAligned(pos, 4):]))
+	}
+	return 0
+}
+
+func (t fbTableReader) int64(id int) int64 {
+	if pos, ok := t.field(id); ok {
+		return int64(binary.LittleEndian.Uint64(t.buf[fbAligned(pos, 8):]))
+	}
+	return 0
+}
+
+func (t fbTableReader) string(id int) string {
+	pos, ok := t.deref(id)
+	if !ok {
+		return ""
+	}
+	n := int(binary.LittleEndian.Uint32(t.buf[pos:]))
+	return string(t.buf[pos+4 : pos+4+n])
+}
+
+func (t fbTableReader) table(id int) fbTableReader {
+	pos, _ := t.deref(id)
+	return fbTableReader{buf: t.buf, pos: pos}
+}
+
+func (t fbTableReader) tables(id int) []fbTableReader {
+	pos, ok := t.deref(id)
+	if !ok {
+		return nil
+	}
+	tables := make([]fbTableReader, binary.LittleEndian.Uint32(t.buf[pos:]))
+	for i := range tables {
+		elem := pos + 4 + 4*i
+		tables[i] = fbTableReader{buf: t.buf, pos: fbAligned(elem+int(binary.LittleEndian.Uint32(t.buf[elem:])), 4)}
+	}
+	return tables
+}
+
+func (t fbTableReader) structs(id, size int) [][]byte {
+	pos, ok := t.deref(id)
+	if !ok {
+		return nil
+	}
+	structs := make([][]byte, binary.LittleEndian.Uint32(t.buf[pos:]))
+	for i := range structs {
+		elem := fbAligned(pos+4+size*i, 8)
+		structs[i] = t.buf[elem : elem+size]
+	}
+	return structs
+}
@@ -0,0 +1,116 @@
+package parquet
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// AtomicFile is an io.Writer which writes a file to the local file system
+// atomically: the content is written to a temporary file in the same directory,
+// which is renamed to its final path when the file is committed. Readers of the
+// path never observe a partially written file, even if the program crashes
+// while writing it.
+//
+// AtomicFile is intended to be used as output of parquet writers, for example:
+//
+//	f, err := parquet.CreateAtomicFile("data.parquet")
+//	if err != nil {
+//		...
+//	}
+//	defer f.Abort()
+//
+//	writer := parquet.NewGenericWriter[RowType](f)
+//	if _, err := writer.Write(rows); err != nil {
+//		writer.Abort()
+//		return err
+//	}
+//	if err := writer.Close(); err != nil {
+//		return err
+//	}
+//	return f.Commit()
+type AtomicFile struct {
+	file *os.File
+	path string
+}
+
+// CreateAtomicFile creates a temporary file which is renamed to path when
+// committed. The file is created with mode 0644.
+func CreateAtomicFile(path string) (*AtomicFile, error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	file, err := os.CreateTemp(dir, "."+base+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return &AtomicFile{file: file, path: path}, nil
+}
+
+// Write writes b to the temporary file.
+func (f *AtomicFile) Write(b []byte) (int, error) {
+	if f.file == nil {
+		return 0, io.ErrClosedPipe
+	}
+	return f.file.Write(b)
+}
+
+// Commit flushes the content of the temporary file to stable storage, closes
+// it, and renames it to the path of f. The file cannot be written after being
+// committed.
+func (f *AtomicFile) Commit() error {
+	if f.file == nil {
+		return io.ErrClosedPipe
+	}
+	file := f.file
+	f.file = nil
+
+	err := file.Sync()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), f.path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return syncDir(filepath.Dir(f.path))
+}
+
+// Abort closes and removes the temporary file, leaving the path of f untouched.
+//
+// Calling Abort after Commit has no effect, which allows programs to defer the
+// call to Abort after creating the file.
+func (f *AtomicFile) Abort() error {
+	if f.file == nil {
+		return nil
+	}
+	file := f.file
+	f.file = nil
+	return errors.Join(file.Close(), os.Remove(file.Name()))
+}
+
+// syncDir flushes the directory entries of dir to stable storage, making the
+// rename of files in the directory durable. Directories cannot be synced on
+// Windows, where renames are durable once they complete.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
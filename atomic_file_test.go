@@ -0,0 +1,68 @@
+package parquet_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestAtomicFile(t *testing.T) {
+	type Row struct {
+		A int64 `parquet:"a"`
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.parquet")
+
+	write := func(rows []Row, commit bool) {
+		t.Helper()
+		f, err := parquet.CreateAtomicFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Abort()
+
+		w := parquet.NewGenericWriter[Row](f)
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if !commit {
+			w.Abort()
+			return
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write([]Row{{A: 1}}, false)
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("aborted file must not exist: %v", err)
+	}
+
+	write([]Row{{A: 1}, {A: 2}}, true)
+	write([]Row{{A: 3}}, false)
+
+	rows, err := parquet.ReadFile[Row](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Row{{A: 1}, {A: 2}}; !reflect.DeepEqual(want, rows) {
+		t.Errorf("want=%+v got=%+v", want, rows)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary files were not removed: %v", entries)
+	}
+}
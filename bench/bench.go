@@ -0,0 +1,252 @@
+// Package bench contains reproducible data generators and benchmark helpers
+// that programs can use to evaluate the performance of parquet encodings and
+// compression codecs on data shaped like their own.
+//
+// The generators produce the same rows for the same seed, which allows results
+// of different configurations or versions of the parquet package to be
+// compared. The helpers are called from regular go benchmark functions:
+//
+//	func BenchmarkZstd(b *testing.B) {
+//		rows := bench.Generate(bench.HighCardinalityStrings, 1, 100e3)
+//		bench.Read(b, rows, parquet.Compression(&parquet.Zstd))
+//	}
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Generator is the signature of functions generating rows of type T from a
+// pseudo-random source.
+type Generator[T any] func(prng *rand.Rand, numRows int) []T
+
+// Generate returns numRows rows produced by gen from a pseudo-random source
+// initialized with seed. Calling Generate with the same arguments always
+// returns the same rows.
+func Generate[T any](gen Generator[T], seed int64, numRows int) []T {
+	return gen(rand.New(rand.NewSource(seed)), numRows)
+}
+
+// FlatInt is the type of rows produced by FlatInts.
+type FlatInt struct {
+	ID        int64 `parquet:"id"`
+	Timestamp int64 `parquet:"timestamp"`
+	Count     int32 `parquet:"count"`
+	Status    int32 `parquet:"status"`
+	Value     int64 `parquet:"value"`
+}
+
+// FlatInts generates rows of integer columns with the distributions commonly
+// found in event data: monotonic identifiers and timestamps, small counters,
+// a low cardinality status, and uniformly random values.
+func FlatInts(prng *rand.Rand, numRows int) []FlatInt {
+	rows := make([]FlatInt, numRows)
+	timestamp := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	for i := range rows {
+		timestamp += prng.Int63n(1000)
+		rows[i] = FlatInt{
+			ID:        int64(i),
+			Timestamp: timestamp,
+			Count:     int32(prng.Intn(100)),
+			Status:    int32(prng.Intn(4)),
+			Value:     prng.Int63(),
+		}
+	}
+
+	return rows
+}
+
+// NestedList is the type of rows produced by NestedLists.
+type NestedList struct {
+	ID    int64       `parquet:"id"`
+	Items []ListItem  `parquet:"items,list"`
+	Tags  []string    `parquet:"tags,list"`
+	Attrs []ListAttrs `parquet:"attrs"`
+}
+
+// ListItem is the type of elements of NestedList.Items.
+type ListItem struct {
+	Key   string  `parquet:"key,dict"`
+	Value float64 `parquet:"value"`
+}
+
+// ListAttrs is the type of elements of NestedList.Attrs.
+type ListAttrs struct {
+	Name   string  `parquet:"name"`
+	Values []int32 `parquet:"values"`
+}
+
+// NestedLists generates rows of repeated and nested columns of varying lengths,
+// including empty lists, exercising the repetition and definition levels.
+func NestedLists(prng *rand.Rand, numRows int) []NestedList {
+	rows := make([]NestedList, numRows)
+
+	for i := range rows {
+		row := NestedList{ID: int64(i)}
+
+		if n := prng.Intn(8); n > 0 {
+			row.Items = make([]ListItem, n)
+			for j := range row.Items {
+				row.Items[j] = ListItem{
+					Key:   fmt.Sprintf("key-%d", prng.Intn(32)),
+					Value: prng.Float64(),
+				}
+			}
+		}
+
+		if n := prng.Intn(4); n > 0 {
+			row.Tags = make([]string, n)
+			for j := range row.Tags {
+				row.Tags[j] = randomString(prng, 4+prng.Intn(8))
+			}
+		}
+
+		if n := prng.Intn(3); n > 0 {
+			row.Attrs = make([]ListAttrs, n)
+			for j := range row.Attrs {
+				values := make([]int32, prng.Intn(5))
+				for k := range values {
+					values[k] = prng.Int31n(1000)
+				}
+				row.Attrs[j] = ListAttrs{
+					Name:   randomString(prng, 6),
+					Values: values,
+				}
+			}
+		}
+
+		rows[i] = row
+	}
+
+	return rows
+}
+
+// HighCardinalityString is the type of rows produced by HighCardinalityStrings.
+type HighCardinalityString struct {
+	UUID    string `parquet:"uuid"`
+	URL     string `parquet:"url"`
+	Message string `parquet:"message"`
+	Country string `parquet:"country"`
+}
+
+// HighCardinalityStrings generates rows of string columns which are mostly
+// unique, such as identifiers, URLs, or free-form text, as well as a low
+// cardinality column for comparison.
+func HighCardinalityStrings(prng *rand.Rand, numRows int) []HighCardinalityString {
+	rows := make([]HighCardinalityString, numRows)
+	countries := [...]string{"US", "FR", "DE", "JP", "BR", "IN", "CN", "GB"}
+
+	for i := range rows {
+		var uuid [16]byte
+		prng.Read(uuid[:])
+
+		rows[i] = HighCardinalityString{
+			UUID:    fmt.Sprintf("%x-%x-%x-%x-%x", uuid[:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]),
+			URL:     fmt.Sprintf("https://example.com/%s/%s?page=%d", randomString(prng, 8), randomString(prng, 12), prng.Intn(1000)),
+			Message: randomString(prng, 20+prng.Intn(200)),
+			Country: countries[prng.Intn(len(countries))],
+		}
+	}
+
+	return rows
+}
+
+func randomString(prng *rand.Rand, n int) string {
+	const characters = "1234567890qwertyuiopasdfghjklzxcvbnm"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = characters[prng.Intn(len(characters))]
+	}
+	return string(b)
+}
+
+// Encode writes rows to an in-memory parquet file configured with options and
+// returns the content of the file.
+func Encode[T any](rows []T, options ...parquet.WriterOption) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, options...); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Write benchmarks writing rows to parquet files configured with options.
+//
+// In addition to the default metrics, the benchmark reports the number of rows
+// written per second and the average size of rows in the output file, which
+// helps compare the compression ratios of different configurations.
+func Write[T any](b *testing.B, rows []T, options ...parquet.WriterOption) {
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[T](buffer, options...)
+
+	b.ResetTimer()
+	start := time.Now()
+
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+		writer.Reset(buffer)
+
+		if _, err := writer.Write(rows); err != nil {
+			b.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	seconds := time.Since(start).Seconds()
+	reportRowMetrics(b, len(rows), buffer.Len(), seconds)
+}
+
+// Read benchmarks reading rows from a parquet file configured with options.
+//
+// The file is written before the benchmark starts. In addition to the default
+// metrics, the benchmark reports the number of rows read per second and the
+// average size of rows in the file.
+func Read[T any](b *testing.B, rows []T, options ...parquet.WriterOption) {
+	data, err := Encode(rows, options...)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	values := make([]T, 1024)
+	reader := parquet.NewGenericReader[T](bytes.NewReader(data))
+	defer reader.Close()
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	start := time.Now()
+
+	for i := 0; i < b.N; i++ {
+		reader.Reset()
+
+		for numRows := 0; numRows < len(rows); {
+			n, err := reader.Read(values)
+			numRows += n
+			if err != nil {
+				if err == io.EOF && numRows == len(rows) {
+					break
+				}
+				b.Fatalf("reading rows: %d/%d: %v", numRows, len(rows), err)
+			}
+		}
+	}
+
+	seconds := time.Since(start).Seconds()
+	reportRowMetrics(b, len(rows), len(data), seconds)
+}
+
+func reportRowMetrics(b *testing.B, numRows, fileSize int, seconds float64) {
+	b.ReportMetric(float64(numRows*b.N)/seconds, "row/s")
+	if numRows > 0 {
+		b.ReportMetric(float64(fileSize)/float64(numRows), "byte/row")
+	}
+}
@@ -0,0 +1,169 @@
+package bench_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/bench"
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+func TestGenerate(t *testing.T) {
+	testGenerate(t, bench.FlatInts)
+	testGenerate(t, bench.NestedLists)
+	testGenerate(t, bench.HighCardinalityStrings)
+}
+
+func testGenerate[T any](t *testing.T, gen bench.Generator[T]) {
+	const numRows = 100
+	rows := bench.Generate(gen, 1, numRows)
+	if len(rows) != numRows {
+		t.Fatalf("number of rows mismatch: want=%d got=%d", numRows, len(rows))
+	}
+	if !reflect.DeepEqual(rows, bench.Generate(gen, 1, numRows)) {
+		t.Errorf("%T: rows generated with the same seed are not equal", rows)
+	}
+	if reflect.DeepEqual(rows, bench.Generate(gen, 2, numRows)) {
+		t.Errorf("%T: rows generated with different seeds are equal", rows)
+	}
+
+	data, err := bench.Encode(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := parquet.Read[T](bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != numRows {
+		t.Fatalf("number of rows read mismatch: want=%d got=%d", numRows, len(values))
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	b.Run("flat-ints", func(b *testing.B) {
+		bench.Write(b, bench.Generate(bench.FlatInts, 1, 10e3))
+	})
+	b.Run("nested-lists", func(b *testing.B) {
+		bench.Write(b, bench.Generate(bench.NestedLists, 1, 10e3))
+	})
+	b.Run("high-cardinality-strings", func(b *testing.B) {
+		bench.Write(b, bench.Generate(bench.HighCardinalityStrings, 1, 10e3))
+	})
+	b.Run("nested-rows", func(b *testing.B) {
+		shape := bench.Shape{Depth: 3, FanOut: 3, NullRate: 0.1, Cardinality: 100}
+		bench.WriteRows(b, shape.Schema(), bench.Generate(bench.Nested(shape), 1, 10e3))
+	})
+}
+
+func BenchmarkRead(b *testing.B) {
+	for _, codec := range []struct {
+		name  string
+		codec parquet.WriterOption
+	}{
+		{"uncompressed", parquet.Compression(&parquet.Uncompressed)},
+		{"snappy", parquet.Compression(&parquet.Snappy)},
+		{"zstd", parquet.Compression(&parquet.Zstd)},
+	} {
+		b.Run(codec.name, func(b *testing.B) {
+			b.Run("flat-ints", func(b *testing.B) {
+				bench.Read(b, bench.Generate(bench.FlatInts, 1, 10e3), codec.codec)
+			})
+			b.Run("nested-lists", func(b *testing.B) {
+				bench.Read(b, bench.Generate(bench.NestedLists, 1, 10e3), codec.codec)
+			})
+			b.Run("high-cardinality-strings", func(b *testing.B) {
+				bench.Read(b, bench.Generate(bench.HighCardinalityStrings, 1, 10e3), codec.codec)
+			})
+		})
+	}
+}
+
+func TestNested(t *testing.T) {
+	for _, shape := range []bench.Shape{
+		{},
+		{Depth: 1, FanOut: 3},
+		{Depth: 3, FanOut: 4, MaxElements: 2, NullRate: 0.2, Cardinality: 10},
+	} {
+		t.Run(fmt.Sprintf("%+v", shape), func(t *testing.T) {
+			const numRows = 100
+			rows := bench.Generate(bench.Nested(shape), 1, numRows)
+			if !reflect.DeepEqual(rows, bench.Generate(bench.Nested(shape), 1, numRows)) {
+				t.Error("rows generated with the same seed are not equal")
+			}
+
+			schema := shape.Schema()
+			data, err := bench.EncodeRows(schema, rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader := parquet.NewReader(bytes.NewReader(data), schema)
+			values := make([]parquet.Row, numRows)
+			n, _ := reader.ReadRows(values)
+			if n != numRows {
+				t.Fatalf("number of rows read mismatch: want=%d got=%d", numRows, n)
+			}
+			for i := range rows {
+				if !rows[i].Equal(values[i]) {
+					t.Fatalf("row %d mismatch:\nwant = %v\ngot  = %v", i, rows[i], values[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRows(t *testing.T) {
+	type Item struct {
+		Key   string  `parquet:"key"`
+		Value *uint16 `parquet:"value,optional"`
+	}
+	type Row struct {
+		ID        int64             `parquet:"id"`
+		Flag      bool              `parquet:"flag"`
+		Small     int32             `parquet:"small,int(8)"`
+		Date      int32             `parquet:"date,date"`
+		Time      time.Time         `parquet:"time,timestamp(microsecond)"`
+		Price     int64             `parquet:"price,decimal(2:10)"`
+		Amount    [16]byte          `parquet:"amount,decimal(4:30)"`
+		UUID      [16]byte          `parquet:"uuid,uuid"`
+		Doc       string            `parquet:"doc,json"`
+		Ratio     *float32          `parquet:"ratio,optional"`
+		Items     []Item            `parquet:"items,list"`
+		Labels    map[string]string `parquet:"labels"`
+		Data      []byte            `parquet:"data"`
+		Timestamp deprecated.Int96  `parquet:"timestamp"`
+	}
+
+	for _, schema := range []*parquet.Schema{
+		parquet.SchemaOf(Row{}),
+		parquet.SchemaOf(bench.NestedList{}),
+		bench.Shape{Depth: 2, FanOut: 3}.Schema(),
+	} {
+		t.Run(schema.Name(), func(t *testing.T) {
+			const numRows = 100
+			rows := bench.GenerateRows(schema, numRows, 1)
+			if !reflect.DeepEqual(rows, bench.GenerateRows(schema, numRows, 1)) {
+				t.Error("rows generated with the same seed are not equal")
+			}
+
+			data, err := bench.EncodeRows(schema, rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader := parquet.NewReader(bytes.NewReader(data), schema)
+			values := make([]parquet.Row, numRows)
+			if n, _ := reader.ReadRows(values); n != numRows {
+				t.Fatalf("number of rows read mismatch: want=%d got=%d", numRows, n)
+			}
+			for i := range rows {
+				if !rows[i].Equal(values[i]) {
+					t.Fatalf("row %d mismatch:\nwant = %v\ngot  = %v", i, rows[i], values[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Shape configures the schema and the distribution of values of the nested
+// datasets produced by Nested.
+//
+// Each group of the schema has FanOut fields: a leaf column, followed by groups
+// alternating between repeated and optional groups, down to Depth levels of
+// nesting where groups only have leaf columns. Leaf columns are optional and
+// cycle through the INT64, string, and DOUBLE types.
+type Shape struct {
+	// Number of levels of groups nested below the root of the schema. A zero
+	// depth produces a flat schema.
+	Depth int
+	// Number of fields of each group. Zero defaults to 2.
+	FanOut int
+	// Maximum number of elements of repeated groups, the number of elements
+	// is uniformly distributed between zero and the maximum. Zero defaults
+	// to 4.
+	MaxElements int
+	// Probability that optional groups and leaf values are null.
+	NullRate float64
+	// Number of distinct values of each leaf column. Zero means that values
+	// are drawn from the full range of the column type.
+	Cardinality int
+}
+
+// Schema returns the schema of rows of the shape.
+func (s Shape) Schema() *parquet.Schema {
+	return parquet.NewSchema("nested", s.root().node)
+}
+
+// Nested returns a generator of rows of the given shape, which have the
+// schema returned by shape.Schema. The rows can be written with a
+// parquet.Writer, or reconstructed into Go values with the schema, for
+// example to benchmark programs that build rows with a parquet.RowBuilder:
+//
+//	shape := bench.Shape{Depth: 3, FanOut: 3, NullRate: 0.1, Cardinality: 100}
+//	rows := bench.Generate(bench.Nested(shape), 1, 10e3)
+//	bench.WriteRows(b, shape.Schema(), rows)
+func Nested(shape Shape) Generator[parquet.Row] {
+	root := shape.root()
+	return func(prng *rand.Rand, numRows int) []parquet.Row {
+		g := &nestedGenerator{shape: shape, prng: prng}
+		rows := make([]parquet.Row, numRows)
+		for i := range rows {
+			g.row = nil
+			g.group(root, 0, 0, 0)
+			// Values are generated in the order of the fields of nested
+			// groups, rows have the values of each column grouped together.
+			sort.SliceStable(g.row, func(i, j int) bool {
+				return g.row[i].Column() < g.row[j].Column()
+			})
+			rows[i] = g.row
+		}
+		return rows
+	}
+}
+
+// shapeNode is a node of the schema of a Shape, with the indexes of the leaf
+// columns nested in it.
+type shapeNode struct {
+	node     parquet.Node
+	repeated bool
+	kind     parquet.Kind
+	fields   []*shapeNode
+	columns  []int
+}
+
+func (s Shape) root() *shapeNode {
+	if s.FanOut <= 0 {
+		s.FanOut = 2
+	}
+	root := s.group(0)
+	numColumns := 0
+	root.assignColumns(&numColumns)
+	root.node = parquet.Group(root.group())
+	return root
+}
+
+func (s Shape) group(depth int) *shapeNode {
+	group := new(shapeNode)
+	for i := 0; i < s.FanOut; i++ {
+		var field *shapeNode
+		switch {
+		case i == 0 || depth == s.Depth:
+			kinds := [...]parquet.Kind{parquet.Int64, parquet.ByteArray, parquet.Double}
+			field = &shapeNode{kind: kinds[i%len(kinds)]}
+			switch field.kind {
+			case parquet.Int64:
+				field.node = parquet.Leaf(parquet.Int64Type)
+			case parquet.ByteArray:
+				field.node = parquet.String()
+			default:
+				field.node = parquet.Leaf(parquet.DoubleType)
+			}
+			field.node = parquet.Optional(field.node)
+		default:
+			field = s.group(depth + 1)
+			if field.repeated = i%2 == 1; field.repeated {
+				field.node = parquet.Repeated(parquet.Group(field.group()))
+			} else {
+				field.node = parquet.Optional(parquet.Group(field.group()))
+			}
+		}
+		group.fields = append(group.fields, field)
+	}
+	return group
+}
+
+func (n *shapeNode) group() parquet.Group {
+	group := make(parquet.Group, len(n.fields))
+	for i, field := range n.fields {
+		group[fmt.Sprintf("field%d", i)] = field.node
+	}
+	return group
+}
+
+// assignColumns sets the indexes of the leaf columns nested in n, in the order
+// of the columns of the schema, where the fields of groups are sorted by name.
+func (n *shapeNode) assignColumns(numColumns *int) {
+	if n.fields == nil {
+		n.columns = []int{*numColumns}
+		*numColumns++
+		return
+	}
+	fields := make([]*shapeNode, len(n.fields))
+	copy(fields, n.fields)
+	names := make(map[*shapeNode]string, len(fields))
+	for i, field := range fields {
+		names[field] = fmt.Sprintf("field%d", i)
+	}
+	sort.Slice(fields, func(i, j int) bool { return names[fields[i]] < names[fields[j]] })
+	for _, field := range fields {
+		field.assignColumns(numColumns)
+		n.columns = append(n.columns, field.columns...)
+	}
+}
+
+type nestedGenerator struct {
+	shape Shape
+	prng  *rand.Rand
+	row   parquet.Row
+}
+
+func (g *nestedGenerator) null() bool {
+	return g.shape.NullRate > 0 && g.prng.Float64() < g.shape.NullRate
+}
+
+// group generates the values of the fields of a group present at the given
+// levels. The repetition level of the first value of each field is rep, and
+// the values of the following elements of repeated fields have a repetition
+// level of maxRep+1.
+func (g *nestedGenerator) group(n *shapeNode, rep, def, maxRep int) {
+	for _, field := range n.fields {
+		switch {
+		case field.fields == nil:
+			if g.null() {
+				g.nulls(field, rep, def)
+			} else {
+				g.row = append(g.row, g.value(field.kind).Level(rep, def+1, field.columns[0]))
+			}
+		case field.repeated:
+			numElements := g.prng.Intn(g.maxElements() + 1)
+			if numElements == 0 {
+				g.nulls(field, rep, def)
+			}
+			for i := 0; i < numElements; i++ {
+				r := rep
+				if i > 0 {
+					r = maxRep + 1
+				}
+				g.group(field, r, def+1, maxRep+1)
+			}
+		default:
+			if g.null() {
+				g.nulls(field, rep, def)
+			} else {
+				g.group(field, rep, def+1, maxRep)
+			}
+		}
+	}
+}
+
+func (g *nestedGenerator) nulls(n *shapeNode, rep, def int) {
+	for _, columnIndex := range n.columns {
+		g.row = append(g.row, parquet.Value{}.Level(rep, def, columnIndex))
+	}
+}
+
+func (g *nestedGenerator) maxElements() int {
+	if g.shape.MaxElements <= 0 {
+		return 4
+	}
+	return g.shape.MaxElements
+}
+
+func (g *nestedGenerator) value(kind parquet.Kind) parquet.Value {
+	v := g.prng.Int63()
+	if g.shape.Cardinality > 0 {
+		v = g.prng.Int63n(int64(g.shape.Cardinality))
+	}
+	switch kind {
+	case parquet.Int64:
+		return parquet.Int64Value(v)
+	case parquet.ByteArray:
+		return parquet.ByteArrayValue([]byte(fmt.Sprintf("value-%d", v)))
+	default:
+		return parquet.DoubleValue(float64(v) / 100)
+	}
+}
+
+// EncodeRows writes rows of schema to an in-memory parquet file configured with
+// options and returns the content of the file.
+func EncodeRows(schema *parquet.Schema, rows []parquet.Row, options ...parquet.WriterOption) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, append([]parquet.WriterOption{schema}, options...)...)
+	if _, err := writer.WriteRows(rows); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// WriteRows benchmarks writing rows of schema to parquet files configured with
+// options, like Write does for rows of Go types.
+func WriteRows(b *testing.B, schema *parquet.Schema, rows []parquet.Row, options ...parquet.WriterOption) {
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, append([]parquet.WriterOption{schema}, options...)...)
+
+	b.ResetTimer()
+	start := time.Now()
+
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+		writer.Reset(buffer)
+
+		if _, err := writer.WriteRows(rows); err != nil {
+			b.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	seconds := time.Since(start).Seconds()
+	reportRowMetrics(b, len(rows), buffer.Len(), seconds)
+}
@@ -0,0 +1,254 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// GenerateRows returns numRows random rows of schema, produced from a
+// pseudo-random source initialized with seed. Calling GenerateRows with the
+// same arguments always returns the same rows.
+//
+// The rows are valid for any schema: optional fields are null one time out of
+// ten, repeated fields have between zero and four elements, and values are in
+// the range of the logical type of their column (e.g. strings are valid UTF-8,
+// JSON columns hold JSON documents, and decimals fit their precision). Rows
+// can be written with a parquet.Writer, for example to load test programs or
+// to fuzz readers and row builders:
+//
+//	rows := bench.GenerateRows(schema, 10e3, 1)
+//	data, err := bench.EncodeRows(schema, rows)
+func GenerateRows(schema *parquet.Schema, numRows int, seed int64) []parquet.Row {
+	g := &rowGenerator{prng: rand.New(rand.NewSource(seed))}
+	rows := make([]parquet.Row, numRows)
+	for i := range rows {
+		g.row, g.columnIndex = nil, 0
+		g.group(schema, 0, 0, 0)
+		rows[i] = g.sortRow()
+	}
+	return rows
+}
+
+const (
+	generatedNullRate    = 0.1
+	generatedMaxElements = 4
+)
+
+type rowGenerator struct {
+	prng        *rand.Rand
+	row         parquet.Row
+	columnIndex int
+}
+
+// group generates the values of the fields of node present at the given
+// levels, following the same conventions as nestedGenerator.group.
+func (g *rowGenerator) group(node parquet.Node, rep, def, maxRep int) {
+	for _, field := range node.Fields() {
+		g.field(field, rep, def, maxRep)
+	}
+}
+
+func (g *rowGenerator) field(node parquet.Node, rep, def, maxRep int) {
+	switch {
+	case node.Repeated():
+		numElements := g.prng.Intn(generatedMaxElements + 1)
+		if numElements == 0 {
+			g.nulls(node, rep, def)
+			return
+		}
+		start := g.columnIndex
+		for i := 0; i < numElements; i++ {
+			r := rep
+			if i > 0 {
+				r = maxRep + 1
+			}
+			g.columnIndex = start
+			g.element(node, r, def+1, maxRep+1)
+		}
+	case node.Optional():
+		if g.prng.Float64() < generatedNullRate || isNullType(node) {
+			g.nulls(node, rep, def)
+		} else {
+			g.element(node, rep, def+1, maxRep)
+		}
+	default:
+		g.element(node, rep, def, maxRep)
+	}
+}
+
+func (g *rowGenerator) element(node parquet.Node, rep, def, maxRep int) {
+	if node.Leaf() {
+		g.row = append(g.row, g.value(node.Type()).Level(rep, def, g.columnIndex))
+		g.columnIndex++
+	} else {
+		g.group(node, rep, def, maxRep)
+	}
+}
+
+func (g *rowGenerator) nulls(node parquet.Node, rep, def int) {
+	for n := numLeafColumns(node); n > 0; n-- {
+		g.row = append(g.row, parquet.Value{}.Level(rep, def, g.columnIndex))
+		g.columnIndex++
+	}
+}
+
+// sortRow groups the values of each column together, preserving the order of
+// values within columns.
+func (g *rowGenerator) sortRow() parquet.Row {
+	numColumns := 0
+	for _, v := range g.row {
+		if c := v.Column() + 1; c > numColumns {
+			numColumns = c
+		}
+	}
+	row := make(parquet.Row, 0, len(g.row))
+	for c := 0; c < numColumns; c++ {
+		for _, v := range g.row {
+			if v.Column() == c {
+				row = append(row, v)
+			}
+		}
+	}
+	return row
+}
+
+func numLeafColumns(node parquet.Node) int {
+	if node.Leaf() {
+		return 1
+	}
+	n := 0
+	for _, field := range node.Fields() {
+		n += numLeafColumns(field)
+	}
+	return n
+}
+
+func isNullType(node parquet.Node) bool {
+	lt := node.Type().LogicalType()
+	return node.Leaf() && lt != nil && lt.Unknown != nil
+}
+
+func (g *rowGenerator) value(t parquet.Type) parquet.Value {
+	prng := g.prng
+	lt := t.LogicalType()
+	if lt == nil {
+		lt = new(format.LogicalType)
+	}
+
+	switch t.Kind() {
+	case parquet.Boolean:
+		return parquet.BooleanValue(prng.Intn(2) == 1)
+
+	case parquet.Int32:
+		switch {
+		case lt.Integer != nil:
+			return parquet.Int32Value(int32(g.integer(lt.Integer)))
+		case lt.Date != nil:
+			// Days between 1970 and 2100.
+			return parquet.Int32Value(prng.Int31n(47482))
+		case lt.Time != nil:
+			return parquet.Int32Value(prng.Int31n(int32(24 * time.Hour / time.Millisecond)))
+		case lt.Decimal != nil:
+			return parquet.Int32Value(int32(g.decimal(lt.Decimal.Precision)))
+		}
+		return parquet.Int32Value(int32(prng.Uint32()))
+
+	case parquet.Int64:
+		switch {
+		case lt.Integer != nil:
+			return parquet.Int64Value(g.integer(lt.Integer))
+		case lt.Time != nil:
+			return parquet.Int64Value(prng.Int63n(int64(24*time.Hour) / int64(timeUnit(lt.Time.Unit))))
+		case lt.Timestamp != nil:
+			// Instants between 1970 and 2100.
+			return parquet.Int64Value(prng.Int63n(int64(47482*24*time.Hour) / int64(timeUnit(lt.Timestamp.Unit))))
+		case lt.Decimal != nil:
+			return parquet.Int64Value(g.decimal(lt.Decimal.Precision))
+		}
+		return parquet.Int64Value(int64(prng.Uint64()))
+
+	case parquet.Int96:
+		return parquet.Int96Value(deprecated.TimeToInt96(time.Unix(prng.Int63n(4102444800), prng.Int63n(1e9))))
+
+	case parquet.Float:
+		return parquet.FloatValue(float32(prng.NormFloat64() * 1000))
+
+	case parquet.Double:
+		return parquet.DoubleValue(prng.NormFloat64() * 1000)
+
+	case parquet.ByteArray:
+		switch {
+		case lt.UTF8 != nil, lt.Enum != nil:
+			return parquet.ByteArrayValue([]byte(randomString(prng, prng.Intn(16))))
+		case lt.Json != nil:
+			return parquet.ByteArrayValue([]byte(fmt.Sprintf(`{"key":%q,"value":%d}`, randomString(prng, 8), prng.Intn(1000))))
+		case lt.Decimal != nil:
+			return parquet.ByteArrayValue(bigEndian(g.decimal(minInt32(lt.Decimal.Precision, 18)), 8))
+		}
+		b := make([]byte, prng.Intn(16))
+		prng.Read(b)
+		return parquet.ByteArrayValue(b)
+
+	default: // FixedLenByteArray
+		size := t.Length()
+		if lt.Decimal != nil {
+			return parquet.FixedLenByteArrayValue(bigEndian(g.decimal(minInt32(lt.Decimal.Precision, 18)), size))
+		}
+		b := make([]byte, size)
+		prng.Read(b)
+		return parquet.FixedLenByteArrayValue(b)
+	}
+}
+
+func (g *rowGenerator) integer(t *format.IntType) int64 {
+	bits := uint(t.BitWidth)
+	if bits == 64 {
+		return int64(g.prng.Uint64())
+	}
+	if t.IsSigned {
+		return g.prng.Int63n(1<<(bits-1)) - g.prng.Int63n(1<<(bits-1))
+	}
+	return g.prng.Int63n(1 << bits)
+}
+
+// decimal returns a random unscaled decimal value of the given precision,
+// which is expected to be at most 18 digits.
+func (g *rowGenerator) decimal(precision int32) int64 {
+	limit := int64(math.Pow10(int(precision)))
+	return g.prng.Int63n(limit) - g.prng.Int63n(limit)/2
+}
+
+// bigEndian returns the two's complement big endian representation of v on
+// size bytes.
+func bigEndian(v int64, size int) []byte {
+	b := make([]byte, size)
+	for i := size - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+func timeUnit(u format.TimeUnit) time.Duration {
+	switch {
+	case u.Millis != nil:
+		return time.Millisecond
+	case u.Micros != nil:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
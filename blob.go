@@ -0,0 +1,104 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// BlobReader reads the values of a BYTE_ARRAY column chunk as io.Reader
+// instances, for example to stream large binary values of files stored in
+// parquet files without copying them.
+//
+// The readers returned by Next read directly from the buffers of the page that
+// the values belong to, so the memory footprint is bounded by the size of the
+// largest page of the column chunk rather than by the size of the values read.
+//
+// BlobReader values are not safe for concurrent use.
+type BlobReader struct {
+	pages  Pages
+	page   Page
+	values ValueReader
+	buffer []Value
+	offset int
+	reader bytes.Reader
+	err    error
+}
+
+// NewBlobReader constructs a reader of the values of the given column chunk,
+// which must be of the BYTE_ARRAY type.
+func NewBlobReader(chunk ColumnChunk) *BlobReader {
+	r := &BlobReader{buffer: make([]Value, 0, defaultValueBufferSize)}
+	if kind := chunk.Type().Kind(); kind != ByteArray {
+		r.err = fmt.Errorf("cannot read blobs from a column of type %s", kind)
+	} else {
+		r.pages = chunk.Pages()
+	}
+	return r
+}
+
+// Next returns a reader of the next value of the column chunk, or nil if the
+// value is null. The reader remains valid until the next call to Next or
+// Close.
+//
+// The method returns io.EOF when all the values have been read.
+func (r *BlobReader) Next() (io.Reader, error) {
+	for r.offset == len(r.buffer) {
+		if r.err != nil {
+			return nil, r.err
+		}
+		r.readValues()
+	}
+
+	v := r.buffer[r.offset]
+	r.offset++
+	if v.IsNull() {
+		return nil, nil
+	}
+	r.reader.Reset(v.byteArray())
+	return &r.reader, nil
+}
+
+func (r *BlobReader) readValues() {
+	if r.values == nil {
+		r.release()
+		p, err := r.pages.ReadPage()
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.page, r.values = p, p.Values()
+	}
+
+	n, err := r.values.ReadValues(r.buffer[:cap(r.buffer)])
+	r.buffer, r.offset = r.buffer[:n], 0
+	switch err {
+	case nil:
+	case io.EOF:
+		r.values = nil
+	default:
+		r.err = err
+	}
+}
+
+func (r *BlobReader) release() {
+	if r.page != nil {
+		Release(r.page)
+		r.page = nil
+	}
+	r.buffer, r.offset = r.buffer[:0], 0
+}
+
+// Close closes the reader, releasing the page that values were read from.
+func (r *BlobReader) Close() error {
+	r.release()
+	r.values = nil
+	if r.err == nil {
+		r.err = io.ErrClosedPipe
+	}
+	if pages := r.pages; pages != nil {
+		r.pages = nil
+		return pages.Close()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestBlobReader(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+		Data []byte `parquet:"data,optional"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].Name = "file"
+		if i%7 != 0 {
+			rows[i].Data = make([]byte, prng.Intn(100e3))
+			prng.Read(rows[i].Data)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256*1024)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+
+	blobs := parquet.NewBlobReader(columns[1])
+	defer blobs.Close()
+
+	for i, row := range rows {
+		r, err := blobs.Next()
+		if err != nil {
+			t.Fatalf("value %d: %v", i, err)
+		}
+		if row.Data == nil {
+			if r != nil {
+				t.Errorf("value %d: want=<nil> got=%T", i, r)
+			}
+			continue
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, row.Data) {
+			t.Errorf("value %d mismatch: want=%d bytes got=%d bytes", i, len(row.Data), len(data))
+		}
+	}
+	if _, err := blobs.Next(); err != io.EOF {
+		t.Errorf("want=%v got=%v", io.EOF, err)
+	}
+
+	if _, err := parquet.NewBlobReader(columns[0]).Next(); err != nil {
+		t.Errorf("reading a string column: %v", err)
+	}
+	if _, err := parquet.NewBlobReader(parquet.NewGenericBuffer[struct{ ID int64 }]().ColumnChunks()[0]).Next(); err == nil {
+		t.Error("expected an error when reading blobs from an INT64 column")
+	}
+}
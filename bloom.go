@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/parquet-go/parquet-go/bloom"
@@ -41,6 +42,39 @@ func (f *bloomFilter) Check(v Value) (bool, error) {
 	return f.check(&f.SectionReader, f.Size(), v.hash(f.hash))
 }
 
+// MightContain tests whether the column chunk at the given path of rowGroup
+// may contain value, using the bloom filter of the column chunk. The function
+// returns false only when the bloom filter proves that the value is absent,
+// which lets point lookups skip row groups without reading their pages.
+//
+// The value is converted to the type of the column before being checked; for
+// example, an INT64 value can be checked in an INT32 column.
+//
+// The function returns true if the column chunk has no bloom filter, for
+// example when the file was opened with SkipBloomFilters, or if value is
+// null, since bloom filters do not record null values. An error is returned if
+// path is not a leaf column of the row group, if the value cannot be converted
+// to the column type, or if reading the bloom filter failed.
+func MightContain(rowGroup RowGroup, value Value, path ...string) (bool, error) {
+	leaf, ok := rowGroup.Schema().Lookup(path...)
+	if !ok {
+		return false, fmt.Errorf("cannot check the bloom filter of column %q which is not a leaf column of the row group", columnPath(path))
+	}
+	if value.IsNull() {
+		return true, nil
+	}
+	filter := rowGroup.ColumnChunks()[leaf.ColumnIndex].BloomFilter()
+	if filter == nil {
+		return true, nil
+	}
+	typ := leaf.Node.Type()
+	value, err := typ.ConvertValue(value, kindType(value.Kind()))
+	if err != nil {
+		return false, fmt.Errorf("cannot check the bloom filter of column %q: %w", columnPath(path), err)
+	}
+	return filter.Check(value)
+}
+
 func (v Value) hash(h bloom.Hash) uint64 {
 	switch v.Kind() {
 	case Boolean:
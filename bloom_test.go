@@ -1,15 +1,132 @@
 package parquet
 
 import (
+	"bytes"
+	"fmt"
 	"math/rand"
+	"os"
+	"reflect"
 	"testing"
 
 	"github.com/parquet-go/parquet-go/bloom"
 	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/quick"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/segmentio/encoding/thrift"
 )
 
+// TestBloomFilterInterop verifies that bloom filters are framed like those
+// written by parquet-mr, which is what Spark uses to read them. The fixture
+// of the test was written by parquet-mr 1.13.
+func TestBloomFilterInterop(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/data_index_bloom_encoding_stats.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := OpenFile(bytes.NewReader(fixture), int64(len(fixture)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixtureChunk := f.RowGroups()[0].ColumnChunks()[0]
+	fixtureHeader := readBloomFilterHeader(t, fixture, fixtureChunk.(*fileColumnChunk).chunk.MetaData.BloomFilterOffset)
+
+	var values []Value
+	var nonNullRows []Row
+	rows := make([]Row, f.NumRows())
+	reader := NewReader(f)
+	n, _ := reader.ReadRows(rows)
+	for _, row := range rows[:n] {
+		if !row[0].IsNull() {
+			values = append(values, row[0])
+			nonNullRows = append(nonNullRows, row)
+		}
+	}
+	if len(values) == 0 {
+		t.Fatal("no values read from the fixture")
+	}
+	for _, value := range values {
+		if ok, err := fixtureChunk.BloomFilter().Check(value); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Errorf("value %q not found in the bloom filter written by parquet-mr", value)
+		}
+	}
+
+	// Write the same values to a file with two row groups, and verify that
+	// the bloom filters can be read using only the column metadata.
+	buffer := new(bytes.Buffer)
+	writer := NewWriter(buffer, NewSchema("schema", Group{"String": Optional(String())}),
+		BloomFilters(SplitBlockFilter(10, "String")),
+		MaxRowsPerRowGroup(int64(len(values)/2)),
+	)
+	if _, err := writer.WriteRows(nonNullRows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	output := buffer.Bytes()
+
+	written, err := OpenFile(bytes.NewReader(output), int64(len(output)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := written.Metadata()
+	if len(metadata.RowGroups) != 2 {
+		t.Fatalf("number of row groups mismatch: want=2 got=%d", len(metadata.RowGroups))
+	}
+
+	for i, rowGroup := range metadata.RowGroups {
+		chunk := &rowGroup.Columns[0].MetaData
+
+		firstPageOffset := chunk.DataPageOffset
+		if chunk.DictionaryPageOffset != 0 {
+			firstPageOffset = chunk.DictionaryPageOffset
+		}
+		if rowGroup.FileOffset != firstPageOffset {
+			t.Errorf("row group %d: file offset mismatch: want=%d got=%d", i, firstPageOffset, rowGroup.FileOffset)
+		}
+
+		offset, length := chunk.BloomFilterOffset, int64(chunk.BloomFilterLength)
+		if offset <= 0 || length <= 0 {
+			t.Fatalf("row group %d: missing bloom filter offset or length: offset=%d length=%d", i, offset, length)
+		}
+		header := readBloomFilterHeader(t, output[offset:offset+length], 0)
+		headerLength := length - int64(header.NumBytes)
+		if header.NumBytes <= 0 || header.NumBytes%bloom.BlockSize != 0 {
+			t.Errorf("row group %d: bitset size is not a multiple of the block size: %d", i, header.NumBytes)
+		}
+
+		// Apart from the size of the bitset, headers must be equal to those
+		// written by parquet-mr.
+		want := fixtureHeader
+		want.NumBytes = header.NumBytes
+		if !reflect.DeepEqual(header, want) {
+			t.Errorf("row group %d: bloom filter header mismatch:\nwant=%+v\ngot= %+v", i, want, header)
+		}
+
+		filter := bloom.SplitBlockFilter(unsafecast.Slice[bloom.Block](output[offset+headerLength : offset+length]))
+		for _, value := range values[i*len(values)/2 : (i+1)*len(values)/2] {
+			if !filter.Check(value.hash(&bloom.XXH64{})) {
+				t.Errorf("row group %d: value %q not found in the bloom filter", i, value)
+			}
+		}
+	}
+}
+
+// readBloomFilterHeader decodes the bloom filter header at the given offset of
+// data.
+func readBloomFilterHeader(t *testing.T, data []byte, offset int64) format.BloomFilterHeader {
+	t.Helper()
+	header := format.BloomFilterHeader{}
+	decoder := thrift.NewDecoder(new(thrift.CompactProtocol).NewReader(bytes.NewReader(data[offset:])))
+	if err := decoder.Decode(&header); err != nil {
+		t.Fatal(err)
+	}
+	return header
+}
+
 func TestSplitBlockFilter(t *testing.T) {
 	newFilter := func(numValues int) bloom.SplitBlockFilter {
 		return make(bloom.SplitBlockFilter, bloom.NumSplitBlocksOf(int64(numValues), 11))
@@ -171,3 +288,100 @@ func BenchmarkSplitBlockFilter(b *testing.B) {
 
 	b.SetBytes(8 * N)
 }
+
+func TestMightContain(t *testing.T) {
+	type Row struct {
+		ID    int32  `parquet:"id"`
+		Name  string `parquet:"name"`
+		Score int64  `parquet:"score"`
+	}
+
+	// Even identifiers only, so odd identifiers are within the bounds of the
+	// column chunk statistics but absent from the bloom filters.
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int32(2 * i), Name: fmt.Sprintf("name-%d", 2*i), Score: int64(i)}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := Write(buffer, rows,
+		MaxRowsPerRowGroup(50),
+		BloomFilters(SplitBlockFilter(10, "id"), SplitBlockFilter(10, "name")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	open := func(t *testing.T, options ...FileOption) *File {
+		f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	f := open(t)
+	rowGroups := f.RowGroups()
+
+	for _, test := range []struct {
+		scenario string
+		value    Value
+		path     []string
+		want     [2]bool
+	}{
+		{scenario: "present", value: ValueOf(int32(10)), path: []string{"id"}, want: [2]bool{true, false}},
+		{scenario: "absent", value: ValueOf(int32(11)), path: []string{"id"}, want: [2]bool{false, false}},
+		{scenario: "converted", value: ValueOf(int64(120)), path: []string{"id"}, want: [2]bool{false, true}},
+		{scenario: "string", value: ValueOf("name-150"), path: []string{"name"}, want: [2]bool{false, true}},
+		{scenario: "null", value: Value{}, path: []string{"id"}, want: [2]bool{true, true}},
+		{scenario: "no bloom filter", value: ValueOf(int64(-1)), path: []string{"score"}, want: [2]bool{true, true}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			for i, rowGroup := range rowGroups {
+				ok, err := MightContain(rowGroup, test.value, test.path...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ok != test.want[i] {
+					t.Errorf("row group %d: want=%t got=%t", i, test.want[i], ok)
+				}
+			}
+		})
+	}
+
+	t.Run("SkipBloomFilters", func(t *testing.T) {
+		f := open(t, SkipBloomFilters(true))
+		ok, err := MightContain(f.RowGroups()[0], ValueOf(int32(11)), "id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Error("values must be reported as maybe present when bloom filters were not loaded")
+		}
+	})
+
+	t.Run("FilterRowGroups", func(t *testing.T) {
+		for _, test := range []struct {
+			predicate Predicate
+			want      int
+		}{
+			{predicate: Eq("id", 10), want: 1},
+			{predicate: Eq("id", 11), want: 0},
+			{predicate: Eq("name", "name-151"), want: 0},
+			{predicate: Ge("id", 11), want: 2},
+		} {
+			matches, err := f.FilterRowGroups(test.predicate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matches) != test.want {
+				t.Errorf("%+v: wrong number of row groups: want=%d got=%d", test.predicate, test.want, len(matches))
+			}
+		}
+	})
+
+	for _, path := range [][]string{{"missing"}, {}} {
+		if _, err := MightContain(rowGroups[0], ValueOf(int32(0)), path...); err == nil {
+			t.Errorf("expected an error checking the bloom filter of column %q", path)
+		}
+	}
+}
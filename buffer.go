@@ -185,6 +185,11 @@ var (
 // The main purpose of the Buffer type is to provide a way to sort rows before
 // writing them to a parquet file. Buffer implements sort.Interface as a way
 // to support reordering the rows that have been written to it.
+//
+// Rows are held in columnar form in memory. A Buffer is a RowGroup, so its
+// rows can be read back with the Rows method, merged with other row groups
+// using MergeRowGroups, or flushed to a file as a row group by passing the
+// buffer to the WriteRowGroup method of writers.
 type Buffer struct {
 	config  *RowGroupConfig
 	schema  *Schema
@@ -251,9 +256,16 @@ func (buf *Buffer) configure(schema *Schema) {
 			columnType = dictionary.Type()
 		}
 
+		// Columns sorted in descending order are wrapped in a reversed column
+		// buffer, which also reverses the position of nulls, so the ordering
+		// of nulls is inverted to keep them where the sorting column places
+		// them, consistently with the comparators returned by Schema.Comparator.
 		sortingIndex := searchSortingColumn(sortingColumns, leaf.path)
-		if sortingIndex < len(sortingColumns) && sortingColumns[sortingIndex].NullsFirst() {
-			nullOrdering = nullsGoFirst
+		if sortingIndex < len(sortingColumns) {
+			sortingColumn := sortingColumns[sortingIndex]
+			if sortingColumn.NullsFirst() != sortingColumn.Descending() {
+				nullOrdering = nullsGoFirst
+			}
 		}
 
 		column := columnType.NewColumnBuffer(columnIndex, bufferCap)
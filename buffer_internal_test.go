@@ -1,8 +1,10 @@
 package parquet
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -54,3 +56,57 @@ func TestBufferPoolBucketIndexAndSizeOf(t *testing.T) {
 		})
 	}
 }
+
+func TestPageBufferPool(t *testing.T) {
+	type Row struct {
+		A *int64 `parquet:"a,optional,snappy"`
+		B string `parquet:"b,zstd"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		if i%2 == 0 {
+			a := int64(i)
+			rows[i].A = &a
+		}
+		rows[i].B = strings.Repeat("b", i%10)
+	}
+
+	output := new(bytes.Buffer)
+	if err := Write(output, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := NewPageBufferPool()
+	f, err := OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()), pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := NewGenericReader[Row](f)
+	if n, err := reader.Read(make([]Row, len(rows))); n != len(rows) {
+		t.Fatalf("want=%d got=%d (%v)", len(rows), n, err)
+	}
+	if err := reader.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pages read from the file must have been allocated from, and returned to
+	// the pool of the file.
+	numBuffers := 0
+	for i := range pool.pool.buckets {
+		for {
+			b, _ := pool.pool.buckets[i].Get().(*buffer)
+			if b == nil {
+				break
+			}
+			if b.pool != &pool.pool {
+				t.Fatal("buffer returned to the wrong pool")
+			}
+			numBuffers++
+		}
+	}
+	if numBuffers == 0 {
+		t.Error("no buffers were returned to the page buffer pool of the file")
+	}
+}
@@ -187,3 +187,30 @@ func newReaderAt(r io.ReadSeeker) io.ReaderAt {
 	}
 	return &readerAt{reader: r, offset: -1}
 }
+
+// PageBufferPool is a pool of the memory buffers that pages are read and
+// decoded into when reading parquet files.
+//
+// By default, all files share a global pool. The pool is backed by sync.Pool,
+// which caches buffers per processor, so goroutines scanning files in parallel
+// reuse buffers without contending on a lock. Programs running independent
+// scans concurrently may give each scan its own pool: scans of files with very
+// different page sizes then do not evict each other's buffers, and the memory
+// retained by the pool of a scan is released once the pool is no longer
+// referenced.
+//
+// PageBufferPool implements the FileOption interface, for example:
+//
+//	pool := parquet.NewPageBufferPool()
+//	f, err := parquet.OpenFile(input, size, pool)
+//
+// Pools are safe to use concurrently from multiple goroutines.
+type PageBufferPool struct {
+	pool bufferPool
+}
+
+// NewPageBufferPool constructs a new, empty, page buffer pool.
+func NewPageBufferPool() *PageBufferPool { return new(PageBufferPool) }
+
+// ConfigureFile satisfies the FileOption interface.
+func (p *PageBufferPool) ConfigureFile(config *FileConfig) { config.PageBufferPool = p }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"math"
 	"math/rand"
 	"reflect"
@@ -17,6 +18,88 @@ import (
 	"github.com/parquet-go/parquet-go/encoding"
 )
 
+func ExampleBuffer() {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	buffer := parquet.NewBuffer(
+		parquet.SchemaOf(Row{}),
+		parquet.SortingRowGroupConfig(
+			parquet.SortingColumns(parquet.Descending("id")),
+		),
+	)
+	for _, row := range []Row{{ID: 1, Name: "A"}, {ID: 3, Name: "C"}, {ID: 2, Name: "B"}} {
+		if err := buffer.Write(row); err != nil {
+			log.Fatal(err)
+		}
+	}
+	sort.Sort(buffer)
+
+	output := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](output)
+	if _, err := writer.WriteRowGroup(buffer); err != nil {
+		log.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	rows, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, row := range rows {
+		fmt.Println(row.ID, row.Name)
+	}
+	// Output:
+	// 3 C
+	// 2 B
+	// 1 A
+}
+
+func TestBufferSortingNullOrdering(t *testing.T) {
+	type Row struct {
+		Value *int64 `parquet:"value,optional"`
+	}
+
+	one, two := int64(1), int64(2)
+	values := []Row{{Value: &one}, {}, {Value: &two}, {}}
+	schema := parquet.SchemaOf(Row{})
+
+	for _, sortingColumn := range []parquet.SortingColumn{
+		parquet.Ascending("value"),
+		parquet.Descending("value"),
+		parquet.NullsFirst(parquet.Ascending("value")),
+		parquet.NullsFirst(parquet.Descending("value")),
+	} {
+		t.Run(fmt.Sprint(sortingColumn), func(t *testing.T) {
+			buffer := parquet.NewBuffer(schema, parquet.SortingRowGroupConfig(parquet.SortingColumns(sortingColumn)))
+			for _, value := range values {
+				if err := buffer.Write(value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			sort.Sort(buffer)
+
+			rows := make([]parquet.Row, len(values))
+			n, err := buffer.Rows().ReadRows(rows)
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			if n != len(values) {
+				t.Fatalf("number of rows mismatch: want=%d got=%d", len(values), n)
+			}
+
+			compare := schema.Comparator(sortingColumn)
+			if !sort.SliceIsSorted(rows, func(i, j int) bool { return compare(rows[i], rows[j]) < 0 }) {
+				t.Errorf("buffer rows are not ordered like the schema comparator: %v", rows)
+			}
+		})
+	}
+}
+
 func TestGenericBuffer(t *testing.T) {
 	testGenericBuffer[booleanColumn](t)
 	testGenericBuffer[int32Column](t)
@@ -0,0 +1,121 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// FileCapabilities reports the features of the parquet format that a file
+// uses, as returned by File.Capabilities.
+//
+// Applications may use the report to route files to processing paths which
+// support the features, for example when a downstream system cannot read data
+// pages of version 2.
+type FileCapabilities struct {
+	// Version of the parquet format recorded in the file metadata.
+	FormatVersion int
+	// Reports whether the file contains data pages of version 1 and 2.
+	DataPageV1 bool
+	DataPageV2 bool
+	// Reports whether the file is encrypted with a plaintext footer. Files with
+	// an encrypted footer cannot be opened, see ErrEncryptedFile.
+	Encrypted bool
+	// Reports whether at least one column chunk of the file has a column index,
+	// an offset index, or a bloom filter.
+	ColumnIndexes bool
+	OffsetIndexes bool
+	BloomFilters  bool
+	// Compression codecs and encodings used by the column chunks of the file,
+	// sorted by identifier.
+	Codecs    []format.CompressionCodec
+	Encodings []format.Encoding
+}
+
+// FormatVersion returns the version of the parquet format recorded in the
+// metadata of f.
+func (f *File) FormatVersion() int { return int(f.metadata.Version) }
+
+// Capabilities returns a report of the features of the parquet format used by
+// f.
+//
+// The versions of data pages are determined from the encoding statistics of
+// column chunks. The header of the first data page of column chunks which have
+// no encoding statistics is read from the file, in which case the method may
+// return an error if reading the header failed.
+func (f *File) Capabilities() (FileCapabilities, error) {
+	if err := f.loadColumnChunks(); err != nil {
+		return FileCapabilities{}, err
+	}
+
+	algorithm := &f.metadata.EncryptionAlgorithm
+	caps := FileCapabilities{
+		FormatVersion: f.FormatVersion(),
+		Encrypted:     algorithm.AesGcmV1 != nil || algorithm.AesGcmCtrV1 != nil,
+	}
+	codecs := make(map[format.CompressionCodec]struct{})
+	encodings := make(map[format.Encoding]struct{})
+
+	section := io.NewSectionReader(f.reader, 0, f.size)
+	rbuf, rbufpool := getBufioReader(section, f.config.ReadBufferSize)
+	defer putBufioReader(rbuf, rbufpool)
+	decoder := thrift.NewDecoder(f.protocol.NewReader(rbuf))
+
+	for i := range f.metadata.RowGroups {
+		for j := range f.metadata.RowGroups[i].Columns {
+			chunk := &f.metadata.RowGroups[i].Columns[j]
+			metadata := &chunk.MetaData
+
+			caps.ColumnIndexes = caps.ColumnIndexes || chunk.ColumnIndexOffset > 0
+			caps.OffsetIndexes = caps.OffsetIndexes || chunk.OffsetIndexOffset > 0
+			caps.BloomFilters = caps.BloomFilters || metadata.BloomFilterOffset > 0
+			caps.Encrypted = caps.Encrypted ||
+				chunk.CryptoMetadata.EncryptionWithFooterKey != nil ||
+				chunk.CryptoMetadata.EncryptionWithColumnKey != nil
+
+			codecs[metadata.Codec] = struct{}{}
+			for _, encoding := range metadata.Encoding {
+				encodings[encoding] = struct{}{}
+			}
+
+			if len(metadata.EncodingStats) == 0 && metadata.NumValues > 0 {
+				section.Seek(metadata.DataPageOffset, io.SeekStart)
+				rbuf.Reset(section)
+
+				header := format.PageHeader{}
+				if err := decoder.Decode(&header); err != nil {
+					return FileCapabilities{}, fmt.Errorf("decoding header of first data page of row group %d column %d: %w", i, j, err)
+				}
+				caps.addPageType(header.Type)
+			}
+			for _, stats := range metadata.EncodingStats {
+				caps.addPageType(stats.PageType)
+			}
+		}
+	}
+
+	caps.Codecs = make([]format.CompressionCodec, 0, len(codecs))
+	for codec := range codecs {
+		caps.Codecs = append(caps.Codecs, codec)
+	}
+	sort.Slice(caps.Codecs, func(i, j int) bool { return caps.Codecs[i] < caps.Codecs[j] })
+
+	caps.Encodings = make([]format.Encoding, 0, len(encodings))
+	for encoding := range encodings {
+		caps.Encodings = append(caps.Encodings, encoding)
+	}
+	sort.Slice(caps.Encodings, func(i, j int) bool { return caps.Encodings[i] < caps.Encodings[j] })
+	return caps, nil
+}
+
+func (caps *FileCapabilities) addPageType(pageType format.PageType) {
+	switch pageType {
+	case format.DataPage:
+		caps.DataPageV1 = true
+	case format.DataPageV2:
+		caps.DataPageV2 = true
+	}
+}
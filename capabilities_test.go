@@ -0,0 +1,73 @@
+package parquet_test
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestFileCapabilities(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,zstd"`
+		Name string `parquet:"name,dict"`
+	}
+
+	rows := []Row{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "A"}}
+
+	for _, version := range []int{1, 2} {
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows,
+			parquet.DataPageVersion(version),
+			parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		caps, err := f.Capabilities()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := parquet.FileCapabilities{
+			FormatVersion: f.FormatVersion(),
+			DataPageV1:    version == 1,
+			DataPageV2:    version == 2,
+			ColumnIndexes: true,
+			OffsetIndexes: true,
+			BloomFilters:  true,
+			Codecs:        []format.CompressionCodec{format.Uncompressed, format.Zstd},
+			Encodings:     []format.Encoding{format.Plain, format.RLEDictionary},
+		}
+		if !reflect.DeepEqual(caps, want) {
+			t.Errorf("capabilities of file with data pages of version %d mismatch:\nwant: %+v\ngot:  %+v", version, want, caps)
+		}
+	}
+
+	for _, path := range testdataFiles {
+		t.Run(path, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Skip(err)
+			}
+			caps, err := f.Capabilities()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(f.Metadata().RowGroups) > 0 && f.NumRows() > 0 && !caps.DataPageV1 && !caps.DataPageV2 {
+				t.Errorf("no data pages found: %+v", caps)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+//go:build cgo && parquet.capi
+
+// Command capi builds a C library exposing a minimal API to read parquet files,
+// for example to back extensions of databases like DuckDB or SQLite, or other
+// programs calling the library through a foreign function interface.
+//
+// The package is only built with the parquet.capi build tag, so programs using
+// the parquet package do not depend on cgo:
+//
+//	go build -tags parquet.capi -buildmode=c-shared -o libparquet.so ./capi
+//
+// The build generates a libparquet.h header declaring the functions below.
+// Files are referenced by opaque handles, and the rows are returned in batches
+// encoded in the Arrow IPC streaming format, which most data processing
+// systems are able to import:
+//
+//	char *err = NULL;
+//	parquet_file f = parquet_open("file.parquet", &err);
+//	if (!f) {
+//		...
+//	}
+//	void *data;
+//	size_t size;
+//	while (parquet_next_batch(f, 0, &data, &size, &err) > 0) {
+//		...
+//		parquet_free(data);
+//	}
+//	parquet_close(f);
+//
+// Strings and buffers returned by the library are allocated with malloc and
+// must be released with parquet_free. Errors are returned in the err output
+// parameters, which may be NULL if the caller is not interested in them.
+//
+// Only files with flat schemas can be read in batches, see ArrowWriter in the
+// parquet package.
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+
+typedef uintptr_t parquet_file;
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+func main() {}
+
+//export parquet_open
+func parquet_open(path *C.char, err **C.char) C.parquet_file {
+	f, e := openFile(C.GoString(path))
+	if e != nil {
+		setError(err, e)
+		return 0
+	}
+	return C.parquet_file(cgo.NewHandle(f))
+}
+
+//export parquet_schema
+func parquet_schema(f C.parquet_file) *C.char {
+	return C.CString(handleOf(f).schema())
+}
+
+//export parquet_num_rows
+func parquet_num_rows(f C.parquet_file) C.int64_t {
+	return C.int64_t(handleOf(f).numRows())
+}
+
+// parquet_next_batch reads up to max_rows rows, or parquet.DefaultArrowBatchSize
+// if max_rows is zero, and sets data and size to an Arrow IPC stream holding
+// the rows. The function returns the number of rows read, zero when all the
+// rows were read, or -1 on error.
+//
+//export parquet_next_batch
+func parquet_next_batch(f C.parquet_file, maxRows C.int64_t, data *unsafe.Pointer, size *C.size_t, err **C.char) C.int64_t {
+	b, n, e := handleOf(f).nextBatch(int(maxRows))
+	if e != nil {
+		setError(err, e)
+		return -1
+	}
+	if n == 0 {
+		return 0
+	}
+	*data = C.CBytes(b)
+	*size = C.size_t(len(b))
+	return C.int64_t(n)
+}
+
+//export parquet_close
+func parquet_close(f C.parquet_file) {
+	h := cgo.Handle(f)
+	h.Value().(*file).close()
+	h.Delete()
+}
+
+//export parquet_free
+func parquet_free(p unsafe.Pointer) {
+	C.free(p)
+}
+
+func handleOf(f C.parquet_file) *file {
+	return cgo.Handle(f).Value().(*file)
+}
+
+func setError(err **C.char, e error) {
+	if err != nil {
+		*err = C.CString(e.Error())
+	}
+}
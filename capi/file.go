@@ -0,0 +1,81 @@
+//go:build cgo && parquet.capi
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// file is the state behind the handles returned by parquet_open.
+type file struct {
+	file   *os.File
+	reader *parquet.Reader
+	rows   []parquet.Row
+	output bytes.Buffer
+}
+
+func openFile(path string) (*file, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	p, err := parquet.OpenFile(f, s.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &file{file: f, reader: parquet.NewReader(p)}, nil
+}
+
+func (f *file) schema() string { return f.reader.Schema().String() }
+
+func (f *file) numRows() int64 { return f.reader.NumRows() }
+
+// nextBatch reads up to maxRows rows from f, and returns them as an Arrow IPC
+// stream holding the schema and a single record batch, along with the number
+// of rows. The returned buffer remains valid until the next call to nextBatch.
+// The function returns zero rows when all the rows were read.
+func (f *file) nextBatch(maxRows int) ([]byte, int, error) {
+	if maxRows <= 0 {
+		maxRows = parquet.DefaultArrowBatchSize
+	}
+	if cap(f.rows) < maxRows {
+		f.rows = make([]parquet.Row, maxRows)
+	}
+	rows := f.rows[:maxRows]
+
+	n, err := f.reader.ReadRows(rows)
+	if n == 0 {
+		if err == io.EOF {
+			err = nil
+		}
+		return nil, 0, err
+	}
+
+	f.output.Reset()
+	w, err := parquet.NewArrowWriter(&f.output, f.reader.Schema(), parquet.ArrowStream)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := w.WriteRows(rows[:n]); err != nil {
+		return nil, 0, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, 0, err
+	}
+	return f.output.Bytes(), n, nil
+}
+
+func (f *file) close() error {
+	f.reader.Close()
+	return f.file.Close()
+}
@@ -0,0 +1,45 @@
+//go:build cgo && parquet.capi
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	f, err := openFile("../testdata/alltypes_plain.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.close()
+
+	if f.numRows() != 8 {
+		t.Errorf("wrong number of rows: want=8 got=%d", f.numRows())
+	}
+	if schema := f.schema(); schema == "" {
+		t.Error("empty schema")
+	}
+
+	numRows := 0
+	for {
+		b, n, err := f.nextBatch(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			break
+		}
+		if !bytes.HasSuffix(b, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}) {
+			t.Error("batch does not end with an arrow end-of-stream marker")
+		}
+		numRows += n
+	}
+	if numRows != 8 {
+		t.Errorf("wrong number of rows read: want=8 got=%d", numRows)
+	}
+
+	if _, err := openFile("../testdata/missing.parquet"); err == nil {
+		t.Error("expected an error when opening a missing file")
+	}
+}
@@ -0,0 +1,183 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/parquet-go/parquet-go/bloom/xxhash"
+)
+
+// Cardinality is an estimate of the number of distinct values of a column,
+// see Column.CardinalityEstimate.
+type Cardinality struct {
+	// Estimated number of distinct non-null values of the column.
+	Estimate int64
+	// Lower and upper bounds of the number of distinct non-null values of the
+	// column. The estimate is exact when both bounds are equal.
+	Min int64
+	Max int64
+	// Reports whether the estimate was computed by reading the values of the
+	// column.
+	Profiled bool
+}
+
+// CardinalityEstimate returns an estimate of the number of distinct non-null
+// values of the leaf column c, for example to let query planners order joins.
+//
+// The number of distinct values of each column chunk is taken from the
+// distinct count of its statistics when it was recorded, or from the size of
+// its dictionary when the column chunk is dictionary encoded, which requires
+// reading the dictionary page. Column chunks without statistics nor
+// dictionaries are assumed to have only distinct values.
+//
+// The metadata does not tell whether values are repeated across column
+// chunks, so the estimate assumes that they are when some of the column chunks
+// have repeated values, and that they are not otherwise (e.g. for columns
+// holding unique identifiers). When profile is true and the bounds are not
+// equal, the values of the column are read and counted with a HyperLogLog
+// sketch instead, which has a standard error of about 1%.
+func (c *Column) CardinalityEstimate(profile bool) (Cardinality, error) {
+	if !c.Leaf() {
+		return Cardinality{}, fmt.Errorf("cannot estimate the cardinality of column %q which is not a leaf column", columnPath(c.Path()))
+	}
+
+	var card Cardinality
+	unique := true
+
+	for _, rowGroup := range c.file.rowGroups {
+		chunk := rowGroup.ColumnChunks()[c.index].(*fileColumnChunk)
+		if err := chunk.load(); err != nil {
+			return Cardinality{}, err
+		}
+		metadata := &chunk.chunk.MetaData
+		nonNull := metadata.NumValues - metadata.Statistics.NullCount
+		distinct := nonNull
+
+		switch {
+		case nonNull <= 0:
+			continue
+		case metadata.Statistics.DistinctCount > 0:
+			distinct = metadata.Statistics.DistinctCount
+		case hasDictionaryFormat(metadata.Encoding):
+			dict, err := chunk.Dictionary()
+			if err != nil {
+				return Cardinality{}, err
+			}
+			if dict != nil {
+				distinct = int64(dict.Len())
+			}
+		default:
+			// Without statistics, the column chunk may have a single distinct
+			// value or only distinct values.
+			if card.Min == 0 {
+				card.Min = 1
+			}
+			card.Max += nonNull
+			continue
+		}
+
+		if distinct > card.Min {
+			card.Min = distinct
+		}
+		card.Max += distinct
+		unique = unique && distinct == nonNull
+	}
+
+	if unique {
+		card.Estimate = card.Max
+	} else {
+		card.Estimate = card.Min
+	}
+
+	if profile && card.Min != card.Max {
+		estimate, err := c.profileCardinality()
+		if err != nil {
+			return Cardinality{}, err
+		}
+		switch {
+		case estimate < card.Min:
+			estimate = card.Min
+		case estimate > card.Max:
+			estimate = card.Max
+		}
+		card.Estimate, card.Profiled = estimate, true
+	}
+
+	return card, nil
+}
+
+// profileCardinality reads the values of the column and returns an estimate of
+// the number of distinct non-null values.
+func (c *Column) profileCardinality() (int64, error) {
+	pages := c.Pages()
+	defer pages.Close()
+
+	sketch := new(hyperLogLog)
+	values := make([]Value, defaultValueBufferSize)
+	var buf []byte
+
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				return sketch.count(), nil
+			}
+			return 0, err
+		}
+
+		reader := p.Values()
+		for {
+			n, err := reader.ReadValues(values)
+			for _, v := range values[:n] {
+				if !v.IsNull() {
+					buf = v.AppendBytes(buf[:0])
+					sketch.add(xxhash.Sum64(buf))
+				}
+			}
+			if err != nil {
+				Release(p)
+				if err != io.EOF {
+					return 0, err
+				}
+				break
+			}
+		}
+	}
+}
+
+const hyperLogLogPrecision = 14
+
+// hyperLogLog is a sketch estimating the number of distinct hashes added to it,
+// see https://en.wikipedia.org/wiki/HyperLogLog
+type hyperLogLog struct {
+	registers [1 << hyperLogLogPrecision]uint8
+}
+
+func (h *hyperLogLog) add(hash uint64) {
+	i := hash >> (64 - hyperLogLogPrecision)
+	// Set a low bit so the number of leading zeros is bounded.
+	w := hash<<hyperLogLogPrecision | 1<<(hyperLogLogPrecision-1)
+	if r := uint8(bits.LeadingZeros64(w)) + 1; r > h.registers[i] {
+		h.registers[i] = r
+	}
+}
+
+func (h *hyperLogLog) count() int64 {
+	m := float64(len(h.registers))
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := (0.7213 / (1 + 1.079/m)) * m * m / sum
+	// Use linear counting for small cardinalities, where the raw estimate of
+	// HyperLogLog is biased.
+	if estimate <= 2.5*m && zeros != 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int64(estimate + 0.5)
+}
@@ -0,0 +1,239 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ChangeReader merges the rows of a base row group with the rows of row groups
+// holding changes to its rows, producing the latest version of each row
+// identified by the values of a set of primary key columns, which is a building
+// block of simple table formats that append change files to base files instead
+// of rewriting them.
+//
+// Rows of the changes row groups replace the rows of the base row group which
+// have the same key, or are inserted after the rows of the base row group when
+// no rows have their key. When multiple changes have the same key, the last
+// one wins, with later row groups taking precedence over earlier ones. Rows
+// which are marked as deleted in the DeletedColumn of the schema, if any, are
+// removed from the output; a change marking a row as deleted therefore deletes
+// the row of the base row group with the same key.
+//
+// The rows of the changes row groups are loaded in memory when reading the
+// first rows, while the rows of the base row group are streamed, which makes
+// the reader suited to merge small change sets with large base files.
+type ChangeReader struct {
+	schema        *Schema
+	base          RowGroup
+	changes       []RowGroup
+	keyColumns    []int
+	deletedColumn int
+
+	rows    Rows
+	loaded  bool
+	latest  []Row
+	applied []bool
+	indexes map[string]int
+	next    int
+	key     []byte
+	value   []byte
+	err     error
+}
+
+// NewChangeReader constructs a reader merging the rows of base with the rows of
+// changes, where rows are identified by the values of the leaf columns at the
+// dot-separated paths of key. Rows of changes are converted to the schema of
+// base when their schemas differ.
+//
+// The function returns an error if the key columns do not exist in the schema
+// of base or are nested in repeated fields.
+func NewChangeReader(key []string, base RowGroup, changes ...RowGroup) (*ChangeReader, error) {
+	schema := base.Schema()
+	if len(key) == 0 {
+		return nil, fmt.Errorf("cannot merge changes without key columns")
+	}
+
+	keyColumns := make([]int, len(key))
+	for i, path := range key {
+		leaf, ok := schema.Lookup(strings.Split(path, ".")...)
+		if !ok {
+			return nil, fmt.Errorf("key column %q is not a leaf column of the schema", path)
+		}
+		if leaf.MaxRepetitionLevel > 0 {
+			return nil, fmt.Errorf("key column %q is nested in a repeated field", path)
+		}
+		keyColumns[i] = leaf.ColumnIndex
+	}
+
+	deletedColumn := -1
+	if leaf, ok := schema.Lookup(DeletedColumn); ok && leaf.MaxRepetitionLevel == 0 && leaf.Node.Type().Kind() == Boolean {
+		deletedColumn = leaf.ColumnIndex
+	}
+
+	return &ChangeReader{
+		schema:        schema,
+		base:          base,
+		changes:       changes,
+		keyColumns:    keyColumns,
+		deletedColumn: deletedColumn,
+	}, nil
+}
+
+// Schema returns the schema of rows read from r, which is the schema of the
+// base row group.
+func (r *ChangeReader) Schema() *Schema { return r.schema }
+
+// ReadRows reads the next merged rows into rows, returning io.EOF when all the
+// rows have been read.
+func (r *ChangeReader) ReadRows(rows []Row) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if !r.loaded {
+		if err := r.load(); err != nil {
+			r.err = err
+			return 0, err
+		}
+	}
+
+	for r.rows != nil {
+		n, err := r.rows.ReadRows(rows)
+		i := 0
+		for j := range rows[:n] {
+			if k, ok := r.indexes[string(r.keyOf(rows[j]))]; ok {
+				r.applied[k] = true
+				rows[j] = append(rows[j][:0], r.latest[k]...)
+			}
+			if !r.deleted(rows[j]) {
+				// Swap the rows instead of overwriting them to retain the
+				// buffers of all the rows passed by the caller.
+				rows[i], rows[j] = rows[j], rows[i]
+				i++
+			}
+		}
+		switch err {
+		case nil:
+		case io.EOF:
+			r.rows.Close()
+			r.rows = nil
+		default:
+			r.err = err
+			return i, err
+		}
+		if i > 0 {
+			return i, nil
+		}
+	}
+
+	// Changes which did not apply to rows of the base row group are inserted
+	// after them, in the order that their keys were first seen.
+	i := 0
+	for i < len(rows) && r.next < len(r.latest) {
+		k := r.next
+		r.next++
+		if !r.applied[k] && !r.deleted(r.latest[k]) {
+			rows[i] = append(rows[i][:0], r.latest[k]...)
+			i++
+		}
+	}
+	if r.next == len(r.latest) {
+		r.err = io.EOF
+		return i, io.EOF
+	}
+	return i, nil
+}
+
+// Close closes the reader, releasing the rows of the base row group.
+func (r *ChangeReader) Close() error {
+	r.latest, r.indexes = nil, nil
+	if r.err == nil {
+		r.err = io.ErrClosedPipe
+	}
+	if rows := r.rows; rows != nil {
+		r.rows = nil
+		return rows.Close()
+	}
+	return nil
+}
+
+func (r *ChangeReader) load() error {
+	r.loaded = true
+	r.indexes = make(map[string]int)
+
+	for _, changes := range r.changes {
+		if !nodesAreEqual(changes.Schema(), r.schema) {
+			conv, err := Convert(r.schema, changes.Schema())
+			if err != nil {
+				return fmt.Errorf("converting changes to the schema of the base row group: %w", err)
+			}
+			changes = ConvertRowGroup(changes, conv)
+		}
+		if err := r.loadChanges(changes.Rows()); err != nil {
+			return err
+		}
+	}
+
+	r.applied = make([]bool, len(r.latest))
+	r.rows = r.base.Rows()
+	return nil
+}
+
+func (r *ChangeReader) loadChanges(rows Rows) error {
+	defer rows.Close()
+	buffer := make([]Row, defaultRowBufferSize)
+	for {
+		n, err := rows.ReadRows(buffer)
+		for _, row := range buffer[:n] {
+			key := r.keyOf(row)
+			if k, ok := r.indexes[string(key)]; ok {
+				r.latest[k] = row.Clone()
+			} else {
+				r.indexes[string(key)] = len(r.latest)
+				r.latest = append(r.latest, row.Clone())
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// keyOf returns the key of row, which remains valid until the next call.
+func (r *ChangeReader) keyOf(row Row) []byte {
+	key := r.key[:0]
+	for _, columnIndex := range r.keyColumns {
+		v := valueOfColumn(row, columnIndex)
+		if v.IsNull() {
+			key = append(key, 0)
+			continue
+		}
+		r.value = v.AppendBytes(r.value[:0])
+		key = append(key, 1)
+		key = binary.AppendUvarint(key, uint64(len(r.value)))
+		key = append(key, r.value...)
+	}
+	r.key = key
+	return key
+}
+
+func (r *ChangeReader) deleted(row Row) bool {
+	if r.deletedColumn < 0 {
+		return false
+	}
+	v := valueOfColumn(row, r.deletedColumn)
+	return !v.IsNull() && v.boolean()
+}
+
+func valueOfColumn(row Row, columnIndex int) Value {
+	for _, v := range row {
+		if v.Column() == columnIndex {
+			return v
+		}
+	}
+	return Value{}
+}
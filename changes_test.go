@@ -0,0 +1,90 @@
+package parquet_test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestChangeReader(t *testing.T) {
+	type Row struct {
+		parquet.SoftDelete
+		Region string `parquet:"region"`
+		ID     int64  `parquet:"id"`
+		Name   string `parquet:"name"`
+	}
+	type Update struct {
+		Region  string `parquet:"region"`
+		ID      int64  `parquet:"id"`
+		Name    string `parquet:"name"`
+		Deleted bool   `parquet:"_deleted"`
+	}
+
+	base := parquet.NewGenericBuffer[Row]()
+	base.Write([]Row{
+		{Region: "eu", ID: 1, Name: "a"},
+		{Region: "us", ID: 1, Name: "b"},
+		{Region: "eu", ID: 2, Name: "c"},
+		{Region: "eu", ID: 3, Name: "d"},
+		{Region: "us", ID: 4, Name: "e", SoftDelete: parquet.SoftDelete{Deleted: true}},
+	})
+	changes1 := parquet.NewGenericBuffer[Row]()
+	changes1.Write([]Row{
+		{Region: "eu", ID: 2, Name: "c1"},
+		{Region: "eu", ID: 5, Name: "f"},
+		{Region: "eu", ID: 3, SoftDelete: parquet.SoftDelete{Deleted: true}},
+		{Region: "eu", ID: 6, Name: "g"},
+	})
+	// The second change set has a different column order and is converted to
+	// the schema of the base row group.
+	changes2 := parquet.NewGenericBuffer[Update]()
+	changes2.Write([]Update{
+		{Region: "eu", ID: 2, Name: "c2"},
+		{Region: "eu", ID: 6, Deleted: true},
+		{Region: "us", ID: 7, Name: "h"},
+	})
+
+	reader, err := parquet.NewChangeReader([]string{"region", "id"}, base, changes1, changes2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	var values []Row
+	buffer := make([]parquet.Row, 2)
+	for {
+		n, err := reader.ReadRows(buffer)
+		for _, row := range buffer[:n] {
+			var value Row
+			if err := reader.Schema().Reconstruct(&value, row); err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, value)
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	want := []Row{
+		{Region: "eu", ID: 1, Name: "a"},
+		{Region: "us", ID: 1, Name: "b"},
+		{Region: "eu", ID: 2, Name: "c2"},
+		{Region: "eu", ID: 5, Name: "f"},
+		{Region: "us", ID: 7, Name: "h"},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", want, values)
+	}
+
+	for _, key := range [][]string{nil, {"missing"}} {
+		if _, err := parquet.NewChangeReader(key, base); err == nil {
+			t.Errorf("expected an error when merging changes with key %q", key)
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package parquet
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/binary"
 	"fmt"
 	"io"
 	"reflect"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/compress/zstd"
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding"
 	"github.com/parquet-go/parquet-go/format"
@@ -31,6 +36,7 @@ type Column struct {
 	offsetIndex []*format.OffsetIndex
 	encoding    encoding.Encoding
 	compression compress.Codec
+	codecs      sync.Once
 
 	depth              int8
 	maxRepetitionLevel byte
@@ -65,10 +71,16 @@ func (c *Column) Fields() []Field {
 }
 
 // Encoding returns the encodings used by this column.
-func (c *Column) Encoding() encoding.Encoding { return c.encoding }
+func (c *Column) Encoding() encoding.Encoding {
+	c.codecs.Do(c.initCodecs)
+	return c.encoding
+}
 
 // Compression returns the compression codecs used by this column.
-func (c *Column) Compression() compress.Codec { return c.compression }
+func (c *Column) Compression() compress.Codec {
+	c.codecs.Do(c.initCodecs)
+	return c.compression
+}
 
 // Path of the column in the parquet schema.
 func (c *Column) Path() []string { return c.path[1:] }
@@ -96,6 +108,11 @@ func (c *Column) Column(name string) *Column {
 }
 
 // Pages returns a reader exposing all pages in this column, across row groups.
+//
+// Pages are produced in the order of the row groups in the file, then in the
+// order that they are laid out in each column chunk. Calling SeekToRow(0) on
+// the returned reader rewinds it to the first page, which allows programs to
+// make multiple passes over the column without reopening the file.
 func (c *Column) Pages() Pages {
 	if c.index < 0 {
 		return emptyPages{}
@@ -104,7 +121,16 @@ func (c *Column) Pages() Pages {
 		pages: make([]filePages, len(c.file.rowGroups)),
 	}
 	for i := range r.pages {
-		r.pages[i].init(c.file.rowGroups[i].(*fileRowGroup).columns[c.index].(*fileColumnChunk))
+		chunk := c.file.rowGroups[i].(*fileRowGroup).columns[c.index].(*fileColumnChunk)
+		if err := chunk.load(); err != nil {
+			r.Close()
+			return &errorPages{err: err}
+		}
+		if err := chunk.checkAccess(); err != nil {
+			r.Close()
+			return &errorPages{err: err}
+		}
+		r.pages[i].init(chunk)
 	}
 	return r
 }
@@ -130,7 +156,7 @@ func (c *columnPages) ReadPage() (Page, error) {
 func (c *columnPages) SeekToRow(rowIndex int64) error {
 	c.index = 0
 
-	for c.index < len(c.pages) && c.pages[c.index].chunk.rowGroup.NumRows >= rowIndex {
+	for c.index < len(c.pages) && rowIndex >= c.pages[c.index].chunk.rowGroup.NumRows {
 		rowIndex -= c.pages[c.index].chunk.rowGroup.NumRows
 		c.index++
 	}
@@ -139,8 +165,10 @@ func (c *columnPages) SeekToRow(rowIndex int64) error {
 		if err := c.pages[c.index].SeekToRow(rowIndex); err != nil {
 			return err
 		}
-		for i := range c.pages[c.index:] {
-			p := &c.pages[c.index+i]
+		// The pages of the following row groups may have been read already,
+		// they must be rewound so the sequence restarts at the seek position.
+		for i := range c.pages[c.index+1:] {
+			p := &c.pages[c.index+1+i]
 			if err := p.SeekToRow(0); err != nil {
 				return err
 			}
@@ -322,28 +350,8 @@ func (cl *columnLoader) open(file *File, path []string) (*Column, error) {
 			}
 		}
 
-		if len(c.chunks) > 0 {
-			// Pick the encoding and compression codec of the first chunk.
-			//
-			// Technically each column chunk may use a different compression
-			// codec, and each page of the column chunk might have a different
-			// encoding. Exposing these details does not provide a lot of value
-			// to the end user.
-			//
-			// Programs that wish to determine the encoding and compression of
-			// each page of the column should iterate through the pages and read
-			// the page headers to determine which compression and encodings are
-			// applied.
-			for _, encoding := range c.chunks[0].MetaData.Encoding {
-				if c.encoding == nil {
-					c.encoding = LookupEncoding(encoding)
-				}
-				if encoding != format.Plain && encoding != format.RLE {
-					c.encoding = LookupEncoding(encoding)
-					break
-				}
-			}
-			c.compression = LookupCompressionCodec(c.chunks[0].MetaData.Codec)
+		if !file.config.LazyColumnMetadata {
+			c.codecs.Do(c.initCodecs)
 		}
 
 		return c, nil
@@ -541,9 +549,98 @@ func schemaRepetitionTypeOf(s *format.SchemaElement) format.FieldRepetitionType
 	return format.Required
 }
 
+// initCodecs sets the encoding and compression codec of the column from the
+// metadata of its first column chunk, which is decoded if the file was opened
+// with LazyColumnMetadata.
+//
+// When the metadata of the first column chunk cannot be decoded, the codecs
+// are those of the next column chunk; reading the pages of column chunks with
+// invalid metadata reports the decoding error. Columns without column chunks
+// that can be decoded are uncompressed, unless their codec was already set.
+func (c *Column) initCodecs() {
+	// The metadata of column chunks is decoded when opening the file, before
+	// the row groups are initialized, unless it is lazily decoded.
+	chunk := -1
+	if c.file == nil || len(c.file.rowGroups) == 0 {
+		if len(c.chunks) > 0 {
+			chunk = 0
+		}
+	} else {
+		for i, rowGroup := range c.file.rowGroups {
+			if rowGroup.(*fileRowGroup).columns[c.index].(*fileColumnChunk).load() == nil {
+				chunk = i
+				break
+			}
+		}
+	}
+	if chunk < 0 {
+		if c.compression == nil {
+			c.compression = LookupCompressionCodec(format.Uncompressed)
+		}
+		return
+	}
+	metadata := &c.chunks[chunk].MetaData
+
+	// Pick the encoding and compression codec of the first chunk.
+	//
+	// Technically each column chunk may use a different compression
+	// codec, and each page of the column chunk might have a different
+	// encoding. Exposing these details does not provide a lot of value
+	// to the end user.
+	//
+	// Programs that wish to determine the encoding and compression of
+	// each page of the column should iterate through the pages and read
+	// the page headers to determine which compression and encodings are
+	// applied.
+	for _, encoding := range metadata.Encoding {
+		if c.encoding == nil {
+			c.encoding = LookupEncoding(encoding)
+		}
+		if encoding != format.Plain && encoding != format.RLE {
+			c.encoding = LookupEncoding(encoding)
+			break
+		}
+	}
+	c.compression = LookupCompressionCodec(metadata.Codec)
+
+	// Columns compressed with a zstd dictionary carry the dictionary in the
+	// key/value metadata of the file, see ColumnCompressionDictionary.
+	if metadata.Codec == format.Zstd {
+		if value, ok := c.file.Lookup(zstdDictionaryKeyPrefix + columnPath(c.Path()).String()); ok {
+			if dict, err := base64.StdEncoding.DecodeString(value); err == nil {
+				c.compression = &zstd.Codec{Dictionary: dict}
+			}
+		}
+	}
+}
+
+// stats returns the statistics collector of the file that c belongs to, which
+// is nil if the column was not opened from a file or statistics are disabled.
+func (c *Column) stats() *readStats {
+	if c.file == nil {
+		return nil
+	}
+	return c.file.stats
+}
+
+// pagePool returns the pool of buffers that pages of the column are read and
+// decoded into.
+func (c *Column) pagePool() *bufferPool {
+	if c.file != nil && c.file.config.PageBufferPool != nil {
+		return &c.file.config.PageBufferPool.pool
+	}
+	return &buffers
+}
+
 func (c *Column) decompress(compressedPageData []byte, uncompressedPageSize int32) (page *buffer, err error) {
-	page = buffers.get(int(uncompressedPageSize))
-	page.data, err = c.compression.Decode(page.data, compressedPageData)
+	stats := c.stats()
+	defer stats.record(readStageDecompress, stats.now())
+	page = c.pagePool().get(int(uncompressedPageSize))
+	data := page.data
+	page.data, err = c.compression.Decode(data, compressedPageData)
+	if err != nil && c.file != nil && c.file.config.DetectCompression != nil {
+		page.data, err = c.decompressDetected(data, compressedPageData, uncompressedPageSize, err)
+	}
 	if err != nil {
 		page.unref()
 		page = nil
@@ -551,6 +648,29 @@ func (c *Column) decompress(compressedPageData []byte, uncompressedPageSize int3
 	return page, err
 }
 
+// decompressDetected retries the decompression of a page which failed with
+// the codec of the column, using the codec detected from the page data. The
+// original error is returned if no other codec was detected or if it also
+// failed to decompress the page.
+func (c *Column) decompressDetected(dst, src []byte, uncompressedPageSize int32, err error) ([]byte, error) {
+	codec := detectCompressionCodec(src, uncompressedPageSize)
+	if codec == nil || codec.CompressionCodec() == c.compression.CompressionCodec() {
+		return dst, err
+	}
+	data, decodeErr := codec.Decode(dst, src)
+	if decodeErr != nil {
+		return data, err
+	}
+	c.file.config.DetectCompression(CompressionMismatch{
+		Path:     c.Path(),
+		Declared: c.compression.CompressionCodec(),
+		Detected: codec.CompressionCodec(),
+	})
+	c.file.readWarnings.add(c.Path(), fmt.Sprintf("page compressed with %s was read from a column chunk declaring %s",
+		codec.CompressionCodec(), c.compression.CompressionCodec()))
+	return data, nil
+}
+
 // DecodeDataPageV1 decodes a data page from the header, compressed data, and
 // optional dictionary passed as arguments.
 func (c *Column) DecodeDataPageV1(header DataPageHeaderV1, page []byte, dict Dictionary) (Page, error) {
@@ -561,7 +681,7 @@ func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Di
 	var pageData = page.data
 	var err error
 
-	if isCompressed(c.compression) {
+	if isCompressed(c.Compression()) {
 		if page, err = c.decompress(pageData, size); err != nil {
 			return nil, fmt.Errorf("decompressing data page v1: %w", err)
 		}
@@ -570,31 +690,46 @@ func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Di
 	}
 
 	var numValues = int(header.NumValues())
+	var numLevels = numValues
 	var repetitionLevels *buffer
 	var definitionLevels *buffer
+	var reconcile = c.reconcileLevelCounts()
+	var stats = c.stats()
+	var start = stats.now()
 
 	if c.maxRepetitionLevel > 0 {
 		encoding := lookupLevelEncoding(header.RepetitionLevelEncoding(), c.maxRepetitionLevel)
-		repetitionLevels, pageData, err = decodeLevelsV1(encoding, numValues, pageData)
+		repetitionLevels, pageData, err = decodeLevelsV1(c.pagePool(), encoding, numLevels, pageData, reconcile != nil)
 		if err != nil {
 			return nil, fmt.Errorf("decoding repetition levels of data page v1: %w", err)
 		}
 		defer repetitionLevels.unref()
+		numLevels = len(repetitionLevels.data)
 	}
 
 	if c.maxDefinitionLevel > 0 {
 		encoding := lookupLevelEncoding(header.DefinitionLevelEncoding(), c.maxDefinitionLevel)
-		definitionLevels, pageData, err = decodeLevelsV1(encoding, numValues, pageData)
+		definitionLevels, pageData, err = decodeLevelsV1(c.pagePool(), encoding, numLevels, pageData, reconcile != nil)
 		if err != nil {
 			return nil, fmt.Errorf("decoding definition levels of data page v1: %w", err)
 		}
 		defer definitionLevels.unref()
+		numLevels = reconcileLevels(repetitionLevels, definitionLevels)
+	}
 
+	if numLevels != numValues {
+		reconcile(LevelCountMismatch{Path: c.Path(), NumValues: numValues, NumLevels: numLevels})
+		c.file.readWarnings.add(c.Path(), "page header declares more values than the page has levels")
+		numValues = numLevels
+	}
+
+	if definitionLevels != nil {
 		// Data pages v1 did not embed the number of null values,
 		// so we have to compute it from the definition levels.
 		numValues -= countLevelsNotEqual(definitionLevels.data, c.maxDefinitionLevel)
 	}
 
+	stats.record(readStageLevelDecode, start)
 	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict)
 }
 
@@ -606,12 +741,19 @@ func (c *Column) DecodeDataPageV2(header DataPageHeaderV2, page []byte, dict Dic
 
 func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Dictionary, size int32) (Page, error) {
 	var numValues = int(header.NumValues())
+	var numLevels = numValues
 	var pageData = page.data
 	var err error
 	var repetitionLevels *buffer
 	var definitionLevels *buffer
-
-	if length := header.RepetitionLevelsByteLength(); length > 0 {
+	var reconcile = c.reconcileLevelCounts()
+	var stats = c.stats()
+	var start = stats.now()
+
+	// Required columns have no levels, the level sections are only decoded
+	// when they are present or when the column has levels; zero levels are
+	// then decoded from empty sections.
+	if length := header.RepetitionLevelsByteLength(); length > 0 || c.maxRepetitionLevel > 0 {
 		if c.maxRepetitionLevel == 0 {
 			// In some cases we've observed files which have a non-zero
 			// repetition level despite the column not being repeated
@@ -621,32 +763,48 @@ func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Di
 			pageData, err = skipLevelsV2(pageData, length)
 		} else {
 			encoding := lookupLevelEncoding(header.RepetitionLevelEncoding(), c.maxRepetitionLevel)
-			repetitionLevels, pageData, err = decodeLevelsV2(encoding, numValues, pageData, length)
+			repetitionLevels, pageData, err = decodeLevelsV2(c.pagePool(), encoding, numLevels, pageData, length, reconcile != nil)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("decoding repetition levels of data page v2: %w", io.ErrUnexpectedEOF)
 		}
 		if repetitionLevels != nil {
 			defer repetitionLevels.unref()
+			numLevels = len(repetitionLevels.data)
 		}
 	}
 
-	if length := header.DefinitionLevelsByteLength(); length > 0 {
+	if length := header.DefinitionLevelsByteLength(); length > 0 || c.maxDefinitionLevel > 0 {
 		if c.maxDefinitionLevel == 0 {
 			pageData, err = skipLevelsV2(pageData, length)
 		} else {
 			encoding := lookupLevelEncoding(header.DefinitionLevelEncoding(), c.maxDefinitionLevel)
-			definitionLevels, pageData, err = decodeLevelsV2(encoding, numValues, pageData, length)
+			definitionLevels, pageData, err = decodeLevelsV2(c.pagePool(), encoding, numLevels, pageData, length, reconcile != nil)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("decoding definition levels of data page v2: %w", io.ErrUnexpectedEOF)
 		}
 		if definitionLevels != nil {
 			defer definitionLevels.unref()
+			numLevels = reconcileLevels(repetitionLevels, definitionLevels)
 		}
 	}
 
-	if isCompressed(c.compression) && header.IsCompressed() {
+	numNulls := int(header.NumNulls())
+	if numLevels != numValues {
+		reconcile(LevelCountMismatch{Path: c.Path(), NumValues: numValues, NumLevels: numLevels})
+		c.file.readWarnings.add(c.Path(), "page header declares more values than the page has levels")
+		numValues = numLevels
+		// The number of nulls in the header is not trusted either when the
+		// number of values was wrong.
+		if definitionLevels != nil {
+			numNulls = countLevelsNotEqual(definitionLevels.data, c.maxDefinitionLevel)
+		}
+	}
+
+	stats.record(readStageLevelDecode, start)
+
+	if isCompressed(c.Compression()) && header.IsCompressed() {
 		if page, err = c.decompress(pageData, size); err != nil {
 			return nil, fmt.Errorf("decompressing data page v2: %w", err)
 		}
@@ -654,7 +812,7 @@ func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Di
 		pageData = page.data
 	}
 
-	numValues -= int(header.NumNulls())
+	numValues -= numNulls
 	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict)
 }
 
@@ -679,23 +837,38 @@ func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetition
 		vbuf = page
 		pageValues = data
 	} else {
-		vbuf = buffers.get(pageType.EstimateDecodeSize(numValues, data, pageEncoding))
+		vbuf = c.pagePool().get(pageType.EstimateDecodeSize(numValues, data, pageEncoding))
 		defer vbuf.unref()
 		pageValues = vbuf.data
 	}
 
 	// Page offsets not needed when dictionary-encoded
 	if pageType.Kind() == ByteArray && !isDictionaryEncoding(pageEncoding) {
-		obuf = buffers.get(4 * (numValues + 1))
+		obuf = c.pagePool().get(4 * (numValues + 1))
 		defer obuf.unref()
 		pageOffsets = unsafecast.BytesToUint32(obuf.data)
 	}
 
+	stats := c.stats()
+	start := stats.now()
 	values := pageType.NewValues(pageValues, pageOffsets)
 	values, err := pageType.Decode(values, data, pageEncoding)
+	stats.record(readStageValueDecode, start)
 	if err != nil {
 		return nil, err
 	}
+	if lazy, ok := dict.(*lazyByteArrayDictionary); ok && isDictionaryEncoding(pageEncoding) {
+		// Eager dictionaries check indexes when values are looked up, lazy
+		// dictionaries are checked here so invalid pages fail to decode.
+		if err := lazy.checkIndexes(values.Int32()); err != nil {
+			return nil, err
+		}
+	}
+	if mode := c.validateUTF8(); mode != UTF8PassThrough && !isDictionaryEncoding(pageEncoding) {
+		if values, err = validateUTF8(mode, values); err != nil {
+			return nil, err
+		}
+	}
 
 	newPage := pageType.NewPage(c.Index(), numValues, values)
 	switch {
@@ -718,7 +891,7 @@ func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetition
 	return newBufferedPage(newPage, vbuf, obuf, repetitionLevels, definitionLevels), nil
 }
 
-func decodeLevelsV1(enc encoding.Encoding, numValues int, data []byte) (*buffer, []byte, error) {
+func decodeLevelsV1(pool *bufferPool, enc encoding.Encoding, numValues int, data []byte, allowShort bool) (*buffer, []byte, error) {
 	if len(data) < 4 {
 		return nil, data, io.ErrUnexpectedEOF
 	}
@@ -727,24 +900,30 @@ func decodeLevelsV1(enc encoding.Encoding, numValues int, data []byte) (*buffer,
 	if j > len(data) {
 		return nil, data, io.ErrUnexpectedEOF
 	}
-	levels, err := decodeLevels(enc, numValues, data[i:j])
+	levels, err := decodeLevels(pool, enc, numValues, data[i:j], allowShort)
 	return levels, data[j:], err
 }
 
-func decodeLevelsV2(enc encoding.Encoding, numValues int, data []byte, length int64) (*buffer, []byte, error) {
-	levels, err := decodeLevels(enc, numValues, data[:length])
+func decodeLevelsV2(pool *bufferPool, enc encoding.Encoding, numValues int, data []byte, length int64, allowShort bool) (*buffer, []byte, error) {
+	if length < 0 || length > int64(len(data)) {
+		return nil, data, io.ErrUnexpectedEOF
+	}
+	levels, err := decodeLevels(pool, enc, numValues, data[:length], allowShort)
 	return levels, data[length:], err
 }
 
-func decodeLevels(enc encoding.Encoding, numValues int, data []byte) (levels *buffer, err error) {
-	levels = buffers.get(numValues)
+// decodeLevels decodes numValues levels from data. When allowShort is true,
+// the returned levels may have fewer than numValues levels instead of causing
+// an error.
+func decodeLevels(pool *bufferPool, enc encoding.Encoding, numValues int, data []byte, allowShort bool) (levels *buffer, err error) {
+	levels = pool.get(numValues)
 	levels.data, err = enc.DecodeLevels(levels.data, data)
 	if err != nil {
 		levels.unref()
 		levels = nil
 	} else {
 		switch {
-		case len(levels.data) < numValues:
+		case len(levels.data) < numValues && !allowShort:
 			err = fmt.Errorf("decoding level expected %d values but got only %d", numValues, len(levels.data))
 		case len(levels.data) > numValues:
 			levels.data = levels.data[:numValues]
@@ -753,6 +932,71 @@ func decodeLevels(enc encoding.Encoding, numValues int, data []byte) (levels *bu
 	return levels, err
 }
 
+// reconcileLevels truncates the repetition levels to the number of definition
+// levels when fewer definition levels were decoded, and returns the number of
+// levels. The repetition levels may be nil.
+func reconcileLevels(repetitionLevels, definitionLevels *buffer) int {
+	numLevels := len(definitionLevels.data)
+	if repetitionLevels != nil && len(repetitionLevels.data) > numLevels {
+		repetitionLevels.data = repetitionLevels.data[:numLevels]
+	}
+	return numLevels
+}
+
+// reconcileLevelCounts returns the function reporting level count mismatches
+// configured on the file of c, or nil if the counts are not reconciled.
+func (c *Column) reconcileLevelCounts() func(LevelCountMismatch) {
+	if c.file == nil {
+		return nil
+	}
+	return c.file.config.ReconcileLevelCounts
+}
+
+// validateUTF8 returns the validation applied to the values of c, which is
+// always UTF8PassThrough for columns which are not STRING columns.
+func (c *Column) validateUTF8() UTF8Validation {
+	if c.file == nil || c.Type().Kind() != ByteArray || !isUTF8Type(c.Type()) {
+		return UTF8PassThrough
+	}
+	return c.file.config.ValidateUTF8
+}
+
+// validateUTF8 validates the byte array values according to mode, returning
+// an error wrapping ErrInvalidUTF8 or a copy of the values where invalid UTF-8
+// sequences are replaced when some values are invalid.
+func validateUTF8(mode UTF8Validation, values encoding.Values) (encoding.Values, error) {
+	data, offsets := values.ByteArray()
+
+	for i := 1; i < len(offsets); i++ {
+		if utf8.Valid(data[offsets[i-1]:offsets[i]]) {
+			continue
+		}
+		if mode != UTF8Replace {
+			return values, fmt.Errorf("value at index %d: %w", i-1, ErrInvalidUTF8)
+		}
+
+		newData := make([]byte, offsets[i-1], len(data)+len(data)/2)
+		newOffsets := make([]uint32, len(offsets))
+		copy(newData, data)
+		copy(newOffsets, offsets[:i])
+
+		for ; i < len(offsets); i++ {
+			value := data[offsets[i-1]:offsets[i]]
+			if utf8.Valid(value) {
+				newData = append(newData, value...)
+			} else {
+				newData = append(newData, bytes.ToValidUTF8(value, replacementChar)...)
+			}
+			newOffsets[i] = uint32(len(newData))
+		}
+		return encoding.ByteArrayValues(newData, newOffsets), nil
+	}
+
+	return values, nil
+}
+
+var replacementChar = []byte(string(utf8.RuneError))
+
 func skipLevelsV2(data []byte, length int64) ([]byte, error) {
 	if length >= int64(len(data)) {
 		return data, io.ErrUnexpectedEOF
@@ -769,7 +1013,7 @@ func (c *Column) DecodeDictionary(header DictionaryPageHeader, page []byte) (Dic
 func (c *Column) decodeDictionary(header DictionaryPageHeader, page *buffer, size int32) (Dictionary, error) {
 	pageData := page.data
 
-	if isCompressed(c.compression) {
+	if isCompressed(c.Compression()) {
 		var err error
 		if page, err = c.decompress(pageData, size); err != nil {
 			return nil, fmt.Errorf("decompressing dictionary page: %w", err)
@@ -784,15 +1028,64 @@ func (c *Column) decodeDictionary(header DictionaryPageHeader, page *buffer, siz
 		pageEncoding = format.Plain
 	}
 
+	stats := c.stats()
+	defer stats.record(readStageValueDecode, stats.now())
+
 	numValues := int(header.NumValues())
 	values := pageType.NewValues(nil, nil)
 	values, err := pageType.Decode(values, pageData, LookupEncoding(pageEncoding))
 	if err != nil {
 		return nil, err
 	}
+	if mode := c.validateUTF8(); mode != UTF8PassThrough {
+		if values, err = validateUTF8(mode, values); err != nil {
+			return nil, fmt.Errorf("decoding dictionary page: %w", err)
+		}
+	}
 	return pageType.NewDictionary(int(c.index), numValues, values), nil
 }
 
+// decodeLazyDictionary is like decodeDictionary but returns a dictionary which
+// locates values in the uncompressed page when they are looked up instead of
+// decoding them all. Only PLAIN encoded dictionaries of BYTE_ARRAY columns are
+// decoded lazily, other dictionaries are decoded by decodeDictionary.
+func (c *Column) decodeLazyDictionary(header DictionaryPageHeader, page *buffer, size int32) (Dictionary, error) {
+	pageType := c.Type()
+	pageEncoding := header.Encoding()
+
+	// Dictionaries of validated string columns are decoded eagerly, since all
+	// their values are inspected anyway.
+	if pageType.Kind() != ByteArray || (pageEncoding != format.Plain && pageEncoding != format.PlainDictionary) ||
+		c.validateUTF8() != UTF8PassThrough {
+		return c.decodeDictionary(header, page, size)
+	}
+
+	pageData := page.data
+
+	if isCompressed(c.Compression()) {
+		var err error
+		if page, err = c.decompress(pageData, size); err != nil {
+			return nil, fmt.Errorf("decompressing dictionary page: %w", err)
+		}
+		defer page.unref()
+		pageData = page.data
+	}
+
+	// The page buffer is released when the function returns, the dictionary
+	// retains a copy of its content.
+	data := make([]byte, len(pageData))
+	copy(data, pageData)
+
+	stats := c.stats()
+	start := stats.now()
+	d, err := newLazyByteArrayDictionary(pageType, c.index, data)
+	stats.record(readStageValueDecode, start)
+	if err != nil {
+		return nil, fmt.Errorf("decoding dictionary page: %w", err)
+	}
+	return d, nil
+}
+
 var (
 	_ Node = (*Column)(nil)
 )
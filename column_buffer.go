@@ -2248,7 +2248,13 @@ func writeRowsFuncOfStruct(t reflect.Type, schema *Schema, path columnPath) writ
 		writeRows := writeRowsFuncOf(f.Type, schema, columnPath)
 		if optional {
 			switch f.Type.Kind() {
-			case reflect.Pointer, reflect.Slice:
+			case reflect.Pointer:
+			case reflect.Slice:
+				// Byte slices are leaf values, a nil slice is a null value of
+				// the optional column.
+				if f.Type.Elem().Kind() == reflect.Uint8 {
+					writeRows = writeRowsFuncOfOptional(f.Type, schema, columnPath, writeRows)
+				}
 			default:
 				writeRows = writeRowsFuncOfOptional(f.Type, schema, columnPath, writeRows)
 			}
@@ -13,6 +13,9 @@ type ColumnChunk interface {
 	Column() int
 
 	// Returns a reader exposing the pages of the column.
+	//
+	// Pages are produced in the order that they are laid out in the column
+	// chunk, which is the same on every call to this method.
 	Pages() Pages
 
 	// Returns the components of the page index for this column chunk,
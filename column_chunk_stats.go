@@ -0,0 +1,193 @@
+package parquet
+
+import (
+	"io"
+	"time"
+)
+
+// ColumnChunkStats carries the statistics of a column chunk.
+type ColumnChunkStats struct {
+	// Type of the column.
+	Type Type
+	// Number of values in the column chunk, including nulls.
+	NumValues int64
+	// Number of null values in the column chunk.
+	NullCount int64
+	// Number of distinct values in the column chunk, or zero if it is not
+	// known; most writers do not record it.
+	DistinctCount int64
+	// Minimum and maximum values of the column chunk. Both are null values
+	// when the statistics are not available or the column chunk only contains
+	// nulls.
+	MinValue Value
+	MaxValue Value
+}
+
+// Min returns the minimum value of the column chunk as a Go value, see
+// ColumnChunkStats.Max.
+func (s ColumnChunkStats) Min() any { return goValueOf(s.Type, s.MinValue) }
+
+// Max returns the maximum value of the column chunk as a Go value, of a type
+// determined by the logical type of the column when it has one: time.Time for
+// dates and timestamps, string for strings, enums and JSON documents, and Go
+// integers of the width and signedness of integer types. Otherwise, the type
+// is the Go type of the physical type of the column (e.g. int32, float64, or
+// []byte).
+//
+// The method returns nil if the maximum value is not known.
+func (s ColumnChunkStats) Max() any { return goValueOf(s.Type, s.MaxValue) }
+
+// ReadColumnChunkStats returns the statistics of the column chunk passed as
+// argument. The type of the statistics is the type of the column chunk.
+//
+// When the column chunk was read from a file, the statistics are taken from
+// the metadata of the column chunk, without reading its pages. The min and max
+// values are only available if the writer of the file recorded them. For other
+// column chunks, the statistics are computed by reading the pages.
+func ReadColumnChunkStats(columnChunk ColumnChunk) (ColumnChunkStats, error) {
+	return readColumnChunkStats(columnChunk, columnChunk.Type())
+}
+
+func readColumnChunkStats(columnChunk ColumnChunk, typ Type) (ColumnChunkStats, error) {
+	stats := ColumnChunkStats{Type: typ}
+
+	if c, ok := columnChunk.(*fileColumnChunk); ok {
+		if err := c.load(); err != nil {
+			return stats, err
+		}
+		metadata := &c.chunk.MetaData
+		stats.NumValues = metadata.NumValues
+		stats.NullCount = metadata.Statistics.NullCount
+		stats.DistinctCount = metadata.Statistics.DistinctCount
+
+		minValue, maxValue := metadata.Statistics.MinValue, metadata.Statistics.MaxValue
+		if maxValue == nil && stats.Type.LogicalType() == nil && stats.Type.Kind() != ByteArray && stats.Type.Kind() != FixedLenByteArray {
+			// The deprecated min and max values were determined by signed
+			// comparison, which is only the order of numeric physical types.
+			minValue, maxValue = metadata.Statistics.Min, metadata.Statistics.Max
+		}
+		// Empty byte arrays are decoded as nil slices, a byte array column
+		// chunk with a max value and no min value has an empty min value.
+		kind := stats.Type.Kind()
+		if maxValue != nil && (minValue != nil || kind == ByteArray) {
+			stats.MinValue, stats.MaxValue = kind.Value(minValue), kind.Value(maxValue)
+		}
+		return stats, nil
+	}
+
+	pages := columnChunk.Pages()
+	defer pages.Close()
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				return stats, nil
+			}
+			return stats, err
+		}
+		stats.NumValues += p.NumValues()
+		stats.NullCount += p.NumNulls()
+		if minValue, maxValue, ok := p.Bounds(); ok {
+			if stats.MinValue.IsNull() || stats.Type.Compare(minValue, stats.MinValue) < 0 {
+				stats.MinValue = minValue.Clone()
+			}
+			if stats.MaxValue.IsNull() || stats.Type.Compare(maxValue, stats.MaxValue) > 0 {
+				stats.MaxValue = maxValue.Clone()
+			}
+		}
+		Release(p)
+	}
+}
+
+// ReadRowGroupStats returns the statistics of each column chunk of rowGroup,
+// see ReadColumnChunkStats.
+//
+// The types of the statistics are the types of the leaf columns of the row
+// group schema, which carry the logical types of the columns even when the
+// column chunks do not, for example in the case of buffered row groups.
+func ReadRowGroupStats(rowGroup RowGroup) ([]ColumnChunkStats, error) {
+	columnChunks := rowGroup.ColumnChunks()
+	types := make([]Type, len(columnChunks))
+	for i, columnChunk := range columnChunks {
+		types[i] = columnChunk.Type()
+	}
+	forEachLeafColumnOf(rowGroup.Schema(), func(leaf leafColumn) {
+		if i := int(leaf.columnIndex); i < len(types) {
+			types[i] = leaf.node.Type()
+		}
+	})
+
+	stats := make([]ColumnChunkStats, len(columnChunks))
+	for i, columnChunk := range columnChunks {
+		s, err := readColumnChunkStats(columnChunk, types[i])
+		if err != nil {
+			return stats[:i], err
+		}
+		stats[i] = s
+	}
+	return stats, nil
+}
+
+// goValueOf returns v as a Go value of the type matching the logical or
+// physical type t, or nil if v is null.
+func goValueOf(t Type, v Value) any {
+	if v.IsNull() {
+		return nil
+	}
+
+	if lt := t.LogicalType(); lt != nil {
+		switch {
+		case lt.Timestamp != nil:
+			return timestamp(v, lt.Timestamp.Unit, time.UTC)
+		case lt.Date != nil:
+			return unixEpoch.AddDate(0, 0, int(v.int32()))
+		case lt.Integer != nil:
+			return goIntegerOf(lt.Integer.BitWidth, lt.Integer.IsSigned, v)
+		}
+	}
+	if isUTF8Type(t) {
+		return string(v.byteArray())
+	}
+
+	switch v.Kind() {
+	case Boolean:
+		return v.boolean()
+	case Int32:
+		return v.int32()
+	case Int64:
+		return v.int64()
+	case Int96:
+		return v.int96()
+	case Float:
+		return v.float()
+	case Double:
+		return v.double()
+	default:
+		return append([]byte{}, v.byteArray()...)
+	}
+}
+
+func goIntegerOf(bitWidth int8, signed bool, v Value) any {
+	if signed {
+		switch bitWidth {
+		case 8:
+			return int8(v.int32())
+		case 16:
+			return int16(v.int32())
+		case 32:
+			return v.int32()
+		default:
+			return v.int64()
+		}
+	}
+	switch bitWidth {
+	case 8:
+		return uint8(v.uint32())
+	case 16:
+		return uint16(v.uint32())
+	case 32:
+		return v.uint32()
+	default:
+		return v.uint64()
+	}
+}
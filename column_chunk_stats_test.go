@@ -0,0 +1,74 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReadColumnChunkStats(t *testing.T) {
+	type Row struct {
+		ID    int64     `parquet:"id"`
+		Name  string    `parquet:"name"`
+		Score *float64  `parquet:"score,optional"`
+		Count uint32    `parquet:"count"`
+		Time  time.Time `parquet:"time,timestamp(millisecond)"`
+	}
+
+	score := 0.5
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []Row{
+		{ID: 3, Name: "bob", Count: 10, Time: start.Add(time.Hour)},
+		{ID: 1, Name: "alice", Score: &score, Count: 3e9, Time: start},
+		{ID: 2, Name: "carol", Count: 7, Time: start.Add(time.Minute)},
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	if _, err := buffer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	output := new(bytes.Buffer)
+	if err := parquet.Write(output, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		min, max  any
+		nullCount int64
+	}{
+		{min: int64(1), max: int64(3)},
+		{min: "alice", max: "carol"},
+		{min: 0.5, max: 0.5, nullCount: 2},
+		{min: uint32(7), max: uint32(3e9)},
+		{min: start, max: start.Add(time.Hour)},
+	}
+
+	for _, rowGroup := range []parquet.RowGroup{buffer, f.RowGroups()[0]} {
+		rowGroupStats, err := parquet.ReadRowGroupStats(rowGroup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rowGroupStats) != len(expected) {
+			t.Fatalf("%T: wrong number of column chunks: %d", rowGroup, len(rowGroupStats))
+		}
+		for i, stats := range rowGroupStats {
+			want := expected[i]
+			if stats.NumValues != 3 || stats.NullCount != want.nullCount {
+				t.Errorf("%T: column %d: wrong counts: values=%d nulls=%d", rowGroup, i, stats.NumValues, stats.NullCount)
+			}
+			if min := stats.Min(); !reflect.DeepEqual(min, want.min) {
+				t.Errorf("%T: column %d: wrong min: want=%#v got=%#v", rowGroup, i, want.min, min)
+			}
+			if max := stats.Max(); !reflect.DeepEqual(max, want.max) {
+				t.Errorf("%T: column %d: wrong max: want=%#v got=%#v", rowGroup, i, want.max, max)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestColumnCodecsInvalidLazyMetadata(t *testing.T) {
+	type Row struct {
+		Value string `parquet:"value,zstd"`
+	}
+
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i].Value = "value"
+	}
+	buffer := new(bytes.Buffer)
+	if err := Write(buffer, rows, MaxRowsPerRowGroup(5)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), LazyColumnMetadata(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt the thrift encoding of the metadata of the first column chunk
+	// before it is decoded.
+	chunks := [2]*fileColumnChunk{
+		f.rowGroups[0].(*fileRowGroup).columns[0].(*fileColumnChunk),
+		f.rowGroups[1].(*fileRowGroup).columns[0].(*fileColumnChunk),
+	}
+	chunks[0].lazy.data = []byte{0xFF, 0xFF, 0xFF}
+
+	column := f.Root().Column("value")
+	if codec := column.Compression(); codec == nil || codec.CompressionCodec() != format.Zstd {
+		t.Fatalf("compression codec mismatch: want=%v got=%v", format.Zstd, codec)
+	}
+
+	pages := chunks[0].Pages()
+	if _, err := pages.ReadPage(); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("reading pages of the column chunk with invalid metadata did not fail: %v", err)
+	}
+	pages.Close()
+
+	pages = chunks[1].Pages()
+	defer pages.Close()
+	p, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := p.NumRows(); n != 5 {
+		t.Errorf("number of rows mismatch: want=%d got=%d", 5, n)
+	}
+}
+
+func TestColumnLazyDictionaryIndexOutOfBounds(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name,dict"`
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := Write(buffer, []Row{{Name: "a"}, {Name: "b"}}, Compression(&Uncompressed)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	column := f.Root().Column("name")
+
+	dict, err := newLazyByteArrayDictionary(column.Type(), column.index, []byte{
+		1, 0, 0, 0, 'a',
+		1, 0, 0, 0, 'b',
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		scenario string
+		indexes  []int32
+		valid    bool
+	}{
+		{scenario: "valid indexes", indexes: []int32{1, 0}, valid: true},
+		{scenario: "index out of bounds", indexes: []int32{0, 2}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			data, err := RLEDictionary.EncodeInt32(nil, test.indexes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			header := DataPageHeaderV1{
+				header: &format.DataPageHeader{
+					NumValues: int32(len(test.indexes)),
+					Encoding:  format.RLEDictionary,
+				},
+			}
+			page, err := column.DecodeDataPageV1(header, data, dict)
+			switch {
+			case test.valid && err != nil:
+				t.Fatal(err)
+			case !test.valid && err == nil:
+				t.Fatal("decoding a page with dictionary indexes out of bounds did not fail")
+			}
+			if page == nil {
+				return
+			}
+			defer Release(page)
+
+			values := make([]Value, len(test.indexes))
+			if n, err := page.Values().ReadValues(values); n != len(values) || (err != nil && err != io.EOF) {
+				t.Fatalf("reading values: n=%d err=%v", n, err)
+			}
+			if values[0].String() != "b" || values[1].String() != "a" {
+				t.Errorf("values mismatch: want=[b a] got=%v", values)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package parquet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// ColumnMask is the type of functions used by readers to mask the values of
+// columns, see MaskColumns.
+//
+// The functions receive non-null values read from a column and return the
+// values that programs reading the rows observe. The returned values must have
+// the same kind as the input values; their repetition level, definition level,
+// and column index are set by the reader.
+type ColumnMask func(Value) Value
+
+// RedactValue is a ColumnMask which replaces values with the zero value of
+// their kind. Fixed length byte arrays are replaced with arrays of zero bytes
+// of the same length.
+func RedactValue(v Value) Value {
+	if v.Kind() == FixedLenByteArray {
+		return FixedLenByteArrayValue(make([]byte, len(v.byteArray())))
+	}
+	return ZeroValue(v.Kind())
+}
+
+// HashValue is a ColumnMask which replaces values with their SHA-256 digest,
+// allowing masked columns to still be compared or joined on.
+//
+// Byte arrays are replaced with the 32 bytes of the digest. Fixed length byte
+// arrays are replaced with the digest truncated or padded with zero bytes to
+// their length, and INT32 and INT64 values with the leading bytes of the
+// digest. Values of other kinds are redacted, see RedactValue.
+//
+// The digest is not keyed, values with a small domain (e.g. dates of birth)
+// can be recovered by hashing all the candidate values. Programs which need
+// stronger guarantees should use their own ColumnMask, for example computing
+// an HMAC of the values.
+func HashValue(v Value) Value {
+	var digest [sha256.Size]byte
+	switch v.Kind() {
+	case Int32:
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], uint32(v.int32()))
+		digest = sha256.Sum256(b[:])
+		return Int32Value(int32(binary.LittleEndian.Uint32(digest[:])))
+	case Int64:
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], uint64(v.int64()))
+		digest = sha256.Sum256(b[:])
+		return Int64Value(int64(binary.LittleEndian.Uint64(digest[:])))
+	case ByteArray:
+		digest = sha256.Sum256(v.byteArray())
+		return ByteArrayValue(digest[:])
+	case FixedLenByteArray:
+		b := v.byteArray()
+		digest = sha256.Sum256(b)
+		masked := make([]byte, len(b))
+		copy(masked, digest[:])
+		return FixedLenByteArrayValue(masked)
+	default:
+		return RedactValue(v)
+	}
+}
+
+// columnMasksOf returns the masks applied to the columns of schema, indexed by
+// column index, or nil if no columns of the schema are masked.
+func columnMasksOf(schema *Schema, masks map[string]ColumnMask) []ColumnMask {
+	if len(masks) == 0 || schema == nil {
+		return nil
+	}
+	var columnMasks []ColumnMask
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		if mask := masks[leaf.path.String()]; mask != nil {
+			if columnMasks == nil {
+				columnMasks = make([]ColumnMask, len(schema.Columns()))
+			}
+			columnMasks[leaf.columnIndex] = mask
+		}
+	})
+	return columnMasks
+}
+
+func maskRows(rows []Row, masks []ColumnMask) {
+	for _, row := range rows {
+		for i, v := range row {
+			if columnIndex := v.Column(); columnIndex >= 0 && columnIndex < len(masks) {
+				if mask := masks[columnIndex]; mask != nil && !v.IsNull() {
+					row[i] = mask(v).Level(v.RepetitionLevel(), v.DefinitionLevel(), columnIndex)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package parquet_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReaderMaskColumns(t *testing.T) {
+	type Row struct {
+		ID    int64   `parquet:"id"`
+		Email string  `parquet:"email"`
+		SSN   *string `parquet:"ssn,optional"`
+		Name  string  `parquet:"name"`
+	}
+
+	ssn := "123-45-6789"
+	rows := []Row{
+		{ID: 1, Email: "alice@example.com", SSN: &ssn, Name: "Alice"},
+		{ID: 2, Email: "bob@example.com", Name: "Bob"},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	masks := parquet.MaskColumns(map[string]parquet.ColumnMask{
+		"email": parquet.HashValue,
+		"ssn":   parquet.RedactValue,
+	})
+
+	t.Run("generic", func(t *testing.T) {
+		reader := parquet.NewGenericReader[Row](bytes.NewReader(buffer.Bytes()), masks)
+		defer reader.Close()
+
+		got := make([]Row, len(rows))
+		n, err := reader.Read(got)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		if n != len(rows) {
+			t.Fatalf("want=%d got=%d", len(rows), n)
+		}
+
+		for i, row := range got {
+			digest := sha256.Sum256([]byte(rows[i].Email))
+			if row.Email != string(digest[:]) {
+				t.Errorf("row %d: email was not hashed: %q", i, row.Email)
+			}
+			if row.ID != rows[i].ID || row.Name != rows[i].Name {
+				t.Errorf("row %d: unmasked columns changed: want=%+v got=%+v", i, rows[i], row)
+			}
+		}
+		if got[0].SSN == nil || *got[0].SSN != "" {
+			t.Errorf("ssn was not redacted: %v", got[0].SSN)
+		}
+		if got[1].SSN != nil {
+			t.Errorf("null ssn was not preserved: %q", *got[1].SSN)
+		}
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()), masks)
+		defer reader.Close()
+
+		got := make([]parquet.Row, len(rows))
+		n, err := reader.ReadRows(got)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		if n != len(rows) {
+			t.Fatalf("want=%d got=%d", len(rows), n)
+		}
+
+		email, _ := reader.Schema().Lookup("email")
+		for i, row := range got[:n] {
+			digest := sha256.Sum256([]byte(rows[i].Email))
+			for _, v := range row {
+				if v.Column() == email.ColumnIndex && !bytes.Equal(v.ByteArray(), digest[:]) {
+					t.Errorf("row %d: email was not hashed: %q", i, v.ByteArray())
+				}
+			}
+		}
+	})
+}
+
+func TestHashValue(t *testing.T) {
+	v := parquet.FixedLenByteArrayValue(make([]byte, 16))
+	h := parquet.HashValue(v)
+	if h.Kind() != parquet.FixedLenByteArray || len(h.ByteArray()) != 16 {
+		t.Errorf("fixed length byte array changed length: want=16 got=%d", len(h.ByteArray()))
+	}
+	if a, b := parquet.HashValue(parquet.Int64Value(42)), parquet.HashValue(parquet.Int64Value(42)); a.Int64() != b.Int64() || a.Int64() == 42 {
+		t.Errorf("int64 hash is not deterministic or not masked: %d %d", a.Int64(), b.Int64())
+	}
+}
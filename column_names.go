@@ -0,0 +1,161 @@
+package parquet
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FoldCase is a column name normalization function which matches names that
+// differ only by case, for example "UserID" and "userid".
+//
+// See MatchColumnNames for details.
+func FoldCase(name string) string { return strings.ToLower(name) }
+
+// NormalizeName is a column name normalization function which matches names
+// that differ by case or by the convention used to separate words, for example
+// "user_id", "user-id", "userId", and "UserID".
+//
+// See MatchColumnNames for details.
+func NormalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', ' ':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// matchColumnNames returns a schema where the fields of schema are renamed
+// after the fields of the file schema that they match. A field matches a column
+// of the file which has the field name as alias, or which has the same name as
+// the field once both are passed through the normalize function. Fields with
+// the exact same names as columns of the file always match.
+//
+// The aliases map is keyed by dot-separated paths of columns in the file, and
+// the values are the names of the fields that the columns are read into.
+//
+// When matchIDs is true, fields which have a field id match the column of the
+// file with the same id regardless of their names. If the file has field ids
+// but none equal to the field id, the field matches no columns, even if one of
+// them has the same name.
+//
+// The function returns schema unchanged if none of the field names differ.
+func matchColumnNames(schema *Schema, file Node, normalize func(string) string, aliases map[string]string, matchIDs bool) *Schema {
+	if normalize == nil {
+		normalize = func(name string) string { return name }
+	}
+	fields, renamed := matchFieldNames(schema.Fields(), file, nil, normalize, aliases, matchIDs)
+	if !renamed {
+		return schema
+	}
+	return NewSchema(schema.Name(), &renamedGroup{Node: schema, fields: fields})
+}
+
+func matchFieldNames(fields []Field, file Node, path columnPath, normalize func(string) string, aliases map[string]string, matchIDs bool) ([]Field, bool) {
+	if file == nil || file.Leaf() {
+		return fields, false
+	}
+
+	fileFields := file.Fields()
+	fileNames := make(map[string]string, len(fileFields))
+	fileAliases := make(map[string]string)
+	fileIDs := make(map[int]string)
+	for _, f := range fileFields {
+		name := f.Name()
+		if id := f.ID(); id != 0 && matchIDs {
+			fileIDs[id] = name
+		}
+		key := normalize(name)
+		// When multiple columns of the file have the same normalized name,
+		// the first one is matched.
+		if _, exists := fileNames[key]; !exists {
+			fileNames[key] = name
+		}
+		if alias, ok := aliases[path.append(name).String()]; ok {
+			fileAliases[alias] = name
+		}
+	}
+
+	matched := make([]Field, len(fields))
+	renamed := false
+
+	for i, field := range fields {
+		name := field.Name()
+		var fileField Field
+		if id := field.ID(); id != 0 && len(fileIDs) != 0 {
+			if fileName, ok := fileIDs[id]; ok {
+				name, fileField = fileName, fieldByName(file, fileName)
+			} else {
+				name = missingFieldName(file, name)
+			}
+		} else if fileName, ok := fileAliases[name]; ok {
+			name, fileField = fileName, fieldByName(file, fileName)
+		} else if fileField = fieldByName(file, name); fileField == nil {
+			if fileName, ok := fileNames[normalize(name)]; ok {
+				name, fileField = fileName, fieldByName(file, fileName)
+			}
+		}
+
+		var children []Field
+		var childrenRenamed bool
+		if fileField != nil && !field.Leaf() {
+			children, childrenRenamed = matchFieldNames(field.Fields(), fileField, path.append(name), normalize, aliases, matchIDs)
+		}
+
+		if name == field.Name() && !childrenRenamed {
+			matched[i] = field
+			continue
+		}
+
+		f := &renamedField{Field: field, name: name}
+		if childrenRenamed {
+			f.fields = children
+		}
+		matched[i] = f
+		renamed = true
+	}
+
+	return matched, renamed
+}
+
+// missingFieldName returns a name derived from name which is not the name of
+// any of the fields of file, so that the field is read as a missing column.
+func missingFieldName(file Node, name string) string {
+	for fieldByName(file, name) != nil {
+		name += "_"
+	}
+	return name
+}
+
+// renamedGroup is used to wrap the root of a schema where some of the fields
+// were renamed.
+type renamedGroup struct {
+	Node
+	fields []Field
+}
+
+func (g *renamedGroup) String() string { return sprint("", g) }
+
+func (g *renamedGroup) Fields() []Field { return g.fields }
+
+// renamedField wraps a field to change its name, and optionally the list of its
+// children when they were renamed as well.
+type renamedField struct {
+	Field
+	name   string
+	fields []Field
+}
+
+func (f *renamedField) String() string { return sprint(f.name, f) }
+
+func (f *renamedField) Name() string { return f.name }
+
+func (f *renamedField) defaultValueOf() Value { return defaultValueOf(f.Field) }
+
+func (f *renamedField) Fields() []Field {
+	if f.fields != nil {
+		return f.fields
+	}
+	return f.Field.Fields()
+}
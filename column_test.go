@@ -1,14 +1,19 @@
 package parquet_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
 )
 
 func TestColumnPageIndex(t *testing.T) {
@@ -525,3 +530,413 @@ func valueOrder(columnType parquet.Type, values []parquet.Value) indexOrder {
 
 	return ascendingIndexOrder
 }
+
+func TestColumnPagesRewind(t *testing.T) {
+	type Row struct {
+		Value int64 `parquet:"value"`
+	}
+
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i].Value = int64(i)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(3), parquet.PageBufferSize(16)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readValues := func(pages parquet.Pages) []int64 {
+		var values []int64
+		err := forEachPage(pages, func(page parquet.Page) error {
+			return forEachValue(page.Values(), func(value parquet.Value) error {
+				values = append(values, value.Int64())
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return values
+	}
+
+	pages := f.Root().Column("value").Pages()
+	defer pages.Close()
+
+	first := readValues(pages)
+	if len(first) != len(rows) {
+		t.Fatalf("number of values mismatch: want=%d got=%d", len(rows), len(first))
+	}
+	for i, v := range first {
+		if v != int64(i) {
+			t.Fatalf("value at index %d mismatch: want=%d got=%d", i, i, v)
+		}
+	}
+
+	for _, seek := range []int64{0, 4, 0} {
+		if err := pages.SeekToRow(seek); err != nil {
+			t.Fatal(err)
+		}
+		values := readValues(pages)
+		if want := first[seek:]; !reflect.DeepEqual(want, values) {
+			t.Errorf("values mismatch after seeking to row %d: want=%v got=%v", seek, want, values)
+		}
+	}
+}
+
+func TestColumnCardinalityEstimate(t *testing.T) {
+	type Row struct {
+		ID      int64   `parquet:"id"`
+		Country string  `parquet:"country,dict"`
+		Bucket  int64   `parquet:"bucket"`
+		Note    *string `parquet:"note,optional"`
+	}
+
+	rows := make([]Row, 5000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:      int64(i),
+			Country: fmt.Sprintf("country-%d", i%20),
+			Bucket:  int64(i % 300),
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.MaxRowsPerRowGroup(1000)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	column := func(name string) *parquet.Column {
+		for _, c := range f.Root().Columns() {
+			if c.Name() == name {
+				return c
+			}
+		}
+		t.Fatalf("column %q not found", name)
+		return nil
+	}
+
+	for _, test := range []struct {
+		column  string
+		profile bool
+		want    parquet.Cardinality
+	}{
+		{column: "id", want: parquet.Cardinality{Estimate: 5000, Min: 1, Max: 5000}},
+		{column: "country", want: parquet.Cardinality{Estimate: 20, Min: 20, Max: 100}},
+		{column: "note", want: parquet.Cardinality{}},
+	} {
+		card, err := column(test.column).CardinalityEstimate(test.profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if card != test.want {
+			t.Errorf("cardinality of %q mismatch: want=%+v got=%+v", test.column, test.want, card)
+		}
+	}
+
+	card, err := column("bucket").CardinalityEstimate(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !card.Profiled || card.Estimate < 290 || card.Estimate > 310 {
+		t.Errorf("profiled cardinality of bucket out of range: %+v", card)
+	}
+
+	card, err = column("country").CardinalityEstimate(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !card.Profiled || card.Estimate != 20 {
+		t.Errorf("profiled cardinality of country mismatch: want=%d got=%+v", 20, card)
+	}
+}
+
+func TestColumnReconcileLevelCounts(t *testing.T) {
+	type Row struct {
+		Value *int32 `parquet:"value,optional"`
+	}
+	rows := make([]Row, 10)
+	for i := 5; i < len(rows); i++ {
+		v := int32(i)
+		rows[i].Value = &v
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.DataPageVersion(1), parquet.Compression(&parquet.Uncompressed)); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+
+	// Rewrite the header of the single data page of the column to record more
+	// values than the page has levels.
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offset := f.Metadata().RowGroups[0].Columns[0].MetaData.DataPageOffset
+	header := format.PageHeader{}
+	protocol := new(thrift.CompactProtocol)
+	if err := thrift.NewDecoder(protocol.NewReader(bytes.NewReader(data[offset:]))).Decode(&header); err != nil {
+		t.Fatal(err)
+	}
+	original, err := thrift.Marshal(protocol, &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.DataPageHeader.NumValues += 3
+	modified, err := thrift.Marshal(protocol, &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modified) != len(original) {
+		t.Fatalf("page header length changed from %d to %d bytes", len(original), len(modified))
+	}
+	copy(data[offset:], modified)
+
+	read := func(options ...parquet.FileOption) ([]Row, error) {
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), options...)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]Row, len(rows)+1)
+		n, err := parquet.NewGenericReader[Row](f).Read(values)
+		if err == io.EOF {
+			err = nil
+		}
+		return values[:n], err
+	}
+
+	if _, err := read(); err == nil {
+		t.Error("reading a page with mismatching level counts must fail by default")
+	}
+
+	var mismatches []parquet.LevelCountMismatch
+	values, err := read(parquet.ReconcileLevelCounts(func(m parquet.LevelCountMismatch) {
+		mismatches = append(mismatches, m)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, rows) {
+		t.Errorf("rows mismatch: want=%+v got=%+v", rows, values)
+	}
+	want := []parquet.LevelCountMismatch{{Path: []string{"value"}, NumValues: 13, NumLevels: 10}}
+	if !reflect.DeepEqual(mismatches, want) {
+		t.Errorf("mismatches: want=%+v got=%+v", want, mismatches)
+	}
+}
+
+func TestColumnDataPageV2Levels(t *testing.T) {
+	t.Run("int32", func(t *testing.T) { testDataPageV2Levels(t, func(i int) int32 { return int32(i) }) })
+	t.Run("int64", func(t *testing.T) { testDataPageV2Levels(t, func(i int) int64 { return int64(i) << 40 }) })
+	t.Run("float64", func(t *testing.T) { testDataPageV2Levels(t, func(i int) float64 { return float64(i) / 3 }) })
+	t.Run("bool", func(t *testing.T) { testDataPageV2Levels(t, func(i int) bool { return i%2 == 0 }) })
+	t.Run("string", func(t *testing.T) { testDataPageV2Levels(t, func(i int) string { return fmt.Sprintf("value-%d", i) }) })
+}
+
+func testDataPageV2Levels[T any](t *testing.T, valueOf func(int) T) {
+	type Required struct {
+		Value T `parquet:"value"`
+	}
+	type Optional struct {
+		Value *T `parquet:"value,optional"`
+	}
+	type Repeated struct {
+		Value []T `parquet:"value"`
+	}
+
+	values := make([]T, 100)
+	for i := range values {
+		values[i] = valueOf(i)
+	}
+
+	required := make([]Required, len(values))
+	optional := make([]Optional, len(values))
+	repeated := make([]Repeated, len(values))
+	for i := range values {
+		required[i].Value = values[i]
+		if i%3 != 0 {
+			optional[i].Value = &values[i]
+		}
+		repeated[i].Value = values[:i%4]
+	}
+
+	t.Run("required", func(t *testing.T) { testDataPageV2LevelsOf(t, required, 0, 0) })
+	t.Run("optional", func(t *testing.T) { testDataPageV2LevelsOf(t, optional, 0, 1) })
+	t.Run("repeated", func(t *testing.T) { testDataPageV2LevelsOf(t, repeated, 1, 1) })
+}
+
+func testDataPageV2LevelsOf[Row any](t *testing.T, rows []Row, maxRepetitionLevel, maxDefinitionLevel int) {
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.DataPageVersion(2), parquet.PageBufferSize(64)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, column := range f.Metadata().RowGroups[0].Columns {
+		header := format.PageHeader{}
+		data := buffer.Bytes()[column.MetaData.DataPageOffset:]
+		if err := thrift.NewDecoder(new(thrift.CompactProtocol).NewReader(bytes.NewReader(data))).Decode(&header); err != nil {
+			t.Fatal(err)
+		}
+		if header.DataPageHeaderV2 == nil {
+			t.Fatal("page is not a data page v2")
+		}
+		if maxRepetitionLevel == 0 && header.DataPageHeaderV2.RepetitionLevelsByteLength != 0 {
+			t.Errorf("column without repetition levels has %dB of repetition levels", header.DataPageHeaderV2.RepetitionLevelsByteLength)
+		}
+		if maxDefinitionLevel == 0 && header.DataPageHeaderV2.DefinitionLevelsByteLength != 0 {
+			t.Errorf("column without definition levels has %dB of definition levels", header.DataPageHeaderV2.DefinitionLevelsByteLength)
+		}
+	}
+
+	pages := f.Root().Columns()[0].Pages()
+	defer pages.Close()
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		if got := p.RepetitionLevels(); (maxRepetitionLevel == 0) != (got == nil) {
+			t.Errorf("repetition levels mismatch: max=%d got=%v", maxRepetitionLevel, got)
+		}
+		if got := p.DefinitionLevels(); (maxDefinitionLevel == 0) != (got == nil) {
+			t.Errorf("definition levels mismatch: max=%d got=%v", maxDefinitionLevel, got)
+		}
+		parquet.Release(p)
+	}
+
+	values := make([]Row, len(rows)+1)
+	n, err := parquet.NewGenericReader[Row](f).Read(values)
+	if err != io.EOF {
+		t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+	}
+	if !reflect.DeepEqual(values[:n], rows) {
+		t.Errorf("rows mismatch:\nwant=%+v\ngot= %+v", rows, values[:n])
+	}
+}
+
+func TestColumnDataPageV2MissingDefinitionLevels(t *testing.T) {
+	// Values of boolean columns are bit-packed, so the page can be decoded
+	// without its definition levels.
+	type Row struct {
+		Value *bool `parquet:"value,optional"`
+	}
+	rows := make([]Row, 10)
+	for i := range rows {
+		v := i%2 == 0
+		rows[i].Value = &v
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.DataPageVersion(2)); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+
+	// Rewrite the header of the data page to drop the definition levels of
+	// the optional column, which must cause an error instead of a panic.
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offset := f.Metadata().RowGroups[0].Columns[0].MetaData.DataPageOffset
+	header := format.PageHeader{}
+	protocol := new(thrift.CompactProtocol)
+	if err := thrift.NewDecoder(protocol.NewReader(bytes.NewReader(data[offset:]))).Decode(&header); err != nil {
+		t.Fatal(err)
+	}
+	original, err := thrift.Marshal(protocol, &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.DataPageHeaderV2.DefinitionLevelsByteLength = 0
+	modified, err := thrift.Marshal(protocol, &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modified) != len(original) {
+		t.Fatalf("page header length changed from %d to %d bytes", len(original), len(modified))
+	}
+	copy(data[offset:], modified)
+
+	f, err = parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parquet.NewGenericReader[Row](f).Read(make([]Row, len(rows))); err == nil || err == io.EOF {
+		t.Errorf("reading a page with missing definition levels must fail, got %v", err)
+	}
+}
+
+func TestColumnValidateUTF8(t *testing.T) {
+	type Row struct {
+		Plain string `parquet:"plain"`
+		Dict  string `parquet:"dict,dict"`
+		Bytes []byte `parquet:"bytes"`
+	}
+	rows := []Row{
+		{Plain: "hello", Dict: "a", Bytes: []byte("\xff")},
+		{Plain: "h\xffllo\xfe\xfd", Dict: "b\xc3", Bytes: []byte("ok")},
+		{Plain: "héllo", Dict: "a", Bytes: []byte("\xc3")},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+
+	read := func(options ...parquet.FileOption) ([]Row, error) {
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), options...)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]Row, len(rows))
+		n, err := parquet.NewGenericReader[Row](f).Read(values)
+		if err == io.EOF {
+			err = nil
+		}
+		return values[:n], err
+	}
+
+	values, err := read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, rows) {
+		t.Errorf("rows mismatch: want=%q got=%q", rows, values)
+	}
+
+	for _, lazy := range []int{0, 1} {
+		if _, err := read(parquet.ValidateUTF8(parquet.UTF8Reject), parquet.LazyDictionarySize(lazy)); !errors.Is(err, parquet.ErrInvalidUTF8) {
+			t.Errorf("lazy=%d: want=%v got=%v", lazy, parquet.ErrInvalidUTF8, err)
+		}
+
+		values, err := read(parquet.ValidateUTF8(parquet.UTF8Replace), parquet.LazyDictionarySize(lazy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Row{
+			{Plain: "hello", Dict: "a", Bytes: []byte("\xff")},
+			{Plain: "h�llo�", Dict: "b�", Bytes: []byte("ok")},
+			{Plain: "héllo", Dict: "a", Bytes: []byte("\xc3")},
+		}
+		if !reflect.DeepEqual(values, want) {
+			t.Errorf("lazy=%d: rows mismatch: want=%q got=%q", lazy, want, values)
+		}
+	}
+}
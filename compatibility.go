@@ -0,0 +1,210 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+// CompatibilityTarget represents a system that parquet files are loaded into,
+// used to check whether the types of a schema are supported by the system.
+type CompatibilityTarget int
+
+const (
+	// TargetBigQuery checks compatibility with Google BigQuery load jobs.
+	TargetBigQuery CompatibilityTarget = iota + 1
+	// TargetSnowflake checks compatibility with Snowflake COPY INTO commands.
+	TargetSnowflake
+	// TargetCSV checks whether the columns can be flattened into CSV records.
+	TargetCSV
+)
+
+// maxBigQueryNestingDepth is the maximum depth of nested records supported by
+// BigQuery.
+const maxBigQueryNestingDepth = 15
+
+// String returns a human-readable name of the target.
+func (t CompatibilityTarget) String() string {
+	switch t {
+	case TargetBigQuery:
+		return "BigQuery"
+	case TargetSnowflake:
+		return "Snowflake"
+	case TargetCSV:
+		return "CSV"
+	default:
+		return fmt.Sprintf("CompatibilityTarget(%d)", int(t))
+	}
+}
+
+// CompatibilityIssue describes a column of a parquet schema which may not be
+// loaded as-is into a target system.
+type CompatibilityIssue struct {
+	// Path to the column in the schema.
+	Path []string
+	// The parquet type of the column.
+	Type string
+	// Describes why the column would not round-trip into the target system.
+	Reason string
+}
+
+// String returns a human-readable representation of the issue.
+func (issue CompatibilityIssue) String() string {
+	return fmt.Sprintf("%s (%s): %s", strings.Join(issue.Path, "."), issue.Type, issue.Reason)
+}
+
+// CheckCompatibility inspects the columns of node and returns the list of
+// issues that would prevent the data from round-tripping into the target
+// system, for example because the system does not support the logical type of
+// a column, or would convert it to a type with less precision.
+//
+// The function is intended to catch problems before load jobs fail or silently
+// alter the data; an empty result does not guarantee that a load will succeed.
+func CheckCompatibility(node Node, target CompatibilityTarget) []CompatibilityIssue {
+	c := compatibilityChecker{target: target}
+	for _, field := range node.Fields() {
+		c.check([]string{field.Name()}, field, 1)
+	}
+	return c.issues
+}
+
+type compatibilityChecker struct {
+	target CompatibilityTarget
+	issues []CompatibilityIssue
+}
+
+func (c *compatibilityChecker) report(path []string, node Node, format string, args ...any) {
+	c.issues = append(c.issues, CompatibilityIssue{
+		Path:   append([]string{}, path...),
+		Type:   compatibilityTypeOf(node),
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *compatibilityChecker) check(path []string, node Node, depth int) {
+	if node.Leaf() {
+		if c.target == TargetCSV && node.Repeated() {
+			c.report(path, node, "repeated columns cannot be represented in CSV records")
+			return
+		}
+		c.checkLeaf(path, node)
+		return
+	}
+
+	switch c.target {
+	case TargetCSV:
+		c.report(path, node, "nested columns cannot be represented in CSV records")
+		return
+
+	case TargetSnowflake:
+		c.report(path, node, "nested columns are loaded as semi-structured VARIANT values")
+		return
+
+	case TargetBigQuery:
+		if depth > maxBigQueryNestingDepth {
+			c.report(path, node, "BigQuery does not support more than %d levels of nested records", maxBigQueryNestingDepth)
+			return
+		}
+		switch {
+		case isMapGroup(node):
+			c.report(path, node, "maps are loaded as repeated records of key/value pairs")
+		case isListGroup(node) && hasRepeatedElements(node):
+			c.report(path, node, "BigQuery does not support arrays of arrays")
+		}
+	}
+
+	for _, field := range node.Fields() {
+		c.check(append(path, field.Name()), field, depth+1)
+	}
+}
+
+func hasRepeatedElements(list Node) bool {
+	repeated := list.Fields()[0]
+	if repeated.Leaf() || len(repeated.Fields()) != 1 {
+		return false
+	}
+	element := repeated.Fields()[0]
+	return element.Repeated() || isListGroup(element)
+}
+
+func (c *compatibilityChecker) checkLeaf(path []string, node Node) {
+	t := node.Type()
+	lt := t.LogicalType()
+
+	if t.Kind() == Int96 {
+		switch c.target {
+		case TargetBigQuery:
+			c.report(path, node, "deprecated INT96 timestamps are converted to TIMESTAMP values with microsecond precision")
+		case TargetCSV:
+			c.report(path, node, "deprecated INT96 timestamps have no standard text representation")
+		}
+		return
+	}
+
+	if lt == nil {
+		if c.target == TargetCSV && (t.Kind() == ByteArray || t.Kind() == FixedLenByteArray) && !isStringConvertedType(t) {
+			c.report(path, node, "binary values must be encoded to be represented in CSV records")
+		}
+		return
+	}
+
+	switch {
+	case lt.Timestamp != nil:
+		if lt.Timestamp.Unit.Nanos != nil && c.target == TargetBigQuery {
+			c.report(path, node, "BigQuery timestamps have microsecond precision, nanosecond timestamps are loaded as INT64 values")
+		}
+
+	case lt.Time != nil:
+		if lt.Time.Unit.Nanos != nil && c.target == TargetBigQuery {
+			c.report(path, node, "BigQuery times have microsecond precision, nanosecond times are loaded as INT64 values")
+		}
+
+	case lt.Integer != nil:
+		if !lt.Integer.IsSigned && lt.Integer.BitWidth == 64 && c.target == TargetBigQuery {
+			c.report(path, node, "unsigned 64 bits integers greater than %d overflow BigQuery INT64 values", int64(1<<63-1))
+		}
+
+	case lt.Decimal != nil:
+		switch c.target {
+		case TargetBigQuery:
+			if lt.Decimal.Precision > 76 || lt.Decimal.Scale > 38 {
+				c.report(path, node, "BigQuery supports decimals with a precision of up to 76 digits and a scale of up to 38 digits")
+			}
+		case TargetSnowflake:
+			if lt.Decimal.Precision > 38 {
+				c.report(path, node, "Snowflake supports decimals with a precision of up to 38 digits")
+			}
+		}
+
+	case lt.UUID != nil:
+		if c.target != TargetCSV {
+			c.report(path, node, "UUIDs are loaded as binary values")
+		} else {
+			c.report(path, node, "binary values must be encoded to be represented in CSV records")
+		}
+
+	case lt.Bson != nil:
+		if c.target == TargetCSV {
+			c.report(path, node, "binary values must be encoded to be represented in CSV records")
+		}
+	}
+}
+
+func isStringConvertedType(t Type) bool {
+	ct := t.ConvertedType()
+	return ct != nil && (*ct == deprecated.UTF8 || *ct == deprecated.Enum)
+}
+
+func compatibilityTypeOf(node Node) string {
+	switch {
+	case node.Leaf():
+		return node.Type().String()
+	case isListGroup(node):
+		return "LIST"
+	case isMapGroup(node):
+		return "MAP"
+	default:
+		return "group"
+	}
+}
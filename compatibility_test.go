@@ -0,0 +1,62 @@
+package parquet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	type Row struct {
+		ID       uint64             `parquet:"id"`
+		Name     string             `parquet:"name"`
+		Legacy   deprecated.Int96   `parquet:"legacy"`
+		Nanos    int64              `parquet:"nanos,timestamp(nanosecond)"`
+		Micros   int64              `parquet:"micros,timestamp(microsecond)"`
+		Payload  []byte             `parquet:"payload"`
+		Tags     []string           `parquet:"tags,list"`
+		Matrix   [][]int32          `parquet:"matrix,list"`
+		Labels   map[string]string  `parquet:"labels"`
+		Location struct{ X, Y int } `parquet:"location"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+
+	paths := func(issues []parquet.CompatibilityIssue) []string {
+		var paths []string
+		for _, issue := range issues {
+			paths = append(paths, issue.Path[len(issue.Path)-1])
+		}
+		return paths
+	}
+
+	for _, test := range []struct {
+		target parquet.CompatibilityTarget
+		want   []string
+	}{
+		{
+			target: parquet.TargetBigQuery,
+			want:   []string{"id", "legacy", "nanos", "matrix", "labels"},
+		},
+		{
+			target: parquet.TargetSnowflake,
+			want:   []string{"tags", "matrix", "labels", "location"},
+		},
+		{
+			target: parquet.TargetCSV,
+			want:   []string{"legacy", "payload", "tags", "matrix", "labels", "location"},
+		},
+	} {
+		t.Run(test.target.String(), func(t *testing.T) {
+			issues := parquet.CheckCompatibility(schema, test.target)
+			for _, issue := range issues {
+				t.Log(issue)
+			}
+			if got := paths(issues); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("columns mismatch:\nwant: %q\ngot:  %q", test.want, got)
+			}
+		})
+	}
+}
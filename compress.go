@@ -1,6 +1,8 @@
 package parquet
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/parquet-go/parquet-go/compress"
@@ -42,12 +44,20 @@ var (
 		Level: lz4.DefaultLevel,
 	}
 
+	// Lz4 is the deprecated LZ4 parquet compression codec, with the Hadoop
+	// block framing used by parquet-mr. It allows reading legacy files,
+	// programs should use Lz4Raw to write new files.
+	Lz4 = lz4.HadoopCodec{
+		Level: lz4.DefaultLevel,
+	}
+
 	// Table of compression codecs indexed by their code in the parquet format.
 	compressionCodecs = [...]compress.Codec{
 		format.Uncompressed: &Uncompressed,
 		format.Snappy:       &Snappy,
 		format.Gzip:         &Gzip,
 		format.Brotli:       &Brotli,
+		format.Lz4:          &Lz4,
 		format.Zstd:         &Zstd,
 		format.Lz4Raw:       &Lz4Raw,
 	}
@@ -91,6 +101,102 @@ func (u *unsupported) error() error {
 	return fmt.Errorf("unsupported compression codec: %s", u.codec)
 }
 
+// withCompressionLevel returns a codec of the same type as codec configured to
+// compress at the given level. The level is expressed on the scale of each
+// compression algorithm: 1 to 22 for ZSTD, -2 to 9 for GZIP, and 0 to 11 for
+// BROTLI. Codecs which do not support levels are returned unchanged.
+func withCompressionLevel(codec compress.Codec, level int) compress.Codec {
+	switch c := codec.(type) {
+	case *zstd.Codec:
+		return &zstd.Codec{Level: zstd.LevelFromZstd(level), Dictionary: c.Dictionary}
+	case *gzip.Codec:
+		return &gzip.Codec{Level: level}
+	case *brotli.Codec:
+		return &brotli.Codec{Quality: level, LGWin: c.LGWin}
+	default:
+		return codec
+	}
+}
+
+// withCompressionDictionary returns a ZSTD codec compressing pages with the
+// given raw dictionary, at the level of codec if it is a ZSTD codec.
+func withCompressionDictionary(codec compress.Codec, dict []byte) compress.Codec {
+	level := zstd.DefaultLevel
+	if c, ok := codec.(*zstd.Codec); ok && c.Level != 0 {
+		level = c.Level
+	}
+	return &zstd.Codec{Level: level, Dictionary: dict}
+}
+
+// compressionDictionaryOf returns the raw dictionary that codec compresses
+// pages with, or nil if it does not use a dictionary.
+func compressionDictionaryOf(codec compress.Codec) []byte {
+	if c, ok := codec.(*zstd.Codec); ok {
+		return c.Dictionary
+	}
+	return nil
+}
+
+// Key/value metadata of files holding the base64 encoding of the zstd
+// dictionary of a column, followed by the path of the column.
+const zstdDictionaryKeyPrefix = "parquet-go.zstd_dictionary."
+
+type compressionLevelKey struct {
+	codec compress.Codec
+	level int
+}
+
+type compressionLevelCache map[compressionLevelKey]compress.Codec
+
+func (cache compressionLevelCache) get(codec compress.Codec, level int) compress.Codec {
+	switch codec.(type) {
+	case *zstd.Codec, *gzip.Codec, *brotli.Codec:
+	default:
+		return codec
+	}
+	key := compressionLevelKey{codec: codec, level: level}
+	c, ok := cache[key]
+	if !ok {
+		c = withCompressionLevel(codec, level)
+		cache[key] = c
+	}
+	return c
+}
+
+// searchCompressionLevel returns the compression level configured for the
+// longest prefix of path found in levels, where keys are column paths joined
+// with dots and the empty key applies to all columns.
+func searchCompressionLevel(levels map[string]int, path columnPath) (int, bool) {
+	for n := len(path); n >= 0 && len(levels) > 0; n-- {
+		if level, ok := levels[path[:n].String()]; ok {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// detectCompressionCodec returns the compression codec that data appears to
+// be compressed with, or nil if it could not be detected. The uncompressed
+// size is used to recognize codecs which have no magic bytes, it is ignored
+// when negative.
+func detectCompressionCodec(data []byte, uncompressedSize int32) compress.Codec {
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		return &Gzip
+	case bytes.HasPrefix(data, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		return &Zstd
+	case uncompressedSize < 0:
+		return nil
+	case len(data) == int(uncompressedSize):
+		return &Uncompressed
+	}
+	// Snappy blocks start with the varint encoding of the decoded length.
+	if n, size := binary.Uvarint(data); size > 0 && n == uint64(uncompressedSize) {
+		return &Snappy
+	}
+	return nil
+}
+
 func isCompressed(c compress.Codec) bool {
 	return c != nil && c.CompressionCodec() != format.Uncompressed
 }
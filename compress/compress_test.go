@@ -3,6 +3,7 @@ package compress_test
 import (
 	"bytes"
 	_ "embed"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"testing"
@@ -65,6 +66,11 @@ var tests = [...]struct {
 		scenario: "lz4-l9",
 		codec:    &lz4.Codec{Level: lz4.Level9},
 	},
+
+	{
+		scenario: "lz4-hadoop",
+		codec:    &lz4.HadoopCodec{Level: lz4.Fast},
+	},
 }
 
 var (
@@ -113,6 +119,114 @@ func TestCompressionCodec(t *testing.T) {
 	}
 }
 
+func TestLz4HadoopCodec(t *testing.T) {
+	compressBlock := func(data []byte) []byte {
+		block, err := new(lz4.Codec).Encode(nil, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return block
+	}
+
+	appendUint32 := func(b []byte, v int) []byte {
+		return binary.BigEndian.AppendUint32(b, uint32(v))
+	}
+
+	// Parquet-mr splits pages in multiple blocks, which may each be made of
+	// multiple LZ4 blocks.
+	block1, block2, block3 := testdata[:1000], testdata[1000:5000], testdata[5000:]
+	framed := appendUint32(nil, len(block1))
+	framed = appendUint32(framed, len(compressBlock(block1)))
+	framed = append(framed, compressBlock(block1)...)
+	framed = appendUint32(framed, len(block2)+len(block3))
+	framed = appendUint32(framed, len(compressBlock(block2)))
+	framed = append(framed, compressBlock(block2)...)
+	framed = appendUint32(framed, len(compressBlock(block3)))
+	framed = append(framed, compressBlock(block3)...)
+
+	for _, test := range []struct {
+		scenario string
+		input    []byte
+	}{
+		{scenario: "hadoop framing", input: framed},
+		{scenario: "raw block", input: compressBlock(testdata)},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			output, err := new(lz4.HadoopCodec).Decode(nil, test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(testdata, output) {
+				t.Error("content mismatch after decompressing")
+			}
+		})
+	}
+
+	if _, err := new(lz4.HadoopCodec).Decode(nil, []byte("not an LZ4 page")); err == nil {
+		t.Error("expected an error when decoding invalid LZ4 data")
+	}
+}
+
+func TestZstdDictionary(t *testing.T) {
+	// Small pages of similar lines of text, which are too small to compress
+	// well without a dictionary.
+	lines := bytes.Split(testdataTomSawyer, []byte("\n"))
+	pages := make([][]byte, 0, len(lines)/4)
+	for i := 0; i+4 <= len(lines); i += 4 {
+		pages = append(pages, bytes.Join(lines[i:i+4], []byte("\n")))
+	}
+	samples, pages := pages[:len(pages)/2], pages[len(pages)/2:]
+
+	dict := zstd.TrainDictionary(samples, 8192)
+	if len(dict) == 0 || len(dict) > 8192 {
+		t.Fatalf("invalid dictionary size: %d", len(dict))
+	}
+	if small := zstd.TrainDictionary(samples[:2], 8192); !bytes.Equal(small, append(append([]byte{}, samples[0]...), samples[1]...)) {
+		t.Error("dictionary of samples smaller than the dictionary size must be the concatenation of the samples")
+	}
+
+	codec := &zstd.Codec{Dictionary: dict}
+	plain := new(zstd.Codec)
+	plainSize, dictSize := 0, 0
+
+	for _, page := range pages {
+		compressed, err := codec.Encode(nil, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decompressed, err := codec.Decode(nil, compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decompressed, page) {
+			t.Fatal("content mismatch after compressing and decompressing with a dictionary")
+		}
+		if len(page) > 0 {
+			if _, err := plain.Decode(nil, compressed); err == nil {
+				t.Fatal("decompressing a page compressed with a dictionary must fail without the dictionary")
+			}
+		}
+		dictSize += len(compressed)
+
+		compressed, err = plain.Encode(nil, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decompressed, err = codec.Decode(nil, compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decompressed, page) {
+			t.Fatal("content mismatch after decompressing with a dictionary a page compressed without one")
+		}
+		plainSize += len(compressed)
+	}
+
+	if dictSize >= plainSize {
+		t.Errorf("pages compressed with a dictionary must be smaller: plain=%d dictionary=%d", plainSize, dictSize)
+	}
+}
+
 func BenchmarkEncode(b *testing.B) {
 	buffer := make([]byte, 0, len(testdata))
 
@@ -0,0 +1,130 @@
+package lz4
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/pierrec/lz4/v4"
+)
+
+// HadoopCodec implements the deprecated LZ4 parquet compression codec, found
+// in files written by older versions of Hive and Spark.
+//
+// Parquet-mr compresses LZ4 pages with the block framing of the Hadoop Lz4Codec,
+// where each block starts with its uncompressed length and is made of one or
+// more LZ4 blocks prefixed with their compressed length, all as 4 bytes big
+// endian integers. Other writers, such as older versions of parquet-cpp, used
+// the LZ4 codec for raw LZ4 blocks; the codec falls back to decoding raw LZ4
+// blocks when the page does not have the Hadoop framing.
+//
+// The codec is provided to read legacy files, programs should use Codec to
+// write LZ4_RAW pages instead.
+type HadoopCodec struct {
+	Level Level
+}
+
+func (c *HadoopCodec) String() string {
+	return "LZ4"
+}
+
+func (c *HadoopCodec) CompressionCodec() format.CompressionCodec {
+	return format.Lz4
+}
+
+func (c *HadoopCodec) Encode(dst, src []byte) ([]byte, error) {
+	const headerSize = 8
+	if len(src) == 0 {
+		return dst[:0], nil
+	}
+	dst = reserveAtLeast(dst, headerSize+lz4.CompressBlockBound(len(src)))
+	n, err := (&Codec{Level: c.Level}).compressBlock(src, dst[headerSize:])
+	if err != nil {
+		return dst[:0], err
+	}
+	binary.BigEndian.PutUint32(dst[0:], uint32(len(src)))
+	binary.BigEndian.PutUint32(dst[4:], uint32(n))
+	return dst[:headerSize+n], nil
+}
+
+func (c *HadoopCodec) Decode(dst, src []byte) ([]byte, error) {
+	if out, ok := decodeHadoop(dst[:0], src); ok {
+		return out, nil
+	}
+	out, err := decodeBlock(dst[:0], src)
+	if err != nil {
+		return dst[:0], fmt.Errorf("decoding LZ4 page which has neither the hadoop framing nor is a raw LZ4 block: %w", err)
+	}
+	return out, nil
+}
+
+// maxCompressionRatio is the maximum ratio between the uncompressed and
+// compressed sizes of LZ4 blocks, used to validate the sizes read from the
+// Hadoop framing and bound the size of output buffers.
+const maxCompressionRatio = 255
+
+// decodeHadoop decodes the blocks of src with the Hadoop framing, returning
+// false if src does not have the Hadoop framing.
+func decodeHadoop(dst, src []byte) ([]byte, bool) {
+	for len(src) > 0 {
+		if len(src) < 4 {
+			return dst, false
+		}
+		uncompressedSize := uint64(binary.BigEndian.Uint32(src))
+		src = src[4:]
+		if uncompressedSize > maxCompressionRatio*uint64(len(src)) {
+			return dst, false
+		}
+
+		end := len(dst) + int(uncompressedSize)
+		if cap(dst) < end {
+			newDst := make([]byte, len(dst), end)
+			copy(newDst, dst)
+			dst = newDst
+		}
+
+		for len(dst) < end {
+			if len(src) < 4 {
+				return dst, false
+			}
+			compressedSize := uint64(binary.BigEndian.Uint32(src))
+			src = src[4:]
+			if compressedSize > uint64(len(src)) {
+				return dst, false
+			}
+			n, err := lz4.UncompressBlock(src[:compressedSize], dst[len(dst):end])
+			if err != nil {
+				return dst, false
+			}
+			dst = dst[:len(dst)+n]
+			src = src[compressedSize:]
+		}
+
+		if len(dst) != end {
+			return dst, false
+		}
+	}
+	return dst, true
+}
+
+// decodeBlock decodes the raw LZ4 block in src. Unlike Codec.Decode, the size
+// of the output buffer is bounded, so the function returns an error instead of
+// growing the buffer indefinitely when src is not a valid LZ4 block.
+func decodeBlock(dst, src []byte) ([]byte, error) {
+	maxSize := maxCompressionRatio * (len(src) + 1)
+	size := 3 * len(src)
+	for {
+		if size > maxSize {
+			size = maxSize
+		}
+		dst = reserveAtLeast(dst, size)
+		n, err := lz4.UncompressBlock(src, dst)
+		if err == nil {
+			return dst[:n], nil
+		}
+		if size == maxSize {
+			return dst[:0], err
+		}
+		size *= 2
+	}
+}
@@ -40,19 +40,17 @@ func (c *Codec) CompressionCodec() format.CompressionCodec {
 
 func (c *Codec) Encode(dst, src []byte) ([]byte, error) {
 	dst = reserveAtLeast(dst, lz4.CompressBlockBound(len(src)))
+	n, err := c.compressBlock(src, dst)
+	return dst[:n], err
+}
 
-	var (
-		n   int
-		err error
-	)
+func (c *Codec) compressBlock(src, dst []byte) (int, error) {
 	if c.Level == Fastest {
 		compressor := lz4.Compressor{}
-		n, err = compressor.CompressBlock(src, dst)
-	} else {
-		compressor := lz4.CompressorHC{Level: c.Level}
-		n, err = compressor.CompressBlock(src, dst)
+		return compressor.CompressBlock(src, dst)
 	}
-	return dst[:n], err
+	compressor := lz4.CompressorHC{Level: c.Level}
+	return compressor.CompressBlock(src, dst)
 }
 
 func (c *Codec) Decode(dst, src []byte) ([]byte, error) {
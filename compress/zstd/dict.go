@@ -0,0 +1,160 @@
+package zstd
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"sort"
+)
+
+const (
+	// DefaultDictionarySize is the dictionary size used by TrainDictionary
+	// when the size passed as argument is zero or negative.
+	DefaultDictionarySize = 16 * 1024
+
+	// Length of the byte sequences counted to score segments of the samples,
+	// and length of the segments copied to dictionaries.
+	dictionaryDmerSize    = 8
+	dictionarySegmentSize = 256
+)
+
+// TrainDictionary builds a raw zstd dictionary of at most size bytes from
+// samples of the data that the dictionary will be used to compress, which can
+// then be set as the Dictionary of a Codec.
+//
+// Dictionaries improve the compression ratio of small pages of similar
+// content, for example pages of strings sharing prefixes or vocabulary, which
+// are too small for the compressor to find repetitions within a single page.
+// Samples should be representative of the pages to compress; the values of a
+// column, or the content of pages of a column chunk, both make good samples.
+//
+// The dictionary is made of the segments of the samples holding the byte
+// sequences which occur in the most samples, with the most frequent segments
+// at the end of the dictionary where they are the cheapest to reference. When
+// the samples are smaller than the dictionary size, the dictionary is the
+// concatenation of the samples.
+func TrainDictionary(samples [][]byte, size int) []byte {
+	if size <= 0 {
+		size = DefaultDictionarySize
+	}
+
+	totalSize := 0
+	for _, sample := range samples {
+		totalSize += len(sample)
+	}
+	if totalSize <= size {
+		dict := make([]byte, 0, totalSize)
+		for _, sample := range samples {
+			dict = append(dict, sample...)
+		}
+		return dict
+	}
+
+	// Count the number of samples that each byte sequence occurs in, so
+	// sequences which are repeated within a single sample, which the
+	// compressor can already find without the dictionary, are not favored.
+	frequencies := make(map[uint64]int)
+	seen := make(map[uint64]struct{})
+	for _, sample := range samples {
+		for i := 0; i+dictionaryDmerSize <= len(sample); i++ {
+			dmer := binary.LittleEndian.Uint64(sample[i:])
+			if _, ok := seen[dmer]; !ok {
+				seen[dmer] = struct{}{}
+				frequencies[dmer]++
+			}
+		}
+		for dmer := range seen {
+			delete(seen, dmer)
+		}
+	}
+
+	// The samples are divided in as many epochs as there are segments in the
+	// dictionary, and the best segments of each epoch are selected until they
+	// add up to the segment size. Selecting a segment clears the frequencies
+	// of its byte sequences, which prevents copying the same content multiple
+	// times in the dictionary.
+	type segment struct {
+		sample, offset, length, score int
+	}
+	numEpochs := (size + dictionarySegmentSize - 1) / dictionarySegmentSize
+	epochSize := (totalSize + numEpochs - 1) / numEpochs
+	segments := make([]segment, 0, numEpochs)
+
+	// scan returns the best segment starting in the epoch beginning at the
+	// given position, and the position of the next epoch.
+	scan := func(sampleIndex, sampleOffset int) (best segment, nextIndex, nextOffset int) {
+		for remain := epochSize; remain > 0 && sampleIndex < len(samples); {
+			sample := samples[sampleIndex]
+			end := min(len(sample), sampleOffset+remain)
+			// Score the windows starting in [sampleOffset:end) with a rolling
+			// sum of the frequencies of the byte sequences they contain.
+			score, windowStart := 0, sampleOffset
+			for i := sampleOffset; i+dictionaryDmerSize <= len(sample) && i < end+dictionarySegmentSize-dictionaryDmerSize; i++ {
+				score += frequencies[binary.LittleEndian.Uint64(sample[i:])]
+				if i-windowStart > dictionarySegmentSize-dictionaryDmerSize {
+					score -= frequencies[binary.LittleEndian.Uint64(sample[windowStart:])]
+					windowStart++
+				}
+				if windowStart < end && score > best.score {
+					best = segment{
+						sample: sampleIndex,
+						offset: windowStart,
+						length: min(dictionarySegmentSize, len(sample)-windowStart),
+						score:  score,
+					}
+				}
+			}
+			remain -= end - sampleOffset
+			if sampleOffset = end; sampleOffset == len(sample) {
+				sampleIndex, sampleOffset = sampleIndex+1, 0
+			}
+		}
+		return best, sampleIndex, sampleOffset
+	}
+
+	for epoch, sampleIndex, sampleOffset := 0, 0, 0; epoch < numEpochs && sampleIndex < len(samples); epoch++ {
+		nextIndex, nextOffset := sampleIndex, sampleOffset
+		for length := 0; length < dictionarySegmentSize; {
+			var best segment
+			best, nextIndex, nextOffset = scan(sampleIndex, sampleOffset)
+			if best.score == 0 {
+				break
+			}
+			content := samples[best.sample][best.offset : best.offset+best.length]
+			for i := 0; i+dictionaryDmerSize <= len(content); i++ {
+				delete(frequencies, binary.LittleEndian.Uint64(content[i:]))
+			}
+			segments = append(segments, best)
+			length += best.length
+		}
+		sampleIndex, sampleOffset = nextIndex, nextOffset
+	}
+
+	// Sort segments by increasing score so the most frequent content is at
+	// the end of the dictionary.
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].score < segments[j].score
+	})
+
+	dict := make([]byte, 0, len(segments)*dictionarySegmentSize)
+	for _, s := range segments {
+		dict = append(dict, samples[s.sample][s.offset:s.offset+s.length]...)
+	}
+	if len(dict) > size {
+		dict = dict[len(dict)-size:]
+	}
+	return dict
+}
+
+// dictionaryID returns the identifier of a raw dictionary recorded in the
+// headers of frames compressed with it. Identifiers below 32768 are reserved
+// by the zstd specification for registered dictionaries.
+func dictionaryID(dict []byte) uint32 {
+	return crc32.ChecksumIEEE(dict) | 1<<31
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -33,9 +33,24 @@ const (
 	DefaultLevel = SpeedDefault
 )
 
+// LevelFromZstd returns the encoder level which best matches the given level
+// on the scale of the reference zstd implementation (1 to 22).
+func LevelFromZstd(level int) Level {
+	return zstd.EncoderLevelFromZstd(level)
+}
+
 type Codec struct {
 	Level Level
 
+	// Dictionary is the content of a raw zstd dictionary used to compress
+	// and decompress pages, see TrainDictionary. Pages compressed with a
+	// dictionary can only be decompressed by codecs configured with the same
+	// dictionary, while codecs with a dictionary can also decompress pages
+	// which were compressed without one.
+	//
+	// The dictionary must not be modified after the codec was first used.
+	Dictionary []byte
+
 	encoders sync.Pool // *zstd.Encoder
 	decoders sync.Pool // *zstd.Decoder
 }
@@ -52,12 +67,16 @@ func (c *Codec) Encode(dst, src []byte) ([]byte, error) {
 	e, _ := c.encoders.Get().(*zstd.Encoder)
 	if e == nil {
 		var err error
-		e, err = zstd.NewWriter(nil,
+		options := []zstd.EOption{
 			zstd.WithEncoderConcurrency(1),
 			zstd.WithEncoderLevel(c.level()),
 			zstd.WithZeroFrames(true),
 			zstd.WithEncoderCRC(false),
-		)
+		}
+		if len(c.Dictionary) != 0 {
+			options = append(options, zstd.WithEncoderDictRaw(dictionaryID(c.Dictionary), c.Dictionary))
+		}
+		e, err = zstd.NewWriter(nil, options...)
 		if err != nil {
 			return dst[:0], err
 		}
@@ -70,9 +89,13 @@ func (c *Codec) Decode(dst, src []byte) ([]byte, error) {
 	d, _ := c.decoders.Get().(*zstd.Decoder)
 	if d == nil {
 		var err error
-		d, err = zstd.NewReader(nil,
+		options := []zstd.DOption{
 			zstd.WithDecoderConcurrency(1),
-		)
+		}
+		if len(c.Dictionary) != 0 {
+			options = append(options, zstd.WithDecoderDictRaw(dictionaryID(c.Dictionary), c.Dictionary))
+		}
+		d, err = zstd.NewReader(nil, options...)
 		if err != nil {
 			return dst[:0], err
 		}
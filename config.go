@@ -1,13 +1,17 @@
 package parquet
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"runtime"
 	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 // ReadMode is an enum that is used to configure the way that a File reads pages.
@@ -18,17 +22,34 @@ const (
 	ReadModeAsync                 // ReadModeAsync reads pages asynchronously in the background.
 )
 
+// UTF8Validation controls how invalid UTF-8 sequences are handled when reading
+// the values of STRING columns, see ValidateUTF8.
+type UTF8Validation int
+
+const (
+	UTF8PassThrough UTF8Validation = iota // UTF8PassThrough reads values as raw bytes without validation (Default).
+	UTF8Reject                            // UTF8Reject fails reading pages which contain invalid UTF-8 values.
+	UTF8Replace                           // UTF8Replace replaces invalid UTF-8 sequences with the U+FFFD replacement character.
+)
+
 const (
 	DefaultColumnIndexSizeLimit = 16
 	DefaultColumnBufferCapacity = 16 * 1024
 	DefaultPageBufferSize       = 256 * 1024
 	DefaultWriteBufferSize      = 32 * 1024
+	DefaultFlushConcurrency     = 1
 	DefaultDataPageVersion      = 2
 	DefaultDataPageStatistics   = false
+	DefaultStrictTimestamps     = false
 	DefaultSkipPageIndex        = false
 	DefaultSkipBloomFilters     = false
 	DefaultMaxRowsPerRowGroup   = math.MaxInt64
 	DefaultReadMode             = ReadModeSync
+	DefaultLazyDictionarySize   = 0
+	DefaultCollectReadStats     = false
+	DefaultLazyColumnMetadata   = false
+	DefaultFindLastFooter       = false
+	DefaultValidateUTF8         = UTF8PassThrough
 )
 
 const (
@@ -90,22 +111,38 @@ func formatCreatedBy(application, version, build string) string {
 //		ReadMode:         ReadModeAsync,
 //	})
 type FileConfig struct {
-	SkipPageIndex    bool
-	SkipBloomFilters bool
-	ReadBufferSize   int
-	ReadMode         ReadMode
-	Schema           *Schema
+	SkipPageIndex        bool
+	SkipBloomFilters     bool
+	ReadBufferSize       int
+	ReadMode             ReadMode
+	Schema               *Schema
+	LazyDictionarySize   int
+	CollectReadStats     bool
+	LazyColumnMetadata   bool
+	FindLastFooter       bool
+	PageTransforms       []PageTransform
+	ColumnAccess         ColumnAccessFunc
+	AccessContext        context.Context
+	PageBufferPool       *PageBufferPool
+	DetectCompression    func(CompressionMismatch)
+	ReconcileLevelCounts func(LevelCountMismatch)
+	ValidateUTF8         UTF8Validation
 }
 
 // DefaultFileConfig returns a new FileConfig value initialized with the
 // default file configuration.
 func DefaultFileConfig() *FileConfig {
 	return &FileConfig{
-		SkipPageIndex:    DefaultSkipPageIndex,
-		SkipBloomFilters: DefaultSkipBloomFilters,
-		ReadBufferSize:   defaultReadBufferSize,
-		ReadMode:         DefaultReadMode,
-		Schema:           nil,
+		SkipPageIndex:      DefaultSkipPageIndex,
+		SkipBloomFilters:   DefaultSkipBloomFilters,
+		ReadBufferSize:     defaultReadBufferSize,
+		ReadMode:           DefaultReadMode,
+		Schema:             nil,
+		LazyDictionarySize: DefaultLazyDictionarySize,
+		CollectReadStats:   DefaultCollectReadStats,
+		LazyColumnMetadata: DefaultLazyColumnMetadata,
+		FindLastFooter:     DefaultFindLastFooter,
+		ValidateUTF8:       DefaultValidateUTF8,
 	}
 }
 
@@ -130,11 +167,22 @@ func (c *FileConfig) Apply(options ...FileOption) {
 // ConfigureFile applies configuration options from c to config.
 func (c *FileConfig) ConfigureFile(config *FileConfig) {
 	*config = FileConfig{
-		SkipPageIndex:    c.SkipPageIndex,
-		SkipBloomFilters: c.SkipBloomFilters,
-		ReadBufferSize:   coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
-		ReadMode:         ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
-		Schema:           coalesceSchema(c.Schema, config.Schema),
+		SkipPageIndex:        c.SkipPageIndex,
+		SkipBloomFilters:     c.SkipBloomFilters,
+		ReadBufferSize:       coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
+		ReadMode:             ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
+		Schema:               coalesceSchema(c.Schema, config.Schema),
+		LazyDictionarySize:   coalesceInt(c.LazyDictionarySize, config.LazyDictionarySize),
+		CollectReadStats:     c.CollectReadStats,
+		LazyColumnMetadata:   c.LazyColumnMetadata,
+		FindLastFooter:       c.FindLastFooter,
+		PageTransforms:       coalescePageTransforms(c.PageTransforms, config.PageTransforms),
+		ColumnAccess:         coalesceColumnAccess(c.ColumnAccess, config.ColumnAccess),
+		AccessContext:        coalesceContext(c.AccessContext, config.AccessContext),
+		PageBufferPool:       coalescePageBufferPool(c.PageBufferPool, config.PageBufferPool),
+		DetectCompression:    coalesceCompressionMismatch(c.DetectCompression, config.DetectCompression),
+		ReconcileLevelCounts: coalesceLevelCountMismatch(c.ReconcileLevelCounts, config.ReconcileLevelCounts),
+		ValidateUTF8:         UTF8Validation(coalesceInt(int(c.ValidateUTF8), int(config.ValidateUTF8))),
 	}
 }
 
@@ -152,7 +200,18 @@ func (c *FileConfig) Validate() error {
 //		// ...
 //	})
 type ReaderConfig struct {
-	Schema *Schema
+	Schema             *Schema
+	TimestampLocation  *time.Location
+	StrictSchema       bool
+	StrictSchemaIgnore []string
+	MatchColumnNames   func(string) string
+	ColumnAliases      map[string]string
+	ColumnMasks        map[string]ColumnMask
+	DisallowNarrowing  bool
+	MatchFieldIDs      bool
+	ReadDeleted        bool
+	Int96Timestamps    bool
+	Filter             []Predicate
 }
 
 // DefaultReaderConfig returns a new ReaderConfig value initialized with the
@@ -181,8 +240,39 @@ func (c *ReaderConfig) Apply(options ...ReaderOption) {
 
 // ConfigureReader applies configuration options from c to config.
 func (c *ReaderConfig) ConfigureReader(config *ReaderConfig) {
+	columnAliases := config.ColumnAliases
+	if len(c.ColumnAliases) > 0 {
+		if columnAliases == nil {
+			columnAliases = make(map[string]string, len(c.ColumnAliases))
+		}
+		for k, v := range c.ColumnAliases {
+			columnAliases[k] = v
+		}
+	}
+
+	columnMasks := config.ColumnMasks
+	if len(c.ColumnMasks) > 0 {
+		if columnMasks == nil {
+			columnMasks = make(map[string]ColumnMask, len(c.ColumnMasks))
+		}
+		for k, v := range c.ColumnMasks {
+			columnMasks[k] = v
+		}
+	}
+
 	*config = ReaderConfig{
-		Schema: coalesceSchema(c.Schema, config.Schema),
+		Schema:             coalesceSchema(c.Schema, config.Schema),
+		TimestampLocation:  coalesceLocation(c.TimestampLocation, config.TimestampLocation),
+		StrictSchema:       c.StrictSchema || config.StrictSchema,
+		StrictSchemaIgnore: append(config.StrictSchemaIgnore[:len(config.StrictSchemaIgnore):len(config.StrictSchemaIgnore)], c.StrictSchemaIgnore...),
+		MatchColumnNames:   coalesceNameFunc(c.MatchColumnNames, config.MatchColumnNames),
+		ColumnAliases:      columnAliases,
+		ColumnMasks:        columnMasks,
+		DisallowNarrowing:  c.DisallowNarrowing || config.DisallowNarrowing,
+		MatchFieldIDs:      c.MatchFieldIDs || config.MatchFieldIDs,
+		ReadDeleted:        c.ReadDeleted || config.ReadDeleted,
+		Int96Timestamps:    c.Int96Timestamps || config.Int96Timestamps,
+		Filter:             append(config.Filter[:len(config.Filter):len(config.Filter)], c.Filter...),
 	}
 }
 
@@ -200,19 +290,32 @@ func (c *ReaderConfig) Validate() error {
 //		CreatedBy: "my test program",
 //	})
 type WriterConfig struct {
-	CreatedBy            string
-	ColumnPageBuffers    BufferPool
-	ColumnIndexSizeLimit int
-	PageBufferSize       int
-	WriteBufferSize      int
-	DataPageVersion      int
-	DataPageStatistics   bool
-	MaxRowsPerRowGroup   int64
-	KeyValueMetadata     map[string]string
-	Schema               *Schema
-	BloomFilters         []BloomFilterColumn
-	Compression          compress.Codec
-	Sorting              SortingConfig
+	CreatedBy                string
+	ColumnPageBuffers        BufferPool
+	ColumnIndexSizeLimit     int
+	PageBufferSize           int
+	WriteBufferSize          int
+	FlushConcurrency         int
+	DataPageVersion          int
+	DataPageStatistics       bool
+	StrictTimestamps         bool
+	MaxByteArrayLength       int
+	TruncateByteArrays       bool
+	NonFiniteFloats          NonFinitePolicy
+	MaxRowsPerRowGroup       int64
+	RowGroupAlignment        int64
+	MaxRowGroupPadding       int64
+	KeyValueMetadata         map[string]string
+	Schema                   *Schema
+	BloomFilters             []BloomFilterColumn
+	PageTransforms           []PageTransform
+	Compression              compress.Codec
+	CompressionLevels        map[string]int
+	CompressionDictionaries  map[string][]byte
+	Dictionaries             map[string][]Value
+	Sorting                  SortingConfig
+	StatisticsTruncateLength int
+	OmitStatistics           map[string]bool
 }
 
 // DefaultWriterConfig returns a new WriterConfig value initialized with the
@@ -224,8 +327,10 @@ func DefaultWriterConfig() *WriterConfig {
 		ColumnIndexSizeLimit: DefaultColumnIndexSizeLimit,
 		PageBufferSize:       DefaultPageBufferSize,
 		WriteBufferSize:      DefaultWriteBufferSize,
+		FlushConcurrency:     DefaultFlushConcurrency,
 		DataPageVersion:      DefaultDataPageVersion,
 		DataPageStatistics:   DefaultDataPageStatistics,
+		StrictTimestamps:     DefaultStrictTimestamps,
 		MaxRowsPerRowGroup:   DefaultMaxRowsPerRowGroup,
 		Sorting: SortingConfig{
 			SortingBuffers: &defaultSortingBufferPool,
@@ -263,20 +368,73 @@ func (c *WriterConfig) ConfigureWriter(config *WriterConfig) {
 		}
 	}
 
+	compressionLevels := config.CompressionLevels
+	if len(c.CompressionLevels) > 0 {
+		if compressionLevels == nil {
+			compressionLevels = make(map[string]int, len(c.CompressionLevels))
+		}
+		for k, v := range c.CompressionLevels {
+			compressionLevels[k] = v
+		}
+	}
+
+	compressionDictionaries := config.CompressionDictionaries
+	if len(c.CompressionDictionaries) > 0 {
+		if compressionDictionaries == nil {
+			compressionDictionaries = make(map[string][]byte, len(c.CompressionDictionaries))
+		}
+		for k, v := range c.CompressionDictionaries {
+			compressionDictionaries[k] = v
+		}
+	}
+
+	dictionaries := config.Dictionaries
+	if len(c.Dictionaries) > 0 {
+		if dictionaries == nil {
+			dictionaries = make(map[string][]Value, len(c.Dictionaries))
+		}
+		for k, v := range c.Dictionaries {
+			dictionaries[k] = v
+		}
+	}
+
+	omitStatistics := config.OmitStatistics
+	if len(c.OmitStatistics) > 0 {
+		if omitStatistics == nil {
+			omitStatistics = make(map[string]bool, len(c.OmitStatistics))
+		}
+		for k, v := range c.OmitStatistics {
+			omitStatistics[k] = v
+		}
+	}
+
 	*config = WriterConfig{
-		CreatedBy:            coalesceString(c.CreatedBy, config.CreatedBy),
-		ColumnPageBuffers:    coalesceBufferPool(c.ColumnPageBuffers, config.ColumnPageBuffers),
-		ColumnIndexSizeLimit: coalesceInt(c.ColumnIndexSizeLimit, config.ColumnIndexSizeLimit),
-		PageBufferSize:       coalesceInt(c.PageBufferSize, config.PageBufferSize),
-		WriteBufferSize:      coalesceInt(c.WriteBufferSize, config.WriteBufferSize),
-		DataPageVersion:      coalesceInt(c.DataPageVersion, config.DataPageVersion),
-		DataPageStatistics:   config.DataPageStatistics,
-		MaxRowsPerRowGroup:   config.MaxRowsPerRowGroup,
-		KeyValueMetadata:     keyValueMetadata,
-		Schema:               coalesceSchema(c.Schema, config.Schema),
-		BloomFilters:         coalesceBloomFilters(c.BloomFilters, config.BloomFilters),
-		Compression:          coalesceCompression(c.Compression, config.Compression),
-		Sorting:              coalesceSortingConfig(c.Sorting, config.Sorting),
+		CreatedBy:                coalesceString(c.CreatedBy, config.CreatedBy),
+		ColumnPageBuffers:        coalesceBufferPool(c.ColumnPageBuffers, config.ColumnPageBuffers),
+		ColumnIndexSizeLimit:     coalesceInt(c.ColumnIndexSizeLimit, config.ColumnIndexSizeLimit),
+		PageBufferSize:           coalesceInt(c.PageBufferSize, config.PageBufferSize),
+		WriteBufferSize:          coalesceInt(c.WriteBufferSize, config.WriteBufferSize),
+		FlushConcurrency:         coalesceInt(c.FlushConcurrency, config.FlushConcurrency),
+		DataPageVersion:          coalesceInt(c.DataPageVersion, config.DataPageVersion),
+		DataPageStatistics:       config.DataPageStatistics,
+		StrictTimestamps:         config.StrictTimestamps,
+		MaxByteArrayLength:       coalesceInt(c.MaxByteArrayLength, config.MaxByteArrayLength),
+		TruncateByteArrays:       config.TruncateByteArrays,
+		NonFiniteFloats:          coalesceNonFinitePolicy(c.NonFiniteFloats, config.NonFiniteFloats),
+		MaxRowsPerRowGroup:       coalesceInt64(c.MaxRowsPerRowGroup, config.MaxRowsPerRowGroup),
+		RowGroupAlignment:        coalesceInt64(c.RowGroupAlignment, config.RowGroupAlignment),
+		MaxRowGroupPadding:       coalesceInt64(c.MaxRowGroupPadding, config.MaxRowGroupPadding),
+		KeyValueMetadata:         keyValueMetadata,
+		Schema:                   coalesceSchema(c.Schema, config.Schema),
+		BloomFilters:             coalesceBloomFilters(c.BloomFilters, config.BloomFilters),
+		PageTransforms:           coalescePageTransforms(c.PageTransforms, config.PageTransforms),
+		Compression:              coalesceCompression(c.Compression, config.Compression),
+		CompressionLevels:        compressionLevels,
+		CompressionDictionaries:  compressionDictionaries,
+		Dictionaries:             dictionaries,
+		Sorting:                  coalesceSortingConfig(c.Sorting, config.Sorting),
+		StatisticsTruncateLength: coalesceInt(c.StatisticsTruncateLength, config.StatisticsTruncateLength),
+		OmitStatistics:           omitStatistics,
 	}
 }
 
@@ -287,7 +445,10 @@ func (c *WriterConfig) Validate() error {
 		validateNotNil(baseName+"ColumnPageBuffers", c.ColumnPageBuffers),
 		validatePositiveInt(baseName+"ColumnIndexSizeLimit", c.ColumnIndexSizeLimit),
 		validatePositiveInt(baseName+"PageBufferSize", c.PageBufferSize),
+		validatePositiveInt(baseName+"FlushConcurrency", c.FlushConcurrency),
 		validateOneOfInt(baseName+"DataPageVersion", c.DataPageVersion, 1, 2),
+		validateRowGroupAlignment(baseName+"MaxRowGroupPadding", c.RowGroupAlignment, c.MaxRowGroupPadding),
+		validateDictionaries(baseName+"Dictionaries", c.Schema, c.Dictionaries),
 		c.Sorting.Validate(),
 	)
 }
@@ -366,6 +527,7 @@ type SortingConfig struct {
 	SortingBuffers     BufferPool
 	SortingColumns     []SortingColumn
 	DropDuplicatedRows bool
+	EnforceSortOrder   bool
 }
 
 // DefaultSortingConfig returns a new SortingConfig value initialized with the
@@ -471,6 +633,11 @@ func FileReadMode(mode ReadMode) FileOption {
 // storage it can be advantageous to increase this value to something more like
 // 4 MiB.
 //
+// The buffers are used to decode the thrift encoded headers of pages and bloom
+// filters, and to read the content of pages, so the option controls the size
+// of most reads, or requests, made to the io.Reader. Page content larger than
+// the buffer is read directly from the io.Reader, bypassing the buffer.
+//
 // Defaults to 4096.
 func ReadBufferSize(size int) FileOption {
 	return fileOption(func(config *FileConfig) { config.ReadBufferSize = size })
@@ -485,6 +652,410 @@ func FileSchema(schema *Schema) FileOption {
 	return fileOption(func(config *FileConfig) { config.Schema = schema })
 }
 
+// LazyDictionarySize is a file configuration option which sets the size in
+// bytes above which dictionary pages of BYTE_ARRAY columns are decoded lazily.
+//
+// Lazy dictionaries retain the uncompressed dictionary page and locate values
+// when they are looked up, instead of decoding all values when the dictionary
+// is loaded. This avoids the decoding cost and the per-value offsets of large
+// dictionaries of strings, at the expense of slower lookups. A value of zero
+// disables lazy decoding.
+//
+// Defaults to 0.
+func LazyDictionarySize(size int) FileOption {
+	return fileOption(func(config *FileConfig) { config.LazyDictionarySize = size })
+}
+
+// CollectReadStats is a file configuration option which enables measuring the
+// time spent in each stage of reading pages (I/O, page header parsing,
+// decompression, level and value decoding), when set to true. The statistics
+// are returned by the Stats method of the file. Statistics of the dictionary
+// encoding of columns are also collected, and are returned by the
+// DictionaryStats method of the file.
+//
+// Collecting statistics adds the cost of reading the clock at each stage,
+// which may be noticeable when reading small pages.
+//
+// Defaults to false.
+func CollectReadStats(enabled bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.CollectReadStats = enabled })
+}
+
+// LazyColumnMetadata is a file configuration option which delays decoding the
+// metadata of column chunks from the file footer until the column chunks are
+// used, when set to true. The page index and bloom filter of each column chunk
+// are also read when the column chunk is first used instead of when opening
+// the file.
+//
+// This reduces the time and memory needed to open files with a very large
+// number of columns when programs only read a few of them. Calling the
+// Metadata or ReadPageIndex methods of the file decodes the metadata of all
+// column chunks.
+//
+// Defaults to false.
+func LazyColumnMetadata(enabled bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.LazyColumnMetadata = enabled })
+}
+
+// FindLastFooter is a file configuration option which makes opening files
+// tolerate bytes written after the footer, when set to true. If the file does
+// not end with a valid footer, it is searched backward for the last footer that
+// can be decoded, and the file is opened as if it ended there.
+//
+// This is useful to recover data from files with trailing garbage, for example
+// concatenation artifacts, or from append-style files where writing a new
+// footer was interrupted. The Size method of the file then returns the offset
+// of the end of the footer that was found. Searching for a footer may read the
+// whole file.
+//
+// Defaults to false.
+func FindLastFooter(enabled bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.FindLastFooter = enabled })
+}
+
+// CompressionMismatch describes a page which was compressed with a different
+// codec than the one recorded in the metadata of its column chunk, see
+// DetectCompression.
+type CompressionMismatch struct {
+	// Path of the column that the page belongs to.
+	Path []string
+	// Codec recorded in the metadata of the column chunk.
+	Declared format.CompressionCodec
+	// Codec that the page was decompressed with.
+	Detected format.CompressionCodec
+}
+
+// DetectCompression is a file configuration option which enables the recovery
+// of pages written by broken writers that mislabel the compression codec of
+// column chunks, for example recording SNAPPY for pages compressed with GZIP.
+//
+// When decompressing a page with the codec of its column chunk fails, the
+// codec is detected by inspecting the page data: GZIP and ZSTD pages are
+// recognized by their magic bytes, SNAPPY pages by the decoded length in
+// their preamble, and uncompressed pages by their length. Decompression is
+// then retried with the detected codec, and the warn function is called to
+// report the mismatch. Functions may be called concurrently.
+//
+// Detection is only attempted for column chunks which declare a compression
+// codec; pages of uncompressed column chunks are never decompressed.
+//
+// Defaults to nil, which disables the detection.
+func DetectCompression(warn func(CompressionMismatch)) FileOption {
+	if warn == nil {
+		warn = func(CompressionMismatch) {}
+	}
+	return fileOption(func(config *FileConfig) { config.DetectCompression = warn })
+}
+
+// LevelCountMismatch describes a data page which has a different number of
+// repetition or definition levels than the number of values recorded in its
+// header, see ReconcileLevelCounts.
+type LevelCountMismatch struct {
+	// Path of the column that the page belongs to.
+	Path []string
+	// Number of values recorded in the page header.
+	NumValues int
+	// Number of levels decoded from the page, which the page was read with.
+	NumLevels int
+}
+
+// ReconcileLevelCounts is a file configuration option which makes reading
+// pages tolerate headers recording more values than the page has repetition
+// or definition levels, which some writers produce. Instead of failing, pages
+// are read with the number of levels that were decoded, and the warn function
+// is called to report the mismatch. Functions may be called concurrently.
+//
+// Defaults to nil, which makes reading such pages fail.
+func ReconcileLevelCounts(warn func(LevelCountMismatch)) FileOption {
+	if warn == nil {
+		warn = func(LevelCountMismatch) {}
+	}
+	return fileOption(func(config *FileConfig) { config.ReconcileLevelCounts = warn })
+}
+
+// ValidateUTF8 is a file configuration option which controls the validation of
+// the values of STRING columns, which user-generated data frequently contains
+// invalid UTF-8 sequences in, breaking programs which expect valid strings such
+// as JSON encoders.
+//
+// With UTF8Reject, reading pages which contain invalid values fails with an
+// error wrapping ErrInvalidUTF8. With UTF8Replace, each run of invalid bytes is
+// replaced by the U+FFFD replacement character, like bytes.ToValidUTF8 does.
+// Values of dictionary-encoded pages are validated in the dictionary page of
+// their column chunk.
+//
+// Defaults to UTF8PassThrough.
+func ValidateUTF8(mode UTF8Validation) FileOption {
+	return fileOption(func(config *FileConfig) { config.ValidateUTF8 = mode })
+}
+
+// ColumnAccessFunc is the type of hooks invoked when opening columns of files,
+// see ColumnAccess.
+//
+// The function receives the context passed to ColumnAccess and the path of the
+// column being opened, and returns a non-nil error to deny access to the
+// column. Functions may be called concurrently and more than once per column.
+type ColumnAccessFunc func(ctx context.Context, path []string) error
+
+// ColumnAccess is a file configuration option which installs a hook invoked
+// with the given context and the path of columns when their pages, column index,
+// or bloom filter are opened. When the hook returns a non-nil error, access to
+// the column is denied: reading its pages fails with the error, wrapped with
+// the path of the column, and the column index and bloom filter are not exposed,
+// neither by the column chunks nor by the ReadPageIndex method of the file.
+//
+// The context carries caller-supplied information such as the identity of the
+// tenant that the file is opened on behalf of, for example:
+//
+//	f, err := parquet.OpenFile(input, size,
+//		parquet.ColumnAccess(ctx, func(ctx context.Context, path []string) error {
+//			if !tenantOf(ctx).CanRead(path) {
+//				return parquet.ErrAccessDenied
+//			}
+//			return nil
+//		}),
+//	)
+//
+// The hook does not restrict access to the metadata of the file, which includes
+// the statistics of column chunks.
+//
+// Defaults to nil, which grants access to all columns.
+func ColumnAccess(ctx context.Context, check ColumnAccessFunc) FileOption {
+	return fileOption(func(config *FileConfig) {
+		config.ColumnAccess = check
+		config.AccessContext = ctx
+	})
+}
+
+// TimestampLocation configures the location that readers use to interpret
+// TIMESTAMP columns with local semantics (isAdjustedToUTC=false), which record
+// a wall clock time rather than an instant.
+//
+// When set, time.Time values read from those columns hold the wall clock time
+// in the given location. Otherwise, the wall clock time is interpreted as UTC.
+// Columns with UTC semantics are not affected by this option.
+//
+// Defaults to nil.
+func TimestampLocation(loc *time.Location) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.TimestampLocation = loc })
+}
+
+// Int96Timestamps configures readers to interpret INT96 columns as the legacy
+// timestamps written by Impala, Hive, and older versions of Spark, which hold
+// the Julian day and the number of nanoseconds since midnight.
+//
+// When enabled, readers that do not have an explicit schema expose the INT96
+// columns of files as TIMESTAMP(NANOS) columns, so their values are read as
+// time.Time values or int64 numbers of nanoseconds since the Unix epoch.
+// Readers with an explicit schema, for example generated from a Go type,
+// always convert INT96 values to the TIMESTAMP columns of their schema.
+//
+// Defaults to false, which reads INT96 values as deprecated.Int96 values.
+func Int96Timestamps(enabled bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.Int96Timestamps = enabled })
+}
+
+// Filter is a reader configuration option which skips the row groups of files
+// where the statistics of column chunks show that no rows can satisfy all the
+// predicates, for example:
+//
+//	reader := parquet.NewGenericReader[RowType](file,
+//		parquet.Filter(
+//			parquet.Ge("ts", since),
+//			parquet.Eq("tenant", tenantID),
+//		),
+//	)
+//
+// Row groups where the bloom filters of column chunks show that no values are
+// equal to those of Eq predicates are skipped as well.
+//
+// The pages of skipped row groups are never read nor decompressed, and the
+// rows of skipped row groups are not included in the number of rows of the
+// reader or in row indexes passed to SeekToRow.
+//
+// When files have a page index, pages of the remaining row groups where the
+// column indexes show that no rows can satisfy the predicates are skipped as
+// well, by seeking directly to the matching pages with the offset indexes.
+// The rows of skipped pages are still included in the number of rows of the
+// reader and in row indexes passed to SeekToRow.
+//
+// Rows of pages which may satisfy the predicates are all returned, including
+// those which do not satisfy them; programs which need exact results should
+// check the rows they read, or use File.Query.
+//
+// The option only applies to readers of files; the reader constructors panic
+// if the predicates are invalid for the file schema, see File.FilterRowGroups.
+//
+// This option is additive, it may be used multiple times to add predicates.
+//
+// Defaults to no predicates, which reads all the row groups.
+func Filter(predicates ...Predicate) ReaderOption {
+	return readerOption(func(config *ReaderConfig) {
+		config.Filter = append(config.Filter, predicates...)
+	})
+}
+
+// StrictSchema is a reader configuration option which requires the schema
+// that rows are read into to match the schema of the file: every leaf column
+// of the file must exist in the read schema, and every leaf column of the read
+// schema must exist in the file. Readers constructed with this option panic
+// with an error wrapping ErrSchemaMismatch when the schemas differ, instead of
+// silently dropping the columns that are missing from the read schema, or
+// reading zero values for the columns missing from the file. The Read method
+// of Reader returns such an error when the type of the Go value passed to it
+// does not match the schema of the file.
+//
+// The paths passed as arguments are dot-separated column paths which are
+// allowed to differ between the schemas. A path naming a group applies to all
+// the columns nested in the group.
+//
+// This option is additive, it may be used multiple times to add more columns
+// to the list of ignored paths.
+//
+// Defaults to false.
+func StrictSchema(ignore ...string) ReaderOption {
+	return readerOption(func(config *ReaderConfig) {
+		config.StrictSchema = true
+		config.StrictSchemaIgnore = append(config.StrictSchemaIgnore, ignore...)
+	})
+}
+
+// DisallowNarrowing is a reader configuration option which prevents reading
+// columns into fields of types that cannot represent all the values of the
+// columns, when set to true.
+//
+// Readers convert the values of columns to the types of the schema that rows
+// are read into, for example when reading a column of INT32 values into an
+// int64 field. Widening conversions (INT32 to INT64 or DOUBLE, FLOAT to DOUBLE)
+// never lose information, but narrowing conversions (e.g. INT64 to INT32, or
+// DOUBLE to FLOAT) may silently truncate values. Readers constructed with this
+// option panic with an error wrapping ErrSchemaMismatch when a column would be
+// narrowed.
+//
+// Defaults to false.
+func DisallowNarrowing(enabled bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.DisallowNarrowing = enabled })
+}
+
+// ReadDeleted is a reader configuration option which makes readers return the
+// rows marked as deleted in the DeletedColumn of files, which they skip by
+// default.
+//
+// Skipping deleted rows only applies to the rows read, the number of rows and
+// row indexes passed to SeekToRow still include the deleted rows.
+//
+// Defaults to false.
+func ReadDeleted(enabled bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.ReadDeleted = enabled })
+}
+
+// MatchColumnNames is a reader configuration option which allows the columns
+// of the schema that rows are read into to match columns of the file that have
+// different names. Two names match when the normalize function returns the
+// same value for both, columns with the exact same names always match.
+//
+// The package provides the FoldCase and NormalizeName functions for the common
+// cases of names differing by case, or by naming convention (e.g. snake_case
+// and camelCase), for example:
+//
+//	reader := parquet.NewGenericReader[RowType](file,
+//		parquet.MatchColumnNames(parquet.NormalizeName),
+//	)
+//
+// Defaults to nil, which requires column names to be equal.
+func MatchColumnNames(normalize func(name string) string) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.MatchColumnNames = normalize })
+}
+
+// MatchFieldIDs is a reader configuration option which matches the fields of
+// the schema that rows are read into with columns of the file by field id
+// rather than by name, as done by table formats like Iceberg which resolve
+// columns by id to support renaming them.
+//
+// Fields with a field id (set with the "id(n)" struct tag or FieldID) are read
+// from the column of the file with the same id, whatever its name; only those
+// columns are read from the file. When the file has field ids but none match,
+// the field is read as a missing column rather than being matched by name.
+// Fields without a field id, and fields of files written without field ids,
+// are matched by name. For example:
+//
+//	type RowType struct {
+//		UserID int64  `parquet:"user_id,id(1)"`
+//		Email  string `parquet:"email,id(4)"`
+//	}
+//
+//	reader := parquet.NewGenericReader[RowType](file,
+//		parquet.MatchFieldIDs(true),
+//	)
+//
+// Defaults to false.
+func MatchFieldIDs(enabled bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.MatchFieldIDs = enabled })
+}
+
+// ColumnAliases is a reader configuration option which maps columns of the
+// file to fields with different names in the schema that rows are read into,
+// allowing columns renamed across versions of a file to be read into the same
+// fields.
+//
+// The keys of the map are dot-separated paths of columns in the file, and the
+// values are the names of the fields that the columns are read into. The field
+// must be nested in the group matching the parent of the column, for example:
+//
+//	reader := parquet.NewGenericReader[RowType](file,
+//		parquet.ColumnAliases(map[string]string{
+//			"user_id":     "UserID",
+//			"address.zip": "ZipCode",
+//		}),
+//	)
+//
+// This option is additive, it may be used multiple times to add more aliases.
+//
+// Defaults to no aliases.
+func ColumnAliases(aliases map[string]string) ReaderOption {
+	return readerOption(func(config *ReaderConfig) {
+		if config.ColumnAliases == nil {
+			config.ColumnAliases = make(map[string]string, len(aliases))
+		}
+		for path, name := range aliases {
+			config.ColumnAliases[path] = name
+		}
+	})
+}
+
+// MaskColumns is a reader configuration option which masks the values of
+// columns when they are read, allowing programs to be given views of files
+// where sensitive columns are redacted or hashed without rewriting the files.
+//
+// The keys of the map are dot-separated paths of columns in the schema that
+// rows are read into, and the values are the masks applied to the non-null
+// values of the columns. The package provides the RedactValue and HashValue
+// masks for the common cases, for example:
+//
+//	reader := parquet.NewGenericReader[RowType](file,
+//		parquet.MaskColumns(map[string]parquet.ColumnMask{
+//			"email": parquet.HashValue,
+//			"ssn":   parquet.RedactValue,
+//		}),
+//	)
+//
+// Masks apply to the rows returned by the Read and ReadRows methods of
+// readers, but not to the pages or statistics of the underlying file.
+//
+// This option is additive, it may be used multiple times to mask more columns.
+//
+// Defaults to no masks.
+func MaskColumns(masks map[string]ColumnMask) ReaderOption {
+	return readerOption(func(config *ReaderConfig) {
+		if config.ColumnMasks == nil {
+			config.ColumnMasks = make(map[string]ColumnMask, len(masks))
+		}
+		for path, mask := range masks {
+			config.ColumnMasks[path] = mask
+		}
+	})
+}
+
 // PageBufferSize configures the size of column page buffers on parquet writers.
 //
 // Note that the page buffer size refers to the in-memory buffers where pages
@@ -508,6 +1079,28 @@ func WriteBufferSize(size int) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.WriteBufferSize = size })
 }
 
+// FlushConcurrency configures the number of goroutines that writers use to
+// encode and compress the column chunks of row groups when they are flushed,
+// which speeds up writing files with many columns on multicore machines.
+//
+// The column chunks are still written to the output in the order of the
+// columns, the files produced do not depend on the concurrency. Each goroutine
+// uses its own scratch space to encode and compress pages, so the memory used
+// by writers grows with the concurrency. Pages written before the row group is
+// flushed, when the buffered values of a column exceed the page buffer size,
+// are encoded sequentially.
+//
+// Passing zero or a negative value sets the concurrency to the value of
+// runtime.GOMAXPROCS(0).
+//
+// Defaults to 1, which flushes column chunks sequentially.
+func FlushConcurrency(concurrency int) WriterOption {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	return writerOption(func(config *WriterConfig) { config.FlushConcurrency = concurrency })
+}
+
 // MaxRowsPerRowGroup configures the maximum number of rows that a writer will
 // produce in each row group.
 //
@@ -524,6 +1117,25 @@ func MaxRowsPerRowGroup(numRows int64) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.MaxRowsPerRowGroup = numRows })
 }
 
+// AlignRowGroups configures writers to align row groups on the blocks of the
+// underlying storage, for example HDFS or object stores, so that engines which
+// split files on block boundaries do not read row groups that span two blocks.
+//
+// When a row group is about to be written and the space remaining in the
+// current block is smaller than or equal to maxPadding, the file is padded
+// with zero bytes so that the row group starts at the beginning of the next
+// block. This matches the padding behavior of parquet-mr. Programs should also
+// limit the size of row groups to the block size, for example with
+// MaxRowsPerRowGroup, for row groups to fit in one block.
+//
+// Defaults to no alignment.
+func AlignRowGroups(blockSize, maxPadding int64) WriterOption {
+	return writerOption(func(config *WriterConfig) {
+		config.RowGroupAlignment = blockSize
+		config.MaxRowGroupPadding = maxPadding
+	})
+}
+
 // CreatedBy creates a configuration option which sets the name of the
 // application that created a parquet file.
 //
@@ -575,6 +1187,24 @@ func DataPageStatistics(enabled bool) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.DataPageStatistics = enabled })
 }
 
+// StrictTimestamps creates a configuration option which defines whether writing
+// time.Time values to TIMESTAMP columns with a coarser unit (e.g. MILLIS or
+// MICROS) returns an error instead of truncating the values.
+//
+// When enabled, writes of Go values holding times with sub-unit precision fail
+// with ErrTimestampTruncated. Programs that need to preserve the full precision
+// of time.Time values should use TIMESTAMP(NANOS) columns instead, for example
+// with the `parquet:",timestamp(nanosecond)"` struct tag, provided that the
+// applications consuming the files support nanosecond timestamps.
+//
+// The option applies to values written with the Write methods of Writer and
+// GenericWriter, rows passed to WriteRows are not checked.
+//
+// Defaults to false.
+func StrictTimestamps(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.StrictTimestamps = enabled })
+}
+
 // KeyValueMetadata creates a configuration option which adds key/value metadata
 // to add to the metadata of parquet files.
 //
@@ -616,6 +1246,205 @@ func Compression(codec compress.Codec) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.Compression = codec })
 }
 
+// MaxByteArrayLength creates a configuration option which limits the length in
+// bytes of values written to BYTE_ARRAY columns, protecting downstream systems
+// which cannot load values larger than a given size.
+//
+// When truncate is false, writing a row with a value exceeding the limit fails
+// with an error wrapping ErrByteArrayTooLong, and the row is not written. The
+// check requires GenericWriter to deconstruct rows, which slows down writes.
+//
+// When truncate is true, values exceeding the limit are truncated to the limit.
+// Values of UTF8 columns are truncated on a character boundary. The number of
+// truncated values of each column is recorded in the key/value metadata of the
+// file, under a key made of the "parquet-go.truncated_values." prefix followed
+// by the path of the column, for example "parquet-go.truncated_values.a.b".
+//
+// Defaults to 0, which does not limit the length of values.
+func MaxByteArrayLength(length int, truncate bool) WriterOption {
+	return writerOption(func(config *WriterConfig) {
+		config.MaxByteArrayLength = length
+		config.TruncateByteArrays = truncate
+	})
+}
+
+// NonFinitePolicy represents the policy applied by writers to NaN and infinite
+// values written to FLOAT and DOUBLE columns.
+type NonFinitePolicy int
+
+const (
+	// AllowNonFinite writes NaN and infinite values to the columns.
+	AllowNonFinite NonFinitePolicy = iota
+	// NullNonFinite replaces NaN and infinite values with nulls in optional
+	// columns; the values are rejected when written to other columns.
+	NullNonFinite
+	// RejectNonFinite fails writes of rows containing NaN or infinite values.
+	RejectNonFinite
+)
+
+// NonFiniteFloats creates a configuration option which sets the policy applied
+// to NaN and infinite values written to FLOAT and DOUBLE columns, since some
+// downstream systems cannot process those values.
+//
+// When values are rejected, writing a row containing a non-finite value fails
+// with an error wrapping ErrNonFiniteFloat, and the row is not written. Both
+// rejecting values and replacing them with nulls require GenericWriter to
+// deconstruct rows, which slows down writes.
+//
+// Defaults to AllowNonFinite.
+func NonFiniteFloats(policy NonFinitePolicy) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.NonFiniteFloats = policy })
+}
+
+// CompressionLevel creates a configuration option which sets the compression
+// level used by a writer for all columns compressed with a codec supporting
+// levels (ZSTD, GZIP, and BROTLI), unless a level was set for the column with
+// ColumnCompressionLevel.
+//
+// The level is expressed on the scale of each compression algorithm: 1 to 22
+// for ZSTD (mapped to the closest level supported by the encoder), -2 to 9 for
+// GZIP, and 0 to 11 for BROTLI.
+//
+// Defaults to the level configured on the codecs.
+func CompressionLevel(level int) WriterOption {
+	return ColumnCompressionLevel(level)
+}
+
+// ColumnCompressionLevel creates a configuration option which sets the
+// compression level used by a writer for the column at the given path, or
+// columns nested under it. The level applies to the compression codec selected
+// for the column, whether it was set in the schema or with the Compression
+// option; see CompressionLevel for the scale of levels.
+//
+// This option is additive, it may be used multiple times to configure levels
+// of multiple columns. When levels are set for more than one prefix of the
+// path of a column, the level of the longest prefix is used.
+func ColumnCompressionLevel(level int, path ...string) WriterOption {
+	key := columnPath(path).String()
+	return writerOption(func(config *WriterConfig) {
+		if config.CompressionLevels == nil {
+			config.CompressionLevels = map[string]int{key: level}
+		} else {
+			config.CompressionLevels[key] = level
+		}
+	})
+}
+
+// StatisticsTruncateLength creates a configuration option which limits the
+// length of the min and max values of BYTE_ARRAY columns recorded in the
+// statistics of column chunks and data pages.
+//
+// Min values are truncated to a prefix of the original value, and max values
+// are truncated then incremented, so the statistics still bound the values of
+// the column and readers pruning on them never skip matching rows. Values of
+// UTF8 columns are truncated on a character boundary. When a max value cannot
+// be truncated (e.g. all its bytes are 0xFF), it is recorded in full. Writers
+// configured with this option record whether the values were truncated in the
+// IsMinValueExact and IsMaxValueExact fields of the statistics.
+//
+// The option does not apply to the column index, see ColumnIndexSizeLimit.
+//
+// Defaults to zero, which does not truncate the statistics.
+func StatisticsTruncateLength(length int) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.StatisticsTruncateLength = length })
+}
+
+// OmitStatistics creates a configuration option which disables the min and
+// max values of the column at the given path, or columns nested under it, for
+// example to avoid leaking sensitive values in the metadata of files:
+//
+//	writer := parquet.NewGenericWriter[RowType](output,
+//		parquet.OmitStatistics("email"),
+//	)
+//
+// The min and max values are omitted from the statistics of column chunks and
+// data pages, and the column index of the columns is not written, so readers
+// see the bounds of the columns as unknown and never prune on them. The null
+// counts are still recorded. Calling the function with an empty path omits the
+// min and max values of all columns.
+//
+// This option is additive, it may be used multiple times to omit statistics of
+// multiple columns.
+func OmitStatistics(path ...string) WriterOption {
+	key := columnPath(path).String()
+	return writerOption(func(config *WriterConfig) {
+		if config.OmitStatistics == nil {
+			config.OmitStatistics = map[string]bool{key: true}
+		} else {
+			config.OmitStatistics[key] = true
+		}
+	})
+}
+
+// ColumnDictionary creates a configuration option which seeds the dictionary of
+// the column at the given path with a list of values.
+//
+// The seeded values are inserted in the dictionary in the order they are given
+// at the beginning of each row group, which gives output files a stable
+// dictionary ordering and spares writers the work of building the dictionary
+// when the values of a column are known in advance, for example enumerations.
+// Values which are not part of the seed are added to the dictionary after the
+// seeded values.
+//
+// Columns with a seeded dictionary are dictionary encoded even if their schema
+// specifies a different encoding. The values must be of the physical type of
+// the column, validating the writer configuration fails otherwise.
+//
+// This option is additive, it may be used multiple times to seed dictionaries
+// of multiple columns.
+func ColumnDictionary(path []string, values ...Value) WriterOption {
+	key := columnPath(path).String()
+	seed := make([]Value, 0, len(values))
+	for _, v := range values {
+		if !v.IsNull() {
+			seed = append(seed, v.Clone())
+		}
+	}
+	return writerOption(func(config *WriterConfig) {
+		if config.Dictionaries == nil {
+			config.Dictionaries = map[string][]Value{key: seed}
+		} else {
+			config.Dictionaries[key] = seed
+		}
+	})
+}
+
+// ColumnCompressionDictionary creates a configuration option which sets the
+// raw zstd dictionary used by a writer to compress the pages of the column at
+// the given path. Dictionaries significantly improve the compression ratio of
+// columns made of many small pages of similar values, they can be trained from
+// a sample of the values with zstd.TrainDictionary, for example:
+//
+//	samples := make([][]byte, len(values))
+//	for i, v := range values {
+//		samples[i] = []byte(v)
+//	}
+//	dict := zstd.TrainDictionary(samples, zstd.DefaultDictionarySize)
+//	writer := parquet.NewWriter(output,
+//		parquet.ColumnCompressionDictionary(dict, "name"),
+//	)
+//
+// The column is compressed with ZSTD, at the level of the ZSTD codec selected
+// for the column if there is one. The dictionaries of columns compressed with
+// a zstd.Codec configured with a Dictionary are embedded in the key/value
+// metadata of the file, under a key made of the "parquet-go.zstd_dictionary."
+// prefix followed by the path of the column, and are used automatically when
+// reading the file with this package. Other readers need to be configured with
+// the dictionary to decompress the pages.
+//
+// This option is additive, it may be used multiple times to set dictionaries
+// of multiple columns.
+func ColumnCompressionDictionary(dictionary []byte, path ...string) WriterOption {
+	key := columnPath(path).String()
+	return writerOption(func(config *WriterConfig) {
+		if config.CompressionDictionaries == nil {
+			config.CompressionDictionaries = map[string][]byte{key: dictionary}
+		} else {
+			config.CompressionDictionaries[key] = dictionary
+		}
+	})
+}
+
 // SortingWriterConfig is a writer option which applies configuration specific
 // to sorting writers.
 func SortingWriterConfig(options ...SortingOption) WriterOption {
@@ -672,6 +1501,20 @@ func DropDuplicatedRows(drop bool) SortingOption {
 	return sortingOption(func(config *SortingConfig) { config.DropDuplicatedRows = drop })
 }
 
+// EnforceSortOrder configures whether writers verify that rows are written in
+// the order of their sorting columns, including the ordering of null values.
+// Writers return ErrRowsNotSorted when a row is ordered before the previous
+// row of the same row group.
+//
+// The sorting columns are recorded in the row group metadata of files, engines
+// may rely on them to merge rows of multiple files without sorting them again;
+// the option guarantees that the metadata is accurate.
+//
+// Defaults to false
+func EnforceSortOrder(enabled bool) SortingOption {
+	return sortingOption(func(config *SortingConfig) { config.EnforceSortOrder = enabled })
+}
+
 type fileOption func(*FileConfig)
 
 func (opt fileOption) ConfigureFile(config *FileConfig) { opt(config) }
@@ -727,6 +1570,27 @@ func coalesceBufferPool(p1, p2 BufferPool) BufferPool {
 	return p2
 }
 
+func coalesceLocation(l1, l2 *time.Location) *time.Location {
+	if l1 != nil {
+		return l1
+	}
+	return l2
+}
+
+func coalesceNameFunc(f1, f2 func(string) string) func(string) string {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
+func coalesceNonFinitePolicy(p1, p2 NonFinitePolicy) NonFinitePolicy {
+	if p1 != AllowNonFinite {
+		return p1
+	}
+	return p2
+}
+
 func coalesceSchema(s1, s2 *Schema) *Schema {
 	if s1 != nil {
 		return s1
@@ -746,6 +1610,7 @@ func coalesceSortingConfig(c1, c2 SortingConfig) SortingConfig {
 		SortingBuffers:     coalesceBufferPool(c1.SortingBuffers, c2.SortingBuffers),
 		SortingColumns:     coalesceSortingColumns(c1.SortingColumns, c2.SortingColumns),
 		DropDuplicatedRows: c1.DropDuplicatedRows,
+		EnforceSortOrder:   c1.EnforceSortOrder,
 	}
 }
 
@@ -756,6 +1621,48 @@ func coalesceBloomFilters(f1, f2 []BloomFilterColumn) []BloomFilterColumn {
 	return f2
 }
 
+func coalescePageTransforms(t1, t2 []PageTransform) []PageTransform {
+	if t1 != nil {
+		return t1
+	}
+	return t2
+}
+
+func coalesceColumnAccess(f1, f2 ColumnAccessFunc) ColumnAccessFunc {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
+func coalesceCompressionMismatch(f1, f2 func(CompressionMismatch)) func(CompressionMismatch) {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
+func coalesceLevelCountMismatch(f1, f2 func(LevelCountMismatch)) func(LevelCountMismatch) {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
+func coalescePageBufferPool(p1, p2 *PageBufferPool) *PageBufferPool {
+	if p1 != nil {
+		return p1
+	}
+	return p2
+}
+
+func coalesceContext(c1, c2 context.Context) context.Context {
+	if c1 != nil {
+		return c1
+	}
+	return c2
+}
+
 func coalesceCompression(c1, c2 compress.Codec) compress.Codec {
 	if c1 != nil {
 		return c1
@@ -777,6 +1684,13 @@ func validatePositiveInt64(optionName string, optionValue int64) error {
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateRowGroupAlignment(optionName string, blockSize, maxPadding int64) error {
+	if blockSize >= 0 && maxPadding >= 0 && (blockSize == 0 || maxPadding < blockSize) {
+		return nil
+	}
+	return errorInvalidOptionValue(optionName, maxPadding)
+}
+
 func validateOneOfInt(optionName string, optionValue int, supportedValues ...int) error {
 	for _, value := range supportedValues {
 		if value == optionValue {
@@ -786,6 +1700,22 @@ func validateOneOfInt(optionName string, optionValue int, supportedValues ...int
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateDictionaries(optionName string, schema *Schema, dictionaries map[string][]Value) error {
+	if schema == nil || len(dictionaries) == 0 {
+		return nil
+	}
+	var err error
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		kind := leaf.node.Type().Kind()
+		for _, v := range dictionaries[leaf.path.String()] {
+			if v.Kind() != kind && err == nil {
+				err = fmt.Errorf("invalid option value: %s: cannot seed dictionary of column %s of type %s with value of type %s", optionName, leaf.path, kind, v.Kind())
+			}
+		}
+	})
+	return err
+}
+
 func validateNotNil(optionName string, optionValue interface{}) error {
 	if optionValue != nil {
 		return nil
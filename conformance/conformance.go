@@ -0,0 +1,580 @@
+// Package conformance contains test suites that programs can run to validate
+// their implementations of the parquet extension points, such as compression
+// codecs and encodings, against the behavior expected by the parquet package.
+//
+// Implementations are registered with the package, then the suites are run
+// from a regular go test function:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.RegisterCodec(new(mycodec.Codec))
+//		conformance.RegisterEncoding(new(myencoding.Encoding))
+//		conformance.Conformance(t)
+//	}
+//
+// The suites always verify that the parquet package reads the reference files
+// embedded in the package, copied from the parquet-testing repository
+// (https://github.com/apache/parquet-testing), with their expected number of
+// rows and column values; see ReferenceFiles. More files, for example a full
+// checkout of the parquet-testing repository, can be validated by registering
+// the directory where they are located with RegisterFiles.
+package conformance
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"math/rand"
+	"path"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+var (
+	registryLock sync.Mutex
+	registry     Suite
+)
+
+// RegisterCodec adds a compression codec to the list of codecs validated by
+// calls to Conformance.
+func RegisterCodec(codec compress.Codec) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry.Codecs = append(registry.Codecs, codec)
+}
+
+// RegisterEncoding adds an encoding to the list of encodings validated by calls
+// to Conformance.
+func RegisterEncoding(enc encoding.Encoding) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry.Encodings = append(registry.Encodings, enc)
+}
+
+// RegisterFiles adds a file system holding reference parquet files to the list
+// of files validated by calls to Conformance.
+func RegisterFiles(files fs.FS) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry.Files = append(registry.Files, files)
+}
+
+// Conformance runs the conformance suite on all the codecs, encodings, and
+// files that were registered with the package, and on the reference files.
+func Conformance(t *testing.T) {
+	registryLock.Lock()
+	suite := Suite{
+		Codecs:    append([]compress.Codec{}, registry.Codecs...),
+		Encodings: append([]encoding.Encoding{}, registry.Encodings...),
+		Files:     append([]fs.FS{}, registry.Files...),
+	}
+	registryLock.Unlock()
+	suite.Run(t)
+}
+
+// Suite is a set of implementations to run the conformance tests against.
+//
+// Programs that prefer not to rely on the global registry of the package can
+// construct Suite values and call Run directly.
+type Suite struct {
+	Codecs    []compress.Codec
+	Encodings []encoding.Encoding
+	Files     []fs.FS
+}
+
+// Run runs the conformance tests of the suite, and validates the reference
+// files embedded in the package.
+func (s *Suite) Run(t *testing.T) {
+	for _, codec := range s.Codecs {
+		codec := codec
+		t.Run("codec="+codec.String(), func(t *testing.T) { Codec(t, codec) })
+	}
+	for _, enc := range s.Encodings {
+		enc := enc
+		t.Run("encoding="+enc.String(), func(t *testing.T) { Encoding(t, enc) })
+	}
+	for _, files := range s.Files {
+		Files(t, files)
+	}
+	ReferenceFiles(t)
+}
+
+// Codec validates the implementation of the compression codec passed as
+// argument.
+//
+// The test verifies that the codec is able to round trip various payloads,
+// that it can be used concurrently from multiple goroutines, that its output
+// can be decoded by the parquet package's implementation of the same codec
+// (and vice versa), and that files written with the codec can be read back.
+func Codec(t *testing.T, codec compress.Codec) {
+	t.Run("round-trip", func(t *testing.T) { testCodecRoundTrip(t, codec) })
+	t.Run("buffer-reuse", func(t *testing.T) { testCodecBufferReuse(t, codec) })
+	t.Run("concurrency", func(t *testing.T) { testCodecConcurrency(t, codec) })
+	t.Run("interoperability", func(t *testing.T) { testCodecInteroperability(t, codec) })
+	t.Run("write-and-read", func(t *testing.T) { testCodecWriteAndRead(t, codec) })
+}
+
+func codecPayloads() map[string][]byte {
+	prng := rand.New(rand.NewSource(0))
+	random := make([]byte, 1<<20)
+	prng.Read(random)
+	return map[string][]byte{
+		"empty":      {},
+		"one-byte":   {42},
+		"text":       []byte("Four score and seven years ago our fathers brought forth on this continent"),
+		"repetitive": bytes.Repeat([]byte("1234567890qwertyuiopasdfghjklzxcvbnm"), 10e3),
+		"zeros":      make([]byte, 64*1024),
+		"random":     random,
+	}
+}
+
+func testCodecRoundTrip(t *testing.T, codec compress.Codec) {
+	for name, payload := range codecPayloads() {
+		compressed, err := codec.Encode(nil, payload)
+		if err != nil {
+			t.Fatalf("%s: encoding: %v", name, err)
+		}
+		decompressed, err := codec.Decode(nil, compressed)
+		if err != nil {
+			t.Fatalf("%s: decoding: %v", name, err)
+		}
+		if !bytes.Equal(payload, decompressed) {
+			t.Fatalf("%s: content mismatch after round trip (%d bytes in, %d bytes out)", name, len(payload), len(decompressed))
+		}
+	}
+}
+
+func testCodecBufferReuse(t *testing.T, codec compress.Codec) {
+	// Codecs must overwrite the content of the output buffers they receive
+	// rather than appending to them.
+	var compressed, decompressed []byte
+	for name, payload := range codecPayloads() {
+		var err error
+		compressed, err = codec.Encode(append(compressed[:0], "garbage"...), payload)
+		if err != nil {
+			t.Fatalf("%s: encoding: %v", name, err)
+		}
+		decompressed, err = codec.Decode(append(decompressed[:0], "garbage"...), compressed)
+		if err != nil {
+			t.Fatalf("%s: decoding: %v", name, err)
+		}
+		if !bytes.Equal(payload, decompressed) {
+			t.Fatalf("%s: content mismatch when reusing output buffers", name)
+		}
+	}
+}
+
+func testCodecConcurrency(t *testing.T, codec compress.Codec) {
+	const numGoroutines = 8
+	payloads := codecPayloads()
+	errs := make(chan error, numGoroutines)
+
+	for i := 0; i < numGoroutines; i++ {
+		go func() {
+			var compressed, decompressed []byte
+			var err error
+			for name, payload := range payloads {
+				if compressed, err = codec.Encode(compressed, payload); err != nil {
+					errs <- fmt.Errorf("%s: encoding: %w", name, err)
+					return
+				}
+				if decompressed, err = codec.Decode(decompressed, compressed); err != nil {
+					errs <- fmt.Errorf("%s: decoding: %w", name, err)
+					return
+				}
+				if !bytes.Equal(payload, decompressed) {
+					errs <- fmt.Errorf("%s: content mismatch in concurrent round trip", name)
+					return
+				}
+			}
+			errs <- nil
+		}()
+	}
+
+	for i := 0; i < numGoroutines; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func testCodecInteroperability(t *testing.T, codec compress.Codec) {
+	reference := parquet.LookupCompressionCodec(codec.CompressionCodec())
+	if _, err := reference.Encode(nil, nil); err != nil {
+		t.Skipf("the parquet package has no implementation of %s to compare with", codec.CompressionCodec())
+	}
+
+	for name, payload := range codecPayloads() {
+		compressed, err := codec.Encode(nil, payload)
+		if err != nil {
+			t.Fatalf("%s: encoding: %v", name, err)
+		}
+		decompressed, err := reference.Decode(nil, compressed)
+		if err != nil {
+			t.Fatalf("%s: decoding with %s: %v", name, reference, err)
+		}
+		if !bytes.Equal(payload, decompressed) {
+			t.Fatalf("%s: content mismatch when decoding with %s", name, reference)
+		}
+
+		compressed, err = reference.Encode(nil, payload)
+		if err != nil {
+			t.Fatalf("%s: encoding with %s: %v", name, reference, err)
+		}
+		decompressed, err = codec.Decode(nil, compressed)
+		if err != nil {
+			t.Fatalf("%s: decoding: %v", name, err)
+		}
+		if !bytes.Equal(payload, decompressed) {
+			t.Fatalf("%s: content mismatch when decoding output of %s", name, reference)
+		}
+	}
+}
+
+type conformanceRow struct {
+	ID      int64   `parquet:"id"`
+	Name    string  `parquet:"name,optional"`
+	Score   float64 `parquet:"score"`
+	Tags    []string
+	Enabled bool `parquet:"enabled"`
+}
+
+func conformanceRows(n int) []conformanceRow {
+	prng := rand.New(rand.NewSource(1))
+	rows := make([]conformanceRow, n)
+	for i := range rows {
+		rows[i] = conformanceRow{
+			ID:      int64(i),
+			Name:    fmt.Sprintf("name-%d", prng.Intn(100)),
+			Score:   prng.Float64(),
+			Tags:    make([]string, prng.Intn(3)),
+			Enabled: prng.Intn(2) == 0,
+		}
+		for j := range rows[i].Tags {
+			rows[i].Tags[j] = fmt.Sprintf("tag-%d", prng.Intn(10))
+		}
+	}
+	return rows
+}
+
+func testCodecWriteAndRead(t *testing.T, codec compress.Codec) {
+	reference := parquet.LookupCompressionCodec(codec.CompressionCodec())
+	if _, err := reference.Encode(nil, nil); err != nil {
+		t.Skipf("files compressed with %s cannot be read by the parquet package", codec.CompressionCodec())
+	}
+
+	rows := conformanceRows(1000)
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.Compression(codec), parquet.PageBufferSize(1024)); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := parquet.Read[conformanceRow](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != len(rows) {
+		t.Fatalf("number of rows mismatch: want=%d got=%d", len(rows), len(read))
+	}
+	for i := range rows {
+		if !reflect.DeepEqual(normalizeRow(rows[i]), normalizeRow(read[i])) {
+			t.Fatalf("row %d mismatch:\nwant: %+v\ngot:  %+v", i, rows[i], read[i])
+		}
+	}
+}
+
+func normalizeRow(row conformanceRow) conformanceRow {
+	if len(row.Tags) == 0 {
+		row.Tags = nil
+	}
+	return row
+}
+
+// Encoding validates the implementation of the encoding passed as argument.
+//
+// The test verifies that all the value types supported by the encoding can be
+// round tripped, and that the encoding does not retain references to its input
+// or output buffers.
+//
+// Encodings which depend on a bit width (e.g. RLE, or dictionary indexes) are
+// tested with values that fit on 3 bits; the encoding must be configured with a
+// bit width large enough to represent these values.
+func Encoding(t *testing.T, enc encoding.Encoding) {
+	if enc.String() == "" {
+		t.Error("encodings must have a non-empty name")
+	}
+	bounded := isBoundedEncoding(enc.Encoding())
+
+	testEncoding(t, "LEVELS", encoding.CanEncodeLevels(enc), func() error {
+		src := make([]byte, 1000)
+		for i := range src {
+			src[i] = byte(i % 2)
+		}
+		buf, err := enc.EncodeLevels(nil, src)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeLevels(nil, buf)
+		return compare(src, out, err)
+	})
+
+	testEncoding(t, "BOOLEAN", encoding.CanEncodeBoolean(enc), func() error {
+		src := []byte{0x00, 0xFF, 0xAA, 0x55, 0x0F, 0xF0, 0x00, 0x00, 0xFF, 0xFF}
+		buf, err := enc.EncodeBoolean(nil, src)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeBoolean(nil, buf)
+		return compare(src, out, err)
+	})
+
+	testEncoding(t, "INT32", encoding.CanEncodeInt32(enc), func() error {
+		src := make([]int32, 1000)
+		for i := range src {
+			if bounded {
+				src[i] = int32(i % 8)
+			} else {
+				src[i] = int32(i*i) - 1000
+			}
+		}
+		if !bounded {
+			src = append(src, math.MinInt32, math.MaxInt32, 0)
+		}
+		buf, err := enc.EncodeInt32(nil, src)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeInt32(nil, buf)
+		return compare(src, out, err)
+	})
+
+	testEncoding(t, "INT64", encoding.CanEncodeInt64(enc), func() error {
+		src := make([]int64, 1000)
+		for i := range src {
+			if bounded {
+				src[i] = int64(i % 8)
+			} else {
+				src[i] = int64(i*i*i) - 1000
+			}
+		}
+		if !bounded {
+			src = append(src, math.MinInt64, math.MaxInt64, 0)
+		}
+		buf, err := enc.EncodeInt64(nil, src)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeInt64(nil, buf)
+		return compare(src, out, err)
+	})
+
+	testEncoding(t, "INT96", encoding.CanEncodeInt96(enc), func() error {
+		src := make([]deprecated.Int96, 100)
+		for i := range src {
+			src[i] = deprecated.Int96{uint32(i), uint32(2 * i), uint32(3 * i)}
+		}
+		buf, err := enc.EncodeInt96(nil, src)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeInt96(nil, buf)
+		return compare(src, out, err)
+	})
+
+	testEncoding(t, "FLOAT", encoding.CanEncodeFloat(enc), func() error {
+		src := make([]float32, 1000)
+		for i := range src {
+			src[i] = float32(i) / 3
+		}
+		src = append(src, float32(math.Inf(+1)), float32(math.Inf(-1)), math.MaxFloat32)
+		buf, err := enc.EncodeFloat(nil, src)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeFloat(nil, buf)
+		return compare(src, out, err)
+	})
+
+	testEncoding(t, "DOUBLE", encoding.CanEncodeDouble(enc), func() error {
+		src := make([]float64, 1000)
+		for i := range src {
+			src[i] = float64(i) / 3
+		}
+		src = append(src, math.Inf(+1), math.Inf(-1), math.MaxFloat64)
+		buf, err := enc.EncodeDouble(nil, src)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeDouble(nil, buf)
+		return compare(src, out, err)
+	})
+
+	testEncoding(t, "BYTE_ARRAY", encoding.CanEncodeByteArray(enc), func() error {
+		values := []byte{}
+		offsets := []uint32{0}
+		for i := 0; i < 1000; i++ {
+			values = append(values, fmt.Sprintf("value-%d", i%37)...)
+			if i%100 == 0 {
+				values = append(values, bytes.Repeat([]byte{'x'}, i)...)
+			}
+			offsets = append(offsets, uint32(len(values)))
+		}
+		buf, err := enc.EncodeByteArray(nil, values, offsets)
+		if err != nil {
+			return err
+		}
+		outValues, outOffsets, err := enc.DecodeByteArray(nil, buf, nil)
+		if err := compare(offsets, outOffsets, err); err != nil {
+			return fmt.Errorf("offsets: %w", err)
+		}
+		return compare(values, outValues, nil)
+	})
+
+	testEncoding(t, "FIXED_LEN_BYTE_ARRAY", encoding.CanEncodeFixedLenByteArray(enc), func() error {
+		const size = 16
+		src := make([]byte, 100*size)
+		for i := range src {
+			src[i] = byte(i)
+		}
+		buf, err := enc.EncodeFixedLenByteArray(nil, src, size)
+		if err != nil {
+			return err
+		}
+		out, err := enc.DecodeFixedLenByteArray(nil, buf, size)
+		return compare(src, out, err)
+	})
+}
+
+func isBoundedEncoding(enc format.Encoding) bool {
+	switch enc {
+	case format.RLE, format.BitPacked, format.PlainDictionary, format.RLEDictionary:
+		return true
+	default:
+		return false
+	}
+}
+
+func testEncoding(t *testing.T, typ string, supported bool, test func() error) {
+	t.Run(typ, func(t *testing.T) {
+		if !supported {
+			t.Skipf("encoding does not support %s values", typ)
+		}
+		if err := test(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func compare[T comparable](want, got []T, err error) error {
+	if err != nil {
+		return fmt.Errorf("decoding: %w", err)
+	}
+	if len(want) != len(got) {
+		return fmt.Errorf("number of values mismatch: want=%d got=%d", len(want), len(got))
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			return fmt.Errorf("values at index %d/%d mismatch: want=%+v got=%+v", i, len(want), want[i], got[i])
+		}
+	}
+	return nil
+}
+
+// Files validates that all the parquet files found in the file system passed
+// as argument can be opened, and that all pages of their columns can be read.
+//
+// Files are recognized by their ".parquet" extension.
+func Files(t *testing.T, files fs.FS) {
+	err := fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || path.Ext(name) != ".parquet" {
+			return nil
+		}
+		t.Run("file="+name, func(t *testing.T) {
+			data, err := fs.ReadFile(files, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := File(bytes.NewReader(data), int64(len(data))); err != nil {
+				t.Fatal(err)
+			}
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// File opens the parquet file read from r and validates that all the pages of
+// its columns can be read and are consistent with the file metadata.
+func File(r io.ReaderAt, size int64) error {
+	f, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buffer := make([]parquet.Value, 128)
+
+	for i, rowGroup := range f.RowGroups() {
+		for j, chunk := range rowGroup.ColumnChunks() {
+			numValues, err := readColumnChunk(chunk, buffer)
+			if err != nil {
+				return fmt.Errorf("row group %d, column %d: %w", i, j, err)
+			}
+			if want := chunk.NumValues(); numValues != want {
+				return fmt.Errorf("row group %d, column %d: column chunk declared %d values but %d were read", i, j, want, numValues)
+			}
+		}
+	}
+	return nil
+}
+
+func readColumnChunk(chunk parquet.ColumnChunk, buffer []parquet.Value) (numValues int64, err error) {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			return numValues, err
+		}
+
+		pageValues := int64(0)
+		values := p.Values()
+		for {
+			n, err := values.ReadValues(buffer)
+			pageValues += int64(n)
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					parquet.Release(p)
+					return numValues, err
+				}
+				break
+			}
+		}
+
+		if pageValues != p.NumValues() {
+			parquet.Release(p)
+			return numValues, fmt.Errorf("page declared %d values but %d were read", p.NumValues(), pageValues)
+		}
+
+		numValues += pageValues
+		parquet.Release(p)
+	}
+}
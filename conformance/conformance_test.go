@@ -0,0 +1,40 @@
+package conformance_test
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/conformance"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/encoding/rle"
+)
+
+func TestConformance(t *testing.T) {
+	suite := conformance.Suite{
+		Codecs: []compress.Codec{
+			&parquet.Uncompressed,
+			&parquet.Snappy,
+			&parquet.Gzip,
+			&parquet.Brotli,
+			&parquet.Zstd,
+			&parquet.Lz4Raw,
+			&parquet.Lz4,
+		},
+		Encodings: []encoding.Encoding{
+			&parquet.Plain,
+			&rle.Encoding{BitWidth: 3},
+			&parquet.RLEDictionary,
+			&parquet.DeltaBinaryPacked,
+			&parquet.DeltaLengthByteArray,
+			&parquet.DeltaByteArray,
+			&parquet.ByteStreamSplit,
+		},
+		Files: []fs.FS{
+			os.DirFS("../testdata"),
+		},
+	}
+	suite.Run(t)
+}
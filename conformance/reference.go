@@ -0,0 +1,222 @@
+package conformance
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// The reference files are copies of files of the parquet-testing repository
+// (https://github.com/apache/parquet-testing), covering the compression codecs,
+// encodings, and page formats written by other implementations.
+//
+//go:embed testdata/*.parquet
+var referenceData embed.FS
+
+// referenceFile describes a reference file and the content that reading it is
+// expected to produce.
+type referenceFile struct {
+	name    string
+	numRows int64
+	// First values of leaf columns, keyed by dot-separated column path, in the
+	// order they are read from the pages of the column chunks. Null values are
+	// represented by the zero value.
+	values map[string][]parquet.Value
+}
+
+var referenceFiles = []referenceFile{
+	{
+		name:    "alltypes_plain.parquet",
+		numRows: 8,
+		values: map[string][]parquet.Value{
+			"id":         valuesOf(int32(4), int32(5), int32(6), int32(7), int32(2), int32(3), int32(0), int32(1)),
+			"bool_col":   valuesOf(true, false, true, false, true, false, true, false),
+			"bigint_col": valuesOf(int64(0), int64(10), int64(0), int64(10), int64(0), int64(10), int64(0), int64(10)),
+			"float_col":  valuesOf(float32(0), float32(1.1), float32(0), float32(1.1), float32(0), float32(1.1), float32(0), float32(1.1)),
+			"double_col": valuesOf(0.0, 10.1, 0.0, 10.1, 0.0, 10.1, 0.0, 10.1),
+			"date_string_col": valuesOf(
+				[]byte("03/01/09"), []byte("03/01/09"), []byte("04/01/09"), []byte("04/01/09"),
+				[]byte("02/01/09"), []byte("02/01/09"), []byte("01/01/09"), []byte("01/01/09"),
+			),
+		},
+	},
+	{
+		name:    "alltypes_plain.snappy.parquet",
+		numRows: 2,
+		values: map[string][]parquet.Value{
+			"id":         valuesOf(int32(6), int32(7)),
+			"string_col": valuesOf([]byte("0"), []byte("1")),
+		},
+	},
+	{
+		name:    "alltypes_dictionary.parquet",
+		numRows: 2,
+		values: map[string][]parquet.Value{
+			"id":         valuesOf(int32(0), int32(1)),
+			"bigint_col": valuesOf(int64(0), int64(10)),
+			"string_col": valuesOf([]byte("0"), []byte("1")),
+		},
+	},
+	{
+		name:    "datapage_v2.snappy.parquet",
+		numRows: 5,
+		values: map[string][]parquet.Value{
+			"a":              valuesOf("abc", "abc", "abc", nil, "abc"),
+			"b":              valuesOf(int32(1), int32(2), int32(3), int32(4), int32(5)),
+			"c":              valuesOf(2.0, 3.0, 4.0, 5.0, 2.0),
+			"d":              valuesOf(true, true, true, false, true),
+			"e.list.element": valuesOf(int32(1), int32(2), int32(3), nil, nil, int32(1), int32(2), int32(3), int32(1), int32(2)),
+		},
+	},
+	{
+		name:    "nulls.snappy.parquet",
+		numRows: 8,
+		values: map[string][]parquet.Value{
+			"b_struct.b_c_int": valuesOf(nil, nil, nil, nil, nil, nil, nil, nil),
+		},
+	},
+	{
+		name:    "rle_boolean_encoding.parquet",
+		numRows: 68,
+		values: map[string][]parquet.Value{
+			"datatype_boolean": valuesOf(true, false, nil, true, true, false, false, true, true, true),
+		},
+	},
+	{
+		name:    "lz4_raw_compressed.parquet",
+		numRows: 4,
+		values: map[string][]parquet.Value{
+			"c0":  valuesOf(int64(1593604800), int64(1593604800), int64(1593604801), int64(1593604801)),
+			"c1":  valuesOf([]byte("abc"), []byte("def"), []byte("abc"), []byte("def")),
+			"v11": valuesOf(42.0, 7.7, 42.125, 7.7),
+		},
+	},
+	{
+		name:    "delta_length_byte_array.parquet",
+		numRows: 1000,
+		values: map[string][]parquet.Value{
+			"FRUIT": func() []parquet.Value {
+				values := make([]parquet.Value, 1000)
+				for i := range values {
+					values[i] = parquet.ValueOf(fmt.Sprintf("apple_banana_mango%d", i*i))
+				}
+				return values
+			}(),
+		},
+	},
+	{
+		name:    "nested_lists.snappy.parquet",
+		numRows: 3,
+		values: map[string][]parquet.Value{
+			"b": valuesOf(int32(1), int32(1), int32(1)),
+		},
+	},
+}
+
+func valuesOf(values ...interface{}) []parquet.Value {
+	v := make([]parquet.Value, len(values))
+	for i, value := range values {
+		v[i] = parquet.ValueOf(value)
+	}
+	return v
+}
+
+// ReferenceFiles validates that the parquet package reads the reference files
+// embedded in the conformance package, which are copies of files written by
+// other implementations of parquet. The test verifies that the pages of their
+// columns are consistent with the file metadata, and that the files contain
+// the expected number of rows and column values.
+//
+// The reference files are always validated by Conformance and Suite.Run.
+func ReferenceFiles(t *testing.T) {
+	for _, file := range referenceFiles {
+		file := file
+		t.Run("reference="+file.name, func(t *testing.T) {
+			data, err := referenceData.ReadFile("testdata/" + file.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := File(bytes.NewReader(data), int64(len(data))); err != nil {
+				t.Fatal(err)
+			}
+			if err := checkReferenceFile(bytes.NewReader(data), int64(len(data)), file); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func checkReferenceFile(r *bytes.Reader, size int64, file referenceFile) error {
+	f, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if numRows := f.NumRows(); numRows != file.numRows {
+		return fmt.Errorf("number of rows mismatch: want=%d got=%d", file.numRows, numRows)
+	}
+
+	buffer := make([]parquet.Value, 128)
+	for path, want := range file.values {
+		leaf, ok := f.Schema().Lookup(strings.Split(path, ".")...)
+		if !ok {
+			return fmt.Errorf("column %s: not found in the file schema", path)
+		}
+		var got []parquet.Value
+		for _, rowGroup := range f.RowGroups() {
+			values, err := readColumnValues(rowGroup.ColumnChunks()[leaf.ColumnIndex], buffer, got)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", path, err)
+			}
+			got = values
+		}
+		if len(got) < len(want) {
+			return fmt.Errorf("column %s: number of values mismatch: want>=%d got=%d", path, len(want), len(got))
+		}
+		for i, value := range want {
+			if !parquet.Equal(value, got[i]) {
+				return fmt.Errorf("column %s: value at index %d mismatch: want=%v got=%v", path, i, value, got[i])
+			}
+		}
+	}
+	return nil
+}
+
+// readColumnValues appends the values of the column chunk to values, cloning
+// them since the buffers of pages are released after being read.
+func readColumnValues(chunk parquet.ColumnChunk, buffer, values []parquet.Value) ([]parquet.Value, error) {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			return values, err
+		}
+		r := p.Values()
+		for {
+			n, err := r.ReadValues(buffer)
+			for _, v := range buffer[:n] {
+				values = append(values, v.Clone())
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					parquet.Release(p)
+					return values, err
+				}
+				break
+			}
+		}
+		parquet.Release(p)
+	}
+}
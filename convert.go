@@ -83,7 +83,7 @@ func convertToSelf(column []Value) error { return nil }
 func convertToType(targetType, sourceType Type) conversionFunc {
 	return func(column []Value) error {
 		for i, v := range column {
-			v, err := sourceType.ConvertValue(v, targetType)
+			v, err := targetType.ConvertValue(v, sourceType)
 			if err != nil {
 				return err
 			}
@@ -117,6 +117,29 @@ func convertToZero(kind Kind) conversionFunc {
 	}
 }
 
+// convertToDefault replaces the values of a column with a default value. The
+// values are read from a neighbor column, the default is only applied where
+// the parent group of the column was defined.
+//
+//go:noinline
+func convertToDefault(value Value, parentDefinitionLevel, maxDefinitionLevel byte) conversionFunc {
+	return func(column []Value) error {
+		for i := range column {
+			if column[i].definitionLevel >= parentDefinitionLevel {
+				column[i].ptr = value.ptr
+				column[i].u64 = value.u64
+				column[i].kind = value.kind
+				column[i].definitionLevel = maxDefinitionLevel
+			} else {
+				column[i].ptr = nil
+				column[i].u64 = 0
+				column[i].kind = value.kind
+			}
+		}
+		return nil
+	}
+}
+
 //go:noinline
 func convertToLevels(repetitionLevels, definitionLevels []byte) conversionFunc {
 	return func(column []Value) error {
@@ -236,10 +259,16 @@ func (id identity) Schema() *Schema                 { return id.schema }
 // The function supports converting between schemas where the source or target
 // have extra columns; if there are more columns in the source, they will be
 // stripped out of the rows. Extra columns in the target schema will be set to
-// null or zero values.
+// null or zero values. Columns are matched by path, so they may appear in a
+// different order in both schemas, and values of columns whose types differ
+// are converted to the type of the target column (e.g. INT32 to INT64), or the
+// conversion reports an error if they cannot be.
 //
 // The returned function is intended to be used to append the converted source
-// row to the destination buffer.
+// row to the destination buffer. Conversions are the building block of schema
+// migrations: ConvertRowReader applies them to rows read from a file, which can
+// then be written with the target schema, and ConvertRowGroup applies them to
+// whole row groups.
 func Convert(to, from Node) (conv Conversion, err error) {
 	schema, _ := to.(*Schema)
 	if schema == nil {
@@ -309,11 +338,31 @@ func Convert(to, from Node) (conv Conversion, err error) {
 			targetType := targetColumn.node.Type()
 			targetKind := targetType.Kind()
 			sourceColumn = sourceMapping.lookupClosest(path)
-			if sourceColumn.node != nil {
+			// Default values are only applied to columns which are not
+			// repeated, since there is no way to tell how many values the
+			// missing column would have had.
+			defaultValue := defaultValueOf(targetColumn.node)
+			if targetColumn.maxRepetitionLevel > 0 {
+				defaultValue = Value{}
+			}
+			switch {
+			case sourceColumn.node != nil && !defaultValue.IsNull():
+				parentDefinitionLevel := targetColumn.maxDefinitionLevel
+				if targetColumn.node.Optional() {
+					parentDefinitionLevel--
+				}
+				conversions = append(conversions,
+					convertToDefault(defaultValue, parentDefinitionLevel, targetColumn.maxDefinitionLevel),
+				)
+			case sourceColumn.node != nil:
 				conversions = append(conversions,
 					convertToZero(targetKind),
 				)
-			} else {
+			case !defaultValue.IsNull():
+				conversions = append(conversions,
+					convertToValue(defaultValue.Level(0, int(targetColumn.maxDefinitionLevel), 0)),
+				)
+			default:
 				conversions = append(conversions,
 					convertToValue(ZeroValue(targetKind)),
 				)
@@ -1015,6 +1064,14 @@ func convertTimestampToTimeMicros(v Value, u format.TimeUnit, sourceZone, target
 	return v.convertToInt64(int64(microseconds)), nil
 }
 
+// convertInt96ToTimestamp converts legacy INT96 timestamps, holding the Julian
+// day and the nanoseconds since midnight, to TIMESTAMP values of the given unit.
+func convertInt96ToTimestamp(v Value, u format.TimeUnit) (Value, error) {
+	t := v.int96().Time()
+	d := timeUnitDuration(u)
+	return v.convertToInt64(t.Unix()*int64(time.Second/d) + int64(t.Nanosecond())/int64(d)), nil
+}
+
 func convertTimestampToTimestamp(v Value, sourceUnit, targetUnit format.TimeUnit) (Value, error) {
 	sourceScale := timeUnitDuration(sourceUnit).Nanoseconds()
 	targetScale := timeUnitDuration(targetUnit).Nanoseconds()
@@ -1,6 +1,9 @@
 package parquet_test
 
 import (
+	"bytes"
+	"fmt"
+	"log"
 	"reflect"
 	"testing"
 	"time"
@@ -479,6 +482,49 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func ExampleConvert() {
+	type UserV1 struct {
+		Name  string `parquet:"name"`
+		Age   int32  `parquet:"age"`
+		Email string `parquet:"email"`
+	}
+	type UserV2 struct {
+		Age     int64   `parquet:"age"`
+		Name    string  `parquet:"name"`
+		Country *string `parquet:"country,optional"`
+	}
+
+	input := new(bytes.Buffer)
+	if err := parquet.Write(input, []UserV1{{Name: "Luke", Age: 19, Email: "luke@example.com"}}); err != nil {
+		log.Fatal(err)
+	}
+
+	// Reorder the name and age columns, promote age to INT64, drop the email
+	// column, and add the country column, which is read as null.
+	schema := parquet.SchemaOf(UserV2{})
+	conv, err := parquet.Convert(schema, parquet.SchemaOf(UserV1{}))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(input.Bytes()))
+	output := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[UserV2](output, schema)
+	if _, err := parquet.CopyRows(writer, parquet.ConvertRowReader(reader, conv)); err != nil {
+		log.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	users, err := parquet.Read[UserV2](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s %d %v\n", users[0].Name, users[0].Age, users[0].Country)
+	// Output: Luke 19 <nil>
+}
+
 func newInt64(i int64) *int64    { return &i }
 func newString(s string) *string { return &s }
 
@@ -0,0 +1,251 @@
+package parquet
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// ColumnStats carries the statistics of a column aggregated over multiple
+// column chunks, for example all the row groups of a file or all the files of
+// a dataset.
+type ColumnStats struct {
+	// Path of the column in the schema.
+	Path []string
+	// Physical type of the column, which determines how the min and max values
+	// are compared.
+	Type format.Type
+	// Number of values and nulls in the column.
+	NumValues int64
+	NullCount int64
+	// Min and max values of the column, only set if HasBounds is true.
+	Min Value
+	Max Value
+	// Reports whether the min and max values of the column are known. Bounds
+	// are unknown if one of the aggregated column chunks had non-null values
+	// but no statistics, or if the column contains only null values.
+	HasBounds bool
+}
+
+// merge aggregates the statistics of other into s.
+//
+// Column chunks which contain only null values do not contribute to the
+// bounds, and column chunks with non-null values but no bounds make the bounds
+// of the aggregate unknown.
+func (s *ColumnStats) merge(other *ColumnStats) {
+	hasValues := s.NumValues > s.NullCount
+
+	switch {
+	case other.NumValues <= other.NullCount:
+		// Only nulls, the bounds are unchanged.
+	case !other.HasBounds || other.Type != s.Type:
+		s.HasBounds, s.Min, s.Max = false, Value{}, Value{}
+	case s.HasBounds:
+		compare := kindType(Kind(s.Type)).Compare
+		if compare(other.Min, s.Min) < 0 {
+			s.Min = other.Min
+		}
+		if compare(other.Max, s.Max) > 0 {
+			s.Max = other.Max
+		}
+	case !hasValues:
+		s.HasBounds, s.Min, s.Max = true, other.Min, other.Max
+	}
+
+	s.NumValues += other.NumValues
+	s.NullCount += other.NullCount
+}
+
+// overlaps returns true if the column may contain values between min and max.
+func (s *ColumnStats) overlaps(min, max Value) bool {
+	if !s.HasBounds {
+		return s.NumValues > s.NullCount
+	}
+	compare := kindType(Kind(s.Type)).Compare
+	return compare(s.Min, max) <= 0 && compare(min, s.Max) <= 0
+}
+
+// kindType returns the type used to compare values of the given kind.
+// Fixed length byte arrays compare like byte arrays.
+func kindType(kind Kind) Type {
+	switch kind {
+	case Boolean:
+		return BooleanType
+	case Int32:
+		return Int32Type
+	case Int64:
+		return Int64Type
+	case Int96:
+		return Int96Type
+	case Float:
+		return FloatType
+	case Double:
+		return DoubleType
+	default:
+		return ByteArrayType
+	}
+}
+
+// ColumnStats returns the statistics of the leaf columns of the file, in the
+// order of m.Columns, aggregated over all its row groups.
+//
+// Min and max values are compared according to the physical type of columns,
+// which may differ from the order of their logical type (e.g. for unsigned
+// integers).
+func (m *PruningMetadata) ColumnStats() []ColumnStats {
+	stats := make([]ColumnStats, len(m.Columns))
+
+	for i, column := range m.Columns {
+		stats[i] = ColumnStats{Path: column.Path, Type: column.Type}
+
+		for j := range m.RowGroups {
+			chunk := &m.RowGroups[j].Columns[i]
+			chunkStats := ColumnStats{
+				Type:      column.Type,
+				NumValues: chunk.NumValues,
+				NullCount: chunk.NullCount,
+			}
+			chunkStats.Min, chunkStats.Max, chunkStats.HasBounds = m.Bounds(j, i)
+			stats[i].merge(&chunkStats)
+		}
+	}
+
+	return stats
+}
+
+// Dataset aggregates the statistics of the files of a dataset, allowing query
+// planners to skip files which cannot contain the values they look for without
+// opening them.
+//
+// Files are added with their pruning metadata, which programs usually load
+// from a catalog where it was stored after being serialized with
+// PruningMetadata.MarshalBinary. The statistics of each file are aggregated
+// when it is added, the statistics of the dataset are aggregated on demand and
+// cached until files are added or removed. Columns of different files are
+// matched by path.
+//
+// The zero-value is a valid empty dataset. Dataset values are safe to use
+// concurrently from multiple goroutines.
+type Dataset struct {
+	mutex sync.Mutex
+	files map[string][]ColumnStats
+	// Cached statistics of the dataset, nil when files were added or removed.
+	columns []ColumnStats
+}
+
+// Add adds a file to the dataset, replacing the file of the same name if there
+// was one.
+func (d *Dataset) Add(name string, metadata *PruningMetadata) {
+	stats := metadata.ColumnStats()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.files == nil {
+		d.files = make(map[string][]ColumnStats)
+	}
+	d.files[name] = stats
+	d.columns = nil
+}
+
+// Remove removes a file from the dataset.
+func (d *Dataset) Remove(name string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, ok := d.files[name]; ok {
+		delete(d.files, name)
+		d.columns = nil
+	}
+}
+
+// Files returns the names of the files of the dataset, in lexicographical order.
+func (d *Dataset) Files() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.fileNames()
+}
+
+func (d *Dataset) fileNames() []string {
+	names := make([]string, 0, len(d.files))
+	for name := range d.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FileStats returns the statistics of the columns of the file with the given
+// name, aggregated over all its row groups. The ok boolean is false if the
+// dataset has no file with this name.
+//
+// The returned slice is shared with the dataset, programs must treat it as
+// read-only.
+func (d *Dataset) FileStats(name string) (stats []ColumnStats, ok bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	stats, ok = d.files[name]
+	return stats, ok
+}
+
+// ColumnStats returns the statistics of the columns of the dataset, aggregated
+// over all its files. Columns are ordered by their first appearance in the
+// files, taken in lexicographical order of their names.
+//
+// The returned slice is shared with the dataset, programs must treat it as
+// read-only.
+func (d *Dataset) ColumnStats() []ColumnStats {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.columns == nil {
+		columns := []ColumnStats{}
+		indexes := make(map[string]int)
+
+		for _, name := range d.fileNames() {
+			for i := range d.files[name] {
+				file := &d.files[name][i]
+				key := columnPath(file.Path).String()
+				j, ok := indexes[key]
+				if !ok {
+					j = len(columns)
+					indexes[key] = j
+					columns = append(columns, ColumnStats{Path: file.Path, Type: file.Type})
+				}
+				columns[j].merge(file)
+			}
+		}
+
+		d.columns = columns
+	}
+
+	return d.columns
+}
+
+// Search returns the names of files of the dataset which may contain values
+// of the column at the given path between min and max (inclusive), in
+// lexicographical order.
+//
+// Files with no statistics for the column are always returned, files which
+// do not have the column or where it contains only nulls are never returned.
+// The min and max values must be of the physical type of the column.
+func (d *Dataset) Search(path []string, min, max Value) []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	names := []string{}
+
+	for _, name := range d.fileNames() {
+		for i := range d.files[name] {
+			if stats := &d.files[name][i]; stringsAreEqual(stats.Path, path) {
+				if stats.overlaps(min, max) {
+					names = append(names, name)
+				}
+				break
+			}
+		}
+	}
+
+	return names
+}
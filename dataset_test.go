@@ -0,0 +1,86 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestDataset(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Name *string `parquet:"name,optional"`
+	}
+
+	name := func(s string) *string { return &s }
+
+	metadataOf := func(rows []Row) *parquet.PruningMetadata {
+		t.Helper()
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows, parquet.MaxRowsPerRowGroup(2)); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f.PruningMetadata()
+	}
+
+	dataset := new(parquet.Dataset)
+	dataset.Add("a.parquet", metadataOf([]Row{{ID: 1, Name: name("b")}, {ID: 2}, {ID: 3, Name: name("c")}}))
+	dataset.Add("b.parquet", metadataOf([]Row{{ID: 10}, {ID: 12}}))
+	dataset.Add("c.parquet", metadataOf([]Row{{ID: 5, Name: name("a")}, {ID: 7, Name: name("z")}}))
+
+	if files, want := dataset.Files(), []string{"a.parquet", "b.parquet", "c.parquet"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files mismatch: want=%q got=%q", want, files)
+	}
+
+	stats, ok := dataset.FileStats("a.parquet")
+	if !ok {
+		t.Fatal("missing file stats")
+	}
+	if id := stats[0]; !id.HasBounds || id.Min.Int64() != 1 || id.Max.Int64() != 3 || id.NumValues != 3 {
+		t.Errorf("file stats mismatch: want=[1,3] (3 values) got=[%v,%v] (%d values)", id.Min, id.Max, id.NumValues)
+	}
+	if name := stats[1]; !name.HasBounds || name.Min.String() != "b" || name.Max.String() != "c" || name.NullCount != 1 {
+		t.Errorf("file stats mismatch: want=[b,c] (1 null) got=[%v,%v] (%d nulls)", name.Min, name.Max, name.NullCount)
+	}
+
+	columns := dataset.ColumnStats()
+	if len(columns) != 2 {
+		t.Fatalf("number of columns mismatch: want=2 got=%d", len(columns))
+	}
+	if id := columns[0]; !id.HasBounds || id.Min.Int64() != 1 || id.Max.Int64() != 12 || id.NumValues != 7 {
+		t.Errorf("dataset stats mismatch: want=[1,12] (7 values) got=[%v,%v] (%d values)", id.Min, id.Max, id.NumValues)
+	}
+	if name := columns[1]; !name.HasBounds || name.Min.String() != "a" || name.Max.String() != "z" || name.NullCount != 3 {
+		t.Errorf("dataset stats mismatch: want=[a,z] (3 nulls) got=[%v,%v] (%d nulls)", name.Min, name.Max, name.NullCount)
+	}
+
+	for _, test := range []struct {
+		path     string
+		min, max parquet.Value
+		want     []string
+	}{
+		{"id", parquet.Int64Value(4), parquet.Int64Value(6), []string{"c.parquet"}},
+		{"id", parquet.Int64Value(3), parquet.Int64Value(10), []string{"a.parquet", "b.parquet", "c.parquet"}},
+		{"id", parquet.Int64Value(13), parquet.Int64Value(20), []string{}},
+		{"name", parquet.ByteArrayValue([]byte("d")), parquet.ByteArrayValue([]byte("d")), []string{"c.parquet"}},
+		{"missing", parquet.Int64Value(0), parquet.Int64Value(0), []string{}},
+	} {
+		if files := dataset.Search([]string{test.path}, test.min, test.max); !reflect.DeepEqual(files, test.want) {
+			t.Errorf("search %s in [%v,%v]: want=%q got=%q", test.path, test.min, test.max, test.want, files)
+		}
+	}
+
+	dataset.Remove("c.parquet")
+	if id := dataset.ColumnStats()[0]; id.Min.Int64() != 1 || id.Max.Int64() != 12 || id.NumValues != 5 {
+		t.Errorf("dataset stats mismatch after removing file: want=[1,12] (5 values) got=[%v,%v] (%d values)", id.Min, id.Max, id.NumValues)
+	}
+	if name := dataset.ColumnStats()[1]; name.Min.String() != "b" || name.Max.String() != "c" {
+		t.Errorf("dataset stats mismatch after removing file: want=[b,c] got=[%v,%v]", name.Min, name.Max)
+	}
+}
@@ -0,0 +1,145 @@
+package parquet
+
+import "io"
+
+// DeletedColumn is the name of the top-level BOOLEAN column which marks rows
+// as deleted, following the soft-delete convention of pipelines that capture
+// data changes to parquet files: instead of rewriting files to remove rows,
+// new versions of the rows are written with the column set to true.
+//
+// Readers skip rows marked as deleted unless they are configured with the
+// ReadDeleted option.
+const DeletedColumn = "_deleted"
+
+// SoftDelete may be embedded in Go structs to add the DeletedColumn to the
+// schema of rows written and read with the type, for example:
+//
+//	type RowType struct {
+//		parquet.SoftDelete
+//		ID   int64  `parquet:"id"`
+//		Name string `parquet:"name"`
+//	}
+//
+//	writer.Write([]RowType{{SoftDelete: parquet.SoftDelete{Deleted: true}, ID: 42}})
+type SoftDelete struct {
+	Deleted bool `parquet:"_deleted"`
+}
+
+// skipDeletedRows returns a row group exposing the rows of rowGroup which are
+// not marked as deleted in the DeletedColumn of source, the row group that
+// rowGroup was converted from. The function returns rowGroup itself if source
+// has no such column.
+//
+// The column is read from the column chunk of source, since the schema of
+// rowGroup may not contain it.
+func skipDeletedRows(rowGroup, source RowGroup) RowGroup {
+	leaf, ok := source.Schema().Lookup(DeletedColumn)
+	if !ok || leaf.MaxRepetitionLevel > 0 || leaf.Node.Type().Kind() != Boolean {
+		return rowGroup
+	}
+	return &liveRowGroup{RowGroup: rowGroup, deleted: source.ColumnChunks()[leaf.ColumnIndex]}
+}
+
+// liveRowGroup is a row group which only exposes the rows not marked as deleted
+// from its Rows method. The column chunks and number of rows are those of the
+// underlying row group, and row indexes passed to SeekToRow include the deleted
+// rows.
+type liveRowGroup struct {
+	RowGroup
+	deleted ColumnChunk
+}
+
+func (g *liveRowGroup) Rows() Rows {
+	return &liveRows{Rows: g.RowGroup.Rows(), pages: g.deleted.Pages()}
+}
+
+type liveRows struct {
+	Rows
+	// Index of the next row read from the underlying rows, which counts the
+	// deleted rows that were skipped.
+	rowIndex int64
+	pages    Pages
+	page     Page
+	values   ValueReader
+	buffer   []Value
+}
+
+func (r *liveRows) ReadRows(rows []Row) (int, error) {
+	for {
+		n, err := r.Rows.ReadRows(rows)
+		r.rowIndex += int64(n)
+		if n > 0 {
+			if err := r.readDeleted(n); err != nil {
+				return 0, err
+			}
+		}
+		i := 0
+		for j := range rows[:n] {
+			if v := r.buffer[j]; v.IsNull() || !v.boolean() {
+				// Swap the rows instead of overwriting them to retain the
+				// buffers of all the rows passed by the caller.
+				rows[i], rows[j] = rows[j], rows[i]
+				i++
+			}
+		}
+		if i > 0 || n == 0 || err != nil {
+			return i, err
+		}
+	}
+}
+
+// readDeleted reads the values of the deleted column for the next n rows.
+func (r *liveRows) readDeleted(n int) error {
+	if cap(r.buffer) < n {
+		r.buffer = make([]Value, 0, n)
+	}
+	r.buffer = r.buffer[:0]
+
+	for len(r.buffer) < n {
+		if r.values == nil {
+			r.release()
+			p, err := r.pages.ReadPage()
+			if err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return err
+			}
+			r.page, r.values = p, p.Values()
+		}
+		k, err := r.values.ReadValues(r.buffer[len(r.buffer):n])
+		r.buffer = r.buffer[:len(r.buffer)+k]
+		switch err {
+		case nil:
+		case io.EOF:
+			r.values = nil
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *liveRows) release() {
+	if r.page != nil {
+		Release(r.page)
+		r.page = nil
+	}
+}
+
+func (r *liveRows) SeekToRow(rowIndex int64) error {
+	if err := r.Rows.SeekToRow(rowIndex); err != nil {
+		return err
+	}
+	r.release()
+	r.values = nil
+	r.rowIndex = rowIndex
+	return r.pages.SeekToRow(rowIndex)
+}
+
+func (r *liveRows) Close() error {
+	r.release()
+	r.values = nil
+	r.pages.Close()
+	return r.Rows.Close()
+}
@@ -0,0 +1,170 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReaderSkipsDeletedRows(t *testing.T) {
+	type Row struct {
+		parquet.SoftDelete
+		ID int64 `parquet:"id"`
+	}
+	type Projection struct {
+		ID int64 `parquet:"id"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		rows[i].Deleted = i%3 != 0
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.MaxRowsPerRowGroup(40)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf, ok := f.Schema().Lookup(parquet.DeletedColumn); !ok || leaf.Node.Type().Kind() != parquet.Boolean {
+		t.Fatalf("missing %s column in schema:\n%s", parquet.DeletedColumn, f.Schema())
+	}
+
+	var live []Projection
+	for _, row := range rows {
+		if !row.Deleted {
+			live = append(live, Projection{ID: row.ID})
+		}
+	}
+
+	t.Run("default", func(t *testing.T) {
+		values := readAll(t, parquet.NewGenericReader[Projection](f))
+		if !reflect.DeepEqual(values, live) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", live, values)
+		}
+	})
+
+	t.Run("row group", func(t *testing.T) {
+		values := readAll(t, parquet.NewGenericRowGroupReader[Row](f.RowGroups()[1]))
+		for _, v := range values {
+			if v.Deleted || v.ID < 40 || v.ID >= 80 {
+				t.Errorf("unexpected row: %+v", v)
+			}
+		}
+		if len(values) != 13 {
+			t.Errorf("number of rows mismatch: want=%d got=%d", 13, len(values))
+		}
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		reader := parquet.NewGenericReader[Projection](f)
+		if err := reader.SeekToRow(50); err != nil {
+			t.Fatal(err)
+		}
+		values := readAll(t, reader)
+		want := live[len(live)-17:]
+		if !reflect.DeepEqual(values, want) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", want, values)
+		}
+	})
+
+	t.Run("read deleted", func(t *testing.T) {
+		values := readAll(t, parquet.NewGenericReader[Row](f, parquet.ReadDeleted(true)))
+		if !reflect.DeepEqual(values, rows) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, values)
+		}
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		reader := parquet.NewReader(f)
+		defer reader.Close()
+		n := 0
+		for {
+			row := Row{}
+			if err := reader.Read(&row); err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				break
+			}
+			if row.Deleted {
+				t.Errorf("unexpected deleted row: %+v", row)
+			}
+			n++
+		}
+		if n != len(live) {
+			t.Errorf("number of rows mismatch: want=%d got=%d", len(live), n)
+		}
+	})
+
+	t.Run("reader projection", func(t *testing.T) {
+		reader := parquet.NewReader(f)
+		defer reader.Close()
+		var values []Projection
+		for {
+			row := Projection{}
+			if err := reader.Read(&row); err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				break
+			}
+			values = append(values, row)
+		}
+		if !reflect.DeepEqual(values, live) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", live, values)
+		}
+	})
+
+	t.Run("reader switching types", func(t *testing.T) {
+		reader := parquet.NewReader(f)
+		defer reader.Close()
+		var values []Projection
+		for i := 0; ; i++ {
+			var err error
+			if i%2 == 0 {
+				row := Row{}
+				if err = reader.Read(&row); err == nil {
+					values = append(values, Projection{ID: row.ID})
+				}
+			} else {
+				row := Projection{}
+				if err = reader.Read(&row); err == nil {
+					values = append(values, row)
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				break
+			}
+		}
+		if !reflect.DeepEqual(values, live) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", live, values)
+		}
+	})
+}
+
+func readAll[T any](t *testing.T, reader *parquet.GenericReader[T]) []T {
+	t.Helper()
+	defer reader.Close()
+	var values []T
+	buffer := make([]T, 7)
+	for {
+		n, err := reader.Read(buffer)
+		values = append(values, buffer[:n]...)
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			return values
+		}
+	}
+}
@@ -3,6 +3,7 @@ package deprecated
 import (
 	"math/big"
 	"math/bits"
+	"time"
 	"unsafe"
 )
 
@@ -29,6 +30,35 @@ func Int64ToInt96(value int64) (i96 Int96) {
 	return
 }
 
+// TimeToInt96 converts a time.Time value to the representation of the legacy
+// INT96 timestamps: the number of nanoseconds since midnight in the 8 low
+// bytes, and the Julian day number in the 4 high bytes.
+func TimeToInt96(t time.Time) (i96 Int96) {
+	t = t.UTC()
+	days := t.Unix() / secondsPerDay
+	if t.Unix()%secondsPerDay < 0 {
+		days--
+	}
+	nanos := uint64(t.Unix()-days*secondsPerDay)*uint64(time.Second) + uint64(t.Nanosecond())
+	i96[0] = uint32(nanos)
+	i96[1] = uint32(nanos >> 32)
+	i96[2] = uint32(days + julianDayOfUnixEpoch)
+	return
+}
+
+// Time interprets i as a legacy INT96 timestamp, as written by Impala, Hive,
+// and older versions of Spark, and returns the time in UTC that it represents.
+func (i Int96) Time() time.Time {
+	days := int64(i[2]) - julianDayOfUnixEpoch
+	nanos := int64(i[1])<<32 | int64(i[0])
+	return time.Unix(days*secondsPerDay, nanos).UTC()
+}
+
+const (
+	julianDayOfUnixEpoch = 2440588
+	secondsPerDay        = 24 * 60 * 60
+)
+
 // IsZero returns true if i is the zero-value.
 func (i Int96) IsZero() bool { return i == Int96{} }
 
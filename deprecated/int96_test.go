@@ -3,6 +3,7 @@ package deprecated_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/parquet-go/parquet-go/deprecated"
 )
@@ -115,3 +116,23 @@ func TestMaxLenInt96(t *testing.T) {
 		})
 	}
 }
+
+func TestInt96Time(t *testing.T) {
+	for _, want := range []time.Time{
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 11, 10, 23, 0, 0, 123456789, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(1582, 10, 15, 12, 30, 0, 0, time.UTC),
+	} {
+		i96 := deprecated.TimeToInt96(want)
+		if got := i96.Time(); !got.Equal(want) {
+			t.Errorf("%v: wrong time: got=%v", want, got)
+		}
+	}
+
+	// 2000-01-01 is Julian day 2451545.
+	i96 := deprecated.TimeToInt96(time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC))
+	if i96 != (deprecated.Int96{0: 1e9, 2: 2451545}) {
+		t.Errorf("wrong representation of 2000-01-01T00:00:01Z: %v", [3]uint32(i96))
+	}
+}
@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"fmt"
 	"io"
 	"math/bits"
 	"unsafe"
@@ -86,6 +87,29 @@ type Dictionary interface {
 	//lookup(indexes []int32, rows sparse.Array)
 }
 
+// DictionaryIndexes returns the dictionary indexes held by a page, or nil if
+// the page is not dictionary encoded.
+//
+// The indexes are those of the non-null values of the page, in the order they
+// appear in the page; the repetition and definition levels of the page can be
+// used to reconstruct the position of null values. Values are materialized by
+// looking up the indexes in the page dictionary:
+//
+//	if indexes := parquet.DictionaryIndexes(page); indexes != nil {
+//		values := make([]parquet.Value, len(indexes))
+//		page.Dictionary().Lookup(indexes, values)
+//	}
+//
+// The returned slice shares the memory of the page, it remains valid until the
+// page is released.
+func DictionaryIndexes(page Page) []int32 {
+	if page.Dictionary() == nil {
+		return nil
+	}
+	data := page.Data()
+	return data.Int32()
+}
+
 func checkLookupIndexBounds(indexes []int32, rows sparse.Array) {
 	if rows.Len() < len(indexes) {
 		panic("dictionary lookup with more indexes than values")
@@ -792,6 +816,164 @@ func (d *byteArrayDictionary) Page() Page {
 	return &d.byteArrayPage
 }
 
+// lazyDictionaryStride is the number of values between each offset recorded by
+// lazy dictionaries.
+const lazyDictionaryStride = 64
+
+// lazyByteArrayDictionary is a dictionary of BYTE_ARRAY values which retains
+// the PLAIN encoded content of a dictionary page and locates values when they
+// are looked up, instead of decoding all values when the dictionary is loaded.
+//
+// Only the offset of every lazyDictionaryStride value is recorded, looking up a
+// value skips at most lazyDictionaryStride-1 length prefixes from the closest
+// recorded offset.
+//
+// The dictionary is materialized into a byteArrayDictionary when it is first
+// modified or when its page is requested.
+type lazyByteArrayDictionary struct {
+	typ         Type
+	data        []byte
+	offsets     []uint32
+	numValues   int
+	columnIndex int16
+	dict        *byteArrayDictionary
+}
+
+func newLazyByteArrayDictionary(typ Type, columnIndex int16, data []byte) (*lazyByteArrayDictionary, error) {
+	d := &lazyByteArrayDictionary{
+		typ:         typ,
+		data:        data,
+		columnIndex: ^columnIndex,
+	}
+
+	for i := 0; i < len(data); d.numValues++ {
+		if (d.numValues % lazyDictionaryStride) == 0 {
+			d.offsets = append(d.offsets, uint32(i))
+		}
+		_, r, err := plain.NextByteArray(data[i:])
+		if err != nil {
+			return nil, err
+		}
+		i = len(data) - len(r)
+	}
+
+	return d, nil
+}
+
+func (d *lazyByteArrayDictionary) Type() Type { return newIndexedType(d.typ, d) }
+
+func (d *lazyByteArrayDictionary) Len() int {
+	if d.dict != nil {
+		return d.dict.Len()
+	}
+	return d.numValues
+}
+
+func (d *lazyByteArrayDictionary) Index(i int32) Value {
+	if d.dict != nil {
+		return d.dict.Index(i)
+	}
+	return d.makeValueBytes(d.index(int(i)))
+}
+
+func (d *lazyByteArrayDictionary) index(i int) []byte {
+	if i < 0 || i >= d.numValues {
+		panic("dictionary index out of bounds")
+	}
+	offset := int(d.offsets[i/lazyDictionaryStride])
+	for n := i % lazyDictionaryStride; n > 0; n-- {
+		offset += plain.ByteArrayLengthSize + plain.ByteArrayLength(d.data[offset:])
+	}
+	j := offset + plain.ByteArrayLengthSize
+	k := j + plain.ByteArrayLength(d.data[offset:])
+	return d.data[j:k:k]
+}
+
+// checkIndexes returns an error if any of the indexes is out of the bounds of
+// the dictionary.
+func (d *lazyByteArrayDictionary) checkIndexes(indexes []int32) error {
+	n := int32(d.Len())
+	for _, i := range indexes {
+		if i < 0 || i >= n {
+			return fmt.Errorf("dictionary index out of bounds: %d/%d", i, n)
+		}
+	}
+	return nil
+}
+
+func (d *lazyByteArrayDictionary) makeValueBytes(v []byte) Value {
+	value := makeValueBytes(ByteArray, v)
+	value.columnIndex = d.columnIndex
+	return value
+}
+
+func (d *lazyByteArrayDictionary) Insert(indexes []int32, values []Value) {
+	d.materialize().Insert(indexes, values)
+}
+
+func (d *lazyByteArrayDictionary) insert(indexes []int32, rows sparse.Array) {
+	d.materialize().insert(indexes, rows)
+}
+
+func (d *lazyByteArrayDictionary) Lookup(indexes []int32, values []Value) {
+	if d.dict != nil {
+		d.dict.Lookup(indexes, values)
+		return
+	}
+	if len(indexes) > len(values) {
+		panic("dictionary lookup with more indexes than values")
+	}
+	for i, j := range indexes {
+		values[i] = d.makeValueBytes(d.index(int(j)))
+	}
+}
+
+func (d *lazyByteArrayDictionary) Bounds(indexes []int32) (min, max Value) {
+	if d.dict != nil {
+		return d.dict.Bounds(indexes)
+	}
+	if len(indexes) > 0 {
+		minValue := d.index(int(indexes[0]))
+		maxValue := minValue
+
+		for _, i := range indexes[1:] {
+			value := d.index(int(i))
+			switch {
+			case string(value) < string(minValue):
+				minValue = value
+			case string(value) > string(maxValue):
+				maxValue = value
+			}
+		}
+
+		min = d.makeValueBytes(minValue)
+		max = d.makeValueBytes(maxValue)
+	}
+	return min, max
+}
+
+func (d *lazyByteArrayDictionary) Reset() {
+	if d.dict != nil {
+		d.dict.Reset()
+		return
+	}
+	d.dict = newByteArrayDictionary(d.typ, ^d.columnIndex, 0, encoding.ByteArrayValues(nil, nil))
+	d.data, d.offsets = nil, nil
+}
+
+func (d *lazyByteArrayDictionary) Page() Page {
+	return d.materialize().Page()
+}
+
+func (d *lazyByteArrayDictionary) materialize() *byteArrayDictionary {
+	if d.dict == nil {
+		values, offsets, _ := Plain.DecodeByteArray(nil, d.data, make([]uint32, 0, d.numValues+1))
+		d.dict = newByteArrayDictionary(d.typ, ^d.columnIndex, int32(d.numValues), encoding.ByteArrayValues(values, offsets))
+		d.data, d.offsets = nil, nil
+	}
+	return d.dict
+}
+
 type fixedLenByteArrayDictionary struct {
 	fixedLenByteArrayPage
 	hashmap map[string]int32
@@ -91,6 +91,15 @@ func isDictionaryFormat(encoding format.Encoding) bool {
 	return encoding == format.PlainDictionary || encoding == format.RLEDictionary
 }
 
+func hasDictionaryFormat(encodings []format.Encoding) bool {
+	for _, encoding := range encodings {
+		if isDictionaryFormat(encoding) {
+			return true
+		}
+	}
+	return false
+}
+
 // LookupEncoding returns the parquet encoding associated with the given code.
 //
 // The function never returns nil. If the encoding is not supported,
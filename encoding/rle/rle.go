@@ -88,6 +88,51 @@ func (e *Encoding) DecodeInt32(dst []int32, src []byte) ([]int32, error) {
 	return unsafecast.BytesToInt32(buf), e.wrap(err)
 }
 
+// Encode appends the hybrid RLE/Bit-Packed encoding of values to dst, using
+// the given bit width, and returns the extended buffer.
+//
+// The function is intended for programs which need the encoding primitive
+// outside of parquet pages (e.g. to write manifests of table formats), the
+// output has neither a length prefix nor a bit width header. The bit width
+// must be large enough to represent all the values, it can be computed from
+// the largest value with bits.Len32.
+func Encode(dst []byte, values []int32, bitWidth int) ([]byte, error) {
+	e := &Encoding{BitWidth: bitWidth}
+	if bitWidth < 0 {
+		return dst, e.wrap(errEncodeInvalidBitWidth("INT32", uint(bitWidth)))
+	}
+	if n := maxLenInt32(values); n > bitWidth {
+		return dst, e.wrap(fmt.Errorf("encoding values of %d bits with bit width %d", n, bitWidth))
+	}
+	dst, err := encodeInt32(dst, values, uint(bitWidth))
+	return dst, e.wrap(err)
+}
+
+// Decode appends count values decoded from the hybrid RLE/Bit-Packed encoding
+// in src to dst, using the given bit width, and returns the extended slice.
+//
+// The number of values must be known by the caller because bit-packed runs are
+// padded to multiples of 8 values; padding values that follow the first count
+// values are discarded. The function returns an error wrapping
+// io.ErrUnexpectedEOF if src holds fewer than count values.
+func Decode(dst []int32, src []byte, bitWidth, count int) ([]int32, error) {
+	e := &Encoding{BitWidth: bitWidth}
+	if bitWidth < 0 {
+		return dst, e.wrap(errDecodeInvalidBitWidth("INT32", uint(bitWidth)))
+	}
+	offset := len(dst)
+	buf := unsafecast.Int32ToBytes(dst)
+	buf, err := decodeInt32(buf, src, uint(bitWidth))
+	if err != nil {
+		return dst, e.wrap(err)
+	}
+	values := unsafecast.BytesToInt32(buf)
+	if n := len(values) - offset; n < count {
+		return dst, e.wrap(fmt.Errorf("decoding %d values: input contains only %d values: %w", count, n, io.ErrUnexpectedEOF))
+	}
+	return values[:offset+count], nil
+}
+
 func (e *Encoding) wrap(err error) error {
 	if err != nil {
 		err = encoding.Error(e, err)
@@ -358,6 +403,9 @@ func decodeInt32(dst, src []byte, bitWidth uint) ([]byte, error) {
 		if bitpacked {
 			offset := len(dst)
 			length := int(count * bitWidth)
+			if i+length > len(src) {
+				return dst, fmt.Errorf("decoding bit-packed block of %d values: %w", 8*count, io.ErrUnexpectedEOF)
+			}
 			dst = resize(dst, offset+4*8*int(count))
 
 			// The bitpack.UnpackInt32 function requires the input to be padded
@@ -1,6 +1,11 @@
 package rle
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/parquet-go/parquet-go/encoding/fuzz"
@@ -59,3 +64,73 @@ func benchmarkEncodeInt32IndexEqual8Contiguous(b *testing.B, f func([][8]int32)
 	}
 	b.SetBytes(32 * int64(len(words)))
 }
+
+func TestEncodeDecode(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+
+	for _, bitWidth := range []int{0, 1, 3, 8, 17, 32} {
+		for _, count := range []int{0, 1, 7, 8, 100, 1001} {
+			t.Run(fmt.Sprintf("bitWidth=%d,count=%d", bitWidth, count), func(t *testing.T) {
+				values := make([]int32, count)
+				for i := range values {
+					// Mix runs of repeated values with random values to
+					// exercise both the run-length and bit-packed blocks.
+					if i%50 < 20 {
+						values[i] = int32(uint32(prng.Uint64()) >> (32 - bitWidth))
+					} else if i > 0 {
+						values[i] = values[i-1]
+					}
+				}
+				if bitWidth == 0 {
+					values = make([]int32, count)
+				}
+
+				prefix := []byte("header")
+				encoded, err := Encode(prefix, values, bitWidth)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(encoded[:len(prefix)]) != string(prefix) {
+					t.Fatalf("prefix was overwritten: %q", encoded[:len(prefix)])
+				}
+
+				decoded, err := Decode([]int32{-1}, encoded[len(prefix):], bitWidth, count)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := append([]int32{-1}, values...); !reflect.DeepEqual(want, decoded) {
+					t.Fatalf("values mismatch:\nwant=%v\ngot =%v", want, decoded)
+				}
+
+				if _, err := Decode(nil, encoded[len(prefix):], bitWidth, count+9); !errors.Is(err, io.ErrUnexpectedEOF) {
+					t.Errorf("want=%v got=%v", io.ErrUnexpectedEOF, err)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	values := make([]int32, 64)
+	for i := range values {
+		values[i] = int32(i)
+	}
+	encoded, err := Encode(nil, values, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := range encoded {
+		if _, err := Decode(nil, encoded[:n], 6, len(values)); err == nil {
+			t.Fatalf("decoding %d/%d bytes: no error", n, len(encoded))
+		}
+	}
+}
+
+func TestEncodeInvalidBitWidth(t *testing.T) {
+	if _, err := Encode(nil, []int32{4}, 2); err == nil {
+		t.Error("encoding value larger than the bit width: no error")
+	}
+	if _, err := Encode(nil, []int32{1}, 33); err == nil {
+		t.Error("encoding with bit width larger than 32: no error")
+	}
+}
@@ -55,6 +55,41 @@ var (
 	// file with more than MaxRowGroups row groups.
 	ErrTooManyRowGroups = errors.New("the limit of 32767 row groups has been reached")
 
+	// ErrTimestampTruncated is returned by writers configured with strict
+	// timestamps when a time.Time value has a greater precision than the unit
+	// of the TIMESTAMP column it is written to.
+	ErrTimestampTruncated = errors.New("timestamp would be truncated to the precision of the column")
+
+	// ErrByteArrayTooLong is returned by writers configured with a maximum
+	// length of BYTE_ARRAY values when a value exceeds the limit.
+	ErrByteArrayTooLong = errors.New("byte array value exceeds the maximum length of the column")
+
+	// ErrNonFiniteFloat is returned by writers configured to reject NaN and
+	// infinite values when such a value is written to a FLOAT or DOUBLE column.
+	ErrNonFiniteFloat = errors.New("non-finite floating point value cannot be written to the column")
+
+	// ErrSchemaMismatch is returned by readers configured with a strict schema
+	// when the columns of the file differ from the columns of the schema that
+	// rows are read into.
+	ErrSchemaMismatch = errors.New("schema of rows does not match the schema of the file")
+
+	// ErrInvalidUTF8 is returned when reading files configured to reject
+	// invalid UTF-8 strings and a STRING column contains an invalid value.
+	ErrInvalidUTF8 = errors.New("invalid UTF-8 sequence in the value of a string column")
+
+	// ErrRowsNotSorted is returned by writers configured to enforce the order
+	// of their sorting columns when rows are written out of order.
+	ErrRowsNotSorted = errors.New("rows are not ordered by the sorting columns of the writer")
+
+	// ErrAccessDenied may be returned by the hooks installed with ColumnAccess
+	// to deny access to columns of a file.
+	ErrAccessDenied = errors.New("access to the parquet column is denied")
+
+	// ErrEncryptedFile is returned when opening a parquet file with an
+	// encrypted footer, which the package does not support reading or
+	// rewriting.
+	ErrEncryptedFile = errors.New("encrypted parquet files are not supported")
+
 	// ErrConversion is used to indicate that a conversion betwen two values
 	// cannot be done because there are no rules to translate between their
 	// physical types.
@@ -0,0 +1,191 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// ScanPlan describes the data that a reader reads to produce rows, as returned
+// by the Explain method of readers.
+//
+// Readers only read the columns of the schema that rows are read into, other
+// columns of the file are pruned and never read. Row groups that cannot match
+// the Filter option of readers are pruned as well.
+type ScanPlan struct {
+	// Row groups of the file, in the order of the file, including those that
+	// the reader skips.
+	RowGroups []RowGroupPlan
+	// Number of rows in the row groups that the reader reads.
+	NumRows int64
+	// Estimated number of bytes read from the file, which is the compressed
+	// size of the column chunks which are not pruned. Zero if the row groups
+	// were not read from a file.
+	EstimatedBytes int64
+}
+
+// RowGroupPlan describes how a reader reads a row group.
+type RowGroupPlan struct {
+	// Index of the row group in the file, or zero for readers constructed from
+	// a single row group.
+	Index int
+	// Number of rows in the row group.
+	NumRows int64
+	// Reports whether the row group is read.
+	Read bool
+	// Explains why the row group is not read, empty if Read is true.
+	PruneReason string
+	// Columns of the row group, nil if the row group is not read.
+	Columns []ColumnPlan
+	// Estimated number of bytes read from the row group.
+	EstimatedBytes int64
+}
+
+// ColumnPlan describes how a reader reads a column chunk.
+type ColumnPlan struct {
+	// Path of the column in the schema of the row group.
+	Path []string
+	// Reports whether the column chunk is read.
+	Read bool
+	// Explains why the column chunk is not read, empty if Read is true.
+	PruneReason string
+	// Number of pages in the column chunk, or -1 if the column chunk has no
+	// page index.
+	NumPages int
+	// Compressed size of the column chunk, including the page headers, or zero
+	// if the column chunk was not read from a file.
+	CompressedBytes int64
+}
+
+// Pruning reasons reported in RowGroupPlan.PruneReason and
+// ColumnPlan.PruneReason.
+const (
+	pruneNotInSchema = "not in read schema"
+	pruneStatistics  = "statistics do not match the filter"
+	pruneBloomFilter = "bloom filters do not match the filter"
+	prunePageIndex   = "page index does not match the filter"
+)
+
+// String returns a human readable representation of the plan.
+func (p ScanPlan) String() string {
+	s := new(strings.Builder)
+	p.print(s)
+	return s.String()
+}
+
+func (p ScanPlan) print(w io.Writer) {
+	fmt.Fprintf(w, "scan: %d row groups, %d rows, %d bytes\n", len(p.RowGroups), p.NumRows, p.EstimatedBytes)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, rowGroup := range p.RowGroups {
+		if !rowGroup.Read {
+			fmt.Fprintf(tw, "row group %d: %d rows, pruned (%s)\n", rowGroup.Index, rowGroup.NumRows, rowGroup.PruneReason)
+			continue
+		}
+		fmt.Fprintf(tw, "row group %d: %d rows, %d bytes\n", rowGroup.Index, rowGroup.NumRows, rowGroup.EstimatedBytes)
+		for _, column := range rowGroup.Columns {
+			pages := "?"
+			if column.NumPages >= 0 {
+				pages = fmt.Sprint(column.NumPages)
+			}
+			action := "read"
+			if !column.Read {
+				action = "pruned (" + column.PruneReason + ")"
+			}
+			fmt.Fprintf(tw, "  %s\t%s pages\t%d bytes\t%s\n", columnPath(column.Path), pages, column.CompressedBytes, action)
+		}
+	}
+	tw.Flush()
+}
+
+// scannedRowGroup is a row group considered by a reader, with its index in the
+// file and the reason why the reader skips it, which is empty if the row group
+// is read.
+type scannedRowGroup struct {
+	index       int
+	rowGroup    RowGroup
+	pruneReason string
+}
+
+// readRowGroups returns the row groups of scan which are not skipped.
+func readRowGroups(scan []scannedRowGroup) []RowGroup {
+	rowGroups := make([]RowGroup, 0, len(scan))
+	for _, s := range scan {
+		if s.pruneReason == "" {
+			rowGroups = append(rowGroups, s.rowGroup)
+		}
+	}
+	return rowGroups
+}
+
+// explainScan returns the plan of reading rows of the given schema from the
+// row groups of scan.
+func explainScan(schema *Schema, scan []scannedRowGroup) ScanPlan {
+	plan := ScanPlan{RowGroups: make([]RowGroupPlan, len(scan))}
+
+	for i, s := range scan {
+		rowGroup := s.rowGroup
+		if s.pruneReason != "" {
+			plan.RowGroups[i] = RowGroupPlan{
+				Index:       s.index,
+				NumRows:     rowGroup.NumRows(),
+				PruneReason: s.pruneReason,
+			}
+			continue
+		}
+
+		readColumns := make(map[int]bool)
+		rowGroupSchema := rowGroup.Schema()
+
+		if nodesAreEqual(schema, rowGroupSchema) {
+			for j := range rowGroup.ColumnChunks() {
+				readColumns[j] = true
+			}
+		} else if conv, err := Convert(schema, rowGroupSchema); err == nil {
+			for j := range schema.Columns() {
+				if k := conv.Column(j); k >= 0 {
+					readColumns[k] = true
+				}
+			}
+		}
+
+		columnPaths := rowGroupSchema.Columns()
+		columnChunks := rowGroup.ColumnChunks()
+		rowGroupPlan := RowGroupPlan{
+			Index:   s.index,
+			NumRows: rowGroup.NumRows(),
+			Read:    true,
+			Columns: make([]ColumnPlan, len(columnChunks)),
+		}
+
+		for j, columnChunk := range columnChunks {
+			column := ColumnPlan{
+				Read:     readColumns[j],
+				NumPages: -1,
+			}
+			if j < len(columnPaths) {
+				column.Path = columnPaths[j]
+			}
+			if !column.Read {
+				column.PruneReason = pruneNotInSchema
+			}
+			if offsetIndex := columnChunk.OffsetIndex(); offsetIndex != nil {
+				column.NumPages = offsetIndex.NumPages()
+			}
+			if c, ok := columnChunk.(*fileColumnChunk); ok && c.load() == nil {
+				column.CompressedBytes = c.chunk.MetaData.TotalCompressedSize
+			}
+			if column.Read {
+				rowGroupPlan.EstimatedBytes += column.CompressedBytes
+			}
+			rowGroupPlan.Columns[j] = column
+		}
+
+		plan.RowGroups[i] = rowGroupPlan
+		plan.NumRows += rowGroupPlan.NumRows
+		plan.EstimatedBytes += rowGroupPlan.EstimatedBytes
+	}
+
+	return plan
+}
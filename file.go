@@ -2,13 +2,18 @@ package parquet
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/parquet-go/parquet-go/format"
 	"github.com/segmentio/encoding/thrift"
@@ -32,6 +37,12 @@ type File struct {
 	offsetIndexes []format.OffsetIndex
 	rowGroups     []RowGroup
 	config        *FileConfig
+	stats         *readStats
+	closed        uint32
+
+	metadataWarnings     []Warning
+	metadataWarningsOnce sync.Once
+	readWarnings         warningSet
 }
 
 // OpenFile opens a parquet file and reads the content between offset 0 and the given
@@ -40,6 +51,21 @@ type File struct {
 // Only the parquet magic bytes and footer are read, column chunks and other
 // parts of the file are left untouched; this means that successfully opening
 // a file does not validate that the pages have valid checksums.
+//
+// Readers backed by network storage or devices with specific access patterns
+// may want to know which sections of the file are about to be read, to issue
+// vectored reads or prefetch data for example. The reader passed to OpenFile may
+// implement any of the following methods, which are called with the location
+// of sections of the file before they are read:
+//
+//	SetMagicFooterSection(offset, length int64)
+//	SetFooterSection(offset, length int64)
+//	SetColumnIndexSection(offset, length int64)
+//	SetOffsetIndexSection(offset, length int64)
+//	SetBloomFilterSection(offset, length int64)
+//	SetColumnChunkSection(offset, length int64)
+//
+// The size of reads made to r is controlled by the ReadBufferSize option.
 func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	b := make([]byte, 8)
 	c, err := NewFileConfig(options...)
@@ -47,42 +73,45 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 		return nil, err
 	}
 	f := &File{reader: r, size: size, config: c}
+	if c.CollectReadStats {
+		f.stats = new(readStats)
+	}
 
 	if _, err := readAt(r, b[:4], 0); err != nil {
 		return nil, fmt.Errorf("reading magic header of parquet file: %w", err)
 	}
+	if string(b[:4]) == "PARE" {
+		return nil, ErrEncryptedFile
+	}
 	if string(b[:4]) != "PAR1" {
 		return nil, fmt.Errorf("invalid magic header of parquet file: %q", b[:4])
 	}
 
-	if cast, ok := f.reader.(interface{ SetMagicFooterSection(offset, length int64) }); ok {
-		cast.SetMagicFooterSection(size-8, 8)
-	}
-	if n, err := r.ReadAt(b[:8], size-8); n != 8 {
-		return nil, fmt.Errorf("reading magic footer of parquet file: %w", err)
+	var footerData []byte
+	if c.FindLastFooter {
+		footerData, f.size, err = f.findLastFooter(size)
+	} else {
+		footerData, err = f.readFooter(size)
 	}
-	if string(b[4:8]) != "PAR1" {
-		return nil, fmt.Errorf("invalid magic footer of parquet file: %q", b[4:8])
+	if err != nil {
+		return nil, err
 	}
 
-	footerSize := int64(binary.LittleEndian.Uint32(b[:4]))
-	footerData := make([]byte, footerSize)
-
-	if cast, ok := f.reader.(interface{ SetFooterSection(offset, length int64) }); ok {
-		cast.SetFooterSection(size-(footerSize+8), footerSize)
-	}
-	if _, err := f.readAt(footerData, size-(footerSize+8)); err != nil {
-		return nil, fmt.Errorf("reading footer of parquet file: %w", err)
+	var lazyColumnChunks [][][]byte
+	if c.LazyColumnMetadata {
+		lazyColumnChunks, err = decodeFileMetaDataLazily(&f.protocol, footerData, &f.metadata)
+	} else {
+		err = thrift.Unmarshal(&f.protocol, footerData, &f.metadata)
 	}
-	if err := thrift.Unmarshal(&f.protocol, footerData, &f.metadata); err != nil {
+	if err != nil {
 		return nil, fmt.Errorf("reading parquet file metadata: %w", err)
 	}
 	if len(f.metadata.Schema) == 0 {
 		return nil, ErrMissingRootColumn
 	}
 
-	if !c.SkipPageIndex {
-		if f.columnIndexes, f.offsetIndexes, err = f.ReadPageIndex(); err != nil {
+	if !c.SkipPageIndex && !c.LazyColumnMetadata {
+		if f.columnIndexes, f.offsetIndexes, err = f.readPageIndex(); err != nil {
 			return nil, fmt.Errorf("reading page index of parquet file: %w", err)
 		}
 	}
@@ -100,6 +129,9 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	columns := make([]*Column, 0, numLeafColumnsOf(f.root))
 	f.schema = schema
 	f.root.forEachLeaf(func(c *Column) { columns = append(columns, c) })
+	if f.stats != nil {
+		f.stats.dictionaries = make([]dictionaryStats, len(columns))
+	}
 
 	rowGroups := make([]fileRowGroup, len(f.metadata.RowGroups))
 	for i := range rowGroups {
@@ -110,12 +142,20 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 		f.rowGroups[i] = &rowGroups[i]
 	}
 
-	if !c.SkipBloomFilters {
-		section := io.NewSectionReader(r, 0, size)
+	if c.LazyColumnMetadata {
+		for i := range rowGroups {
+			g := &rowGroups[i]
+			lazy := make([]lazyColumnChunk, len(g.columns))
+			for j := range g.columns {
+				lazy[j].data = lazyColumnChunks[i][j]
+				g.columns[j].(*fileColumnChunk).lazy = &lazy[j]
+			}
+		}
+	} else if !c.SkipBloomFilters {
+		section := io.NewSectionReader(r, 0, f.size)
 		rbuf, rbufpool := getBufioReader(section, c.ReadBufferSize)
 		defer putBufioReader(rbuf, rbufpool)
 
-		header := format.BloomFilterHeader{}
 		compact := thrift.CompactProtocol{}
 		decoder := thrift.NewDecoder(compact.NewReader(rbuf))
 
@@ -125,32 +165,170 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 			for j := range g.columns {
 				c := g.columns[j].(*fileColumnChunk)
 
-				if offset := c.chunk.MetaData.BloomFilterOffset; offset > 0 {
-					section.Seek(offset, io.SeekStart)
-					rbuf.Reset(section)
+				if err := c.readBloomFilter(section, rbuf, decoder); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
 
-					header = format.BloomFilterHeader{}
-					if err := decoder.Decode(&header); err != nil {
-						return nil, fmt.Errorf("decoding bloom filter header: %w", err)
-					}
+	sortKeyValueMetadata(f.metadata.KeyValueMetadata)
+	return f, nil
+}
 
-					offset, _ = section.Seek(0, io.SeekCurrent)
-					offset -= int64(rbuf.Buffered())
+// OpenFileSection opens a parquet file embedded in r, starting at the given
+// offset and spanning size bytes, for example in container formats or log
+// files which store parquet payloads next to other data.
+//
+// Offsets of the file, including those reported by File.ReadAt and by the
+// read hint methods documented on OpenFile, are relative to the beginning of
+// the parquet file. The read hints are forwarded to r after being translated
+// to offsets in r, when r implements them.
+func OpenFileSection(r io.ReaderAt, offset, size int64, options ...FileOption) (*File, error) {
+	if offset < 0 || size < 0 {
+		return nil, fmt.Errorf("invalid section of parquet file: offset=%d size=%d", offset, size)
+	}
+	return OpenFile(&fileSection{reader: r, offset: offset, size: size}, size, options...)
+}
 
-					if cast, ok := r.(interface{ SetBloomFilterSection(offset, length int64) }); ok {
-						bloomFilterOffset := c.chunk.MetaData.BloomFilterOffset
-						bloomFilterLength := (offset - bloomFilterOffset) + int64(header.NumBytes)
-						cast.SetBloomFilterSection(bloomFilterOffset, bloomFilterLength)
-					}
+// fileSection is the io.ReaderAt used to read files opened with
+// OpenFileSection. Unlike io.SectionReader, it forwards the read hints to the
+// underlying reader.
+type fileSection struct {
+	reader io.ReaderAt
+	offset int64
+	size   int64
+}
 
-					c.bloomFilter = newBloomFilter(r, offset, &header)
-				}
+func (s *fileSection) ReadAt(b []byte, off int64) (int, error) {
+	if off < 0 || off >= s.size {
+		return 0, io.EOF
+	}
+	if limit := s.size - off; int64(len(b)) > limit {
+		n, err := s.reader.ReadAt(b[:limit], s.offset+off)
+		if err == nil {
+			err = io.EOF
+		}
+		return n, err
+	}
+	return s.reader.ReadAt(b, s.offset+off)
+}
+
+func (s *fileSection) SetMagicFooterSection(offset, length int64) {
+	if r, ok := s.reader.(interface{ SetMagicFooterSection(offset, length int64) }); ok {
+		r.SetMagicFooterSection(s.offset+offset, length)
+	}
+}
+
+func (s *fileSection) SetFooterSection(offset, length int64) {
+	if r, ok := s.reader.(interface{ SetFooterSection(offset, length int64) }); ok {
+		r.SetFooterSection(s.offset+offset, length)
+	}
+}
+
+func (s *fileSection) SetColumnIndexSection(offset, length int64) {
+	if r, ok := s.reader.(interface{ SetColumnIndexSection(offset, length int64) }); ok {
+		r.SetColumnIndexSection(s.offset+offset, length)
+	}
+}
+
+func (s *fileSection) SetOffsetIndexSection(offset, length int64) {
+	if r, ok := s.reader.(interface{ SetOffsetIndexSection(offset, length int64) }); ok {
+		r.SetOffsetIndexSection(s.offset+offset, length)
+	}
+}
+
+func (s *fileSection) SetBloomFilterSection(offset, length int64) {
+	if r, ok := s.reader.(interface{ SetBloomFilterSection(offset, length int64) }); ok {
+		r.SetBloomFilterSection(s.offset+offset, length)
+	}
+}
+
+func (s *fileSection) SetColumnChunkSection(offset, length int64) {
+	if r, ok := s.reader.(interface{ SetColumnChunkSection(offset, length int64) }); ok {
+		r.SetColumnChunkSection(s.offset+offset, length)
+	}
+}
+
+// readFooter reads the footer of the parquet file ending at the given offset,
+// returning the thrift encoding of the file metadata.
+func (f *File) readFooter(end int64) ([]byte, error) {
+	b := make([]byte, 8)
+
+	if cast, ok := f.reader.(interface{ SetMagicFooterSection(offset, length int64) }); ok {
+		cast.SetMagicFooterSection(end-8, 8)
+	}
+	if n, err := f.reader.ReadAt(b[:8], end-8); n != 8 {
+		return nil, fmt.Errorf("reading magic footer of parquet file: %w", err)
+	}
+	if string(b[4:8]) == "PARE" {
+		return nil, ErrEncryptedFile
+	}
+	if string(b[4:8]) != "PAR1" {
+		return nil, fmt.Errorf("invalid magic footer of parquet file: %q", b[4:8])
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(b[:4]))
+	if footerSize > end-12 {
+		return nil, fmt.Errorf("invalid footer size of parquet file: %d", footerSize)
+	}
+	if footerSize > math.MaxInt {
+		return nil, fmt.Errorf("footer size of parquet file exceeds the addressable memory: %d", footerSize)
+	}
+	footerData := make([]byte, footerSize)
+
+	if cast, ok := f.reader.(interface{ SetFooterSection(offset, length int64) }); ok {
+		cast.SetFooterSection(end-(footerSize+8), footerSize)
+	}
+	if _, err := f.readAt(footerData, end-(footerSize+8)); err != nil {
+		return nil, fmt.Errorf("reading footer of parquet file: %w", err)
+	}
+	return footerData, nil
+}
+
+// findLastFooter searches the parquet file backward from the given offset for
+// the last footer which holds valid file metadata, returning the thrift
+// encoding of the metadata and the offset of the end of the footer.
+func (f *File) findLastFooter(end int64) ([]byte, int64, error) {
+	// The first footer magic can only start after the magic header and the
+	// length of the footer.
+	const minOffset = 8
+	magic := []byte("PAR1")
+	chunk := make([]byte, 64*1024)
+
+	for limit := end; limit-minOffset >= int64(len(magic)); {
+		start := limit - int64(len(chunk))
+		if start < minOffset {
+			start = minOffset
+		}
+		b := chunk[:limit-start]
+		if _, err := f.readAt(b, start); err != nil {
+			return nil, 0, fmt.Errorf("searching footer of parquet file: %w", err)
+		}
+
+		for i := len(b); i > 0; {
+			if i = bytes.LastIndex(b[:i], magic); i < 0 {
+				break
+			}
+			footerEnd := start + int64(i+len(magic))
+			footerData, err := f.readFooter(footerEnd)
+			if err != nil {
+				continue
 			}
+			metadata := new(format.FileMetaData)
+			if thrift.Unmarshal(&f.protocol, footerData, metadata) == nil && len(metadata.Schema) > 0 {
+				return footerData, footerEnd, nil
+			}
+		}
+
+		if start == minOffset {
+			break
 		}
+		// Overlap the chunks so magic numbers spanning two chunks are found.
+		limit = start + int64(len(magic)-1)
 	}
 
-	sortKeyValueMetadata(f.metadata.KeyValueMetadata)
-	return f, nil
+	return nil, 0, fmt.Errorf("no valid footer found in parquet file of size %d", end)
 }
 
 // ReadPageIndex reads the page index section of the parquet file f.
@@ -179,7 +357,29 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 // reading the page index section until after the file was opened. Note that in
 // this case the page index is not cached within the file, programs are expected
 // to make use of independently from the parquet package.
+//
+// When the file was opened with the ColumnAccess option, the column indexes of
+// columns that access is denied to are returned as zero values.
 func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, error) {
+	columnIndexes, offsetIndexes, err := f.readPageIndex()
+	if err != nil || f.config.ColumnAccess == nil || len(columnIndexes) == 0 {
+		return columnIndexes, offsetIndexes, err
+	}
+	for i, rowGroup := range f.rowGroups {
+		columns := rowGroup.(*fileRowGroup).columns
+		for j, column := range columns {
+			if column.(*fileColumnChunk).checkAccess() != nil {
+				columnIndexes[(i*len(columns))+j] = format.ColumnIndex{}
+			}
+		}
+	}
+	return columnIndexes, offsetIndexes, nil
+}
+
+func (f *File) readPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, error) {
+	if err := f.loadColumnChunks(); err != nil {
+		return nil, nil, err
+	}
 	if len(f.metadata.RowGroups) == 0 {
 		return nil, nil, nil
 	}
@@ -210,6 +410,11 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 	if columnIndexLength == 0 && offsetIndexLength == 0 {
 		return nil, nil, nil
 	}
+	// On 32 bits platforms, the page index may be larger than what can be
+	// loaded in memory at once.
+	if columnIndexLength > math.MaxInt || offsetIndexLength > math.MaxInt {
+		return nil, nil, fmt.Errorf("page index of %d+%d bytes exceeds the addressable memory", columnIndexLength, offsetIndexLength)
+	}
 
 	numRowGroups := len(f.metadata.RowGroups)
 	numColumns := len(f.metadata.RowGroups[0].Columns)
@@ -282,6 +487,10 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 func (f *File) NumRows() int64 { return f.metadata.NumRows }
 
 // RowGroups returns the list of row groups in the file.
+//
+// The row groups are ordered as they appear in the file metadata. The same
+// slice is returned on every call and the row groups may be read any number of
+// times, applications should treat the returned slice as read-only.
 func (f *File) RowGroups() []RowGroup { return f.rowGroups }
 
 // Root returns the root column of f.
@@ -291,7 +500,26 @@ func (f *File) Root() *Column { return f.root }
 func (f *File) Schema() *Schema { return f.schema }
 
 // Metadata returns the metadata of f.
-func (f *File) Metadata() *format.FileMetaData { return &f.metadata }
+//
+// When the file was opened with LazyColumnMetadata, the metadata of column
+// chunks which were not used yet are decoded by this method. Column chunks
+// with invalid metadata are left zero.
+func (f *File) Metadata() *format.FileMetaData {
+	f.loadColumnChunks()
+	return &f.metadata
+}
+
+// Stats returns the time spent in each stage of reading pages from f.
+//
+// The statistics are only collected when the file was opened with the
+// CollectReadStats option, the method returns a zero value otherwise.
+func (f *File) Stats() ReadStats { return f.stats.snapshot() }
+
+// DictionaryStats returns statistics of the dictionary encoding of the pages
+// read from each leaf column of the file, indexed by column index. Statistics
+// are only collected when the file was opened with CollectReadStats, the
+// method returns nil otherwise.
+func (f *File) DictionaryStats() []DictionaryStats { return f.stats.dictionarySnapshot() }
 
 // Size returns the size of f (in bytes).
 func (f *File) Size() int64 { return f.size }
@@ -315,16 +543,105 @@ func (f *File) ReadAt(b []byte, off int64) (int, error) {
 	return f.readAt(b, off)
 }
 
+// Close marks f as closed.
+//
+// After Close returns, reads of pages or rows from the file will fail with
+// io.ErrClosedPipe, including reads made by readers that were created prior to
+// closing the file. The underlying io.ReaderAt is not closed, it remains owned
+// by the program that passed it to OpenFile.
+//
+// Close does not release the page buffers held by readers of the file nor stop
+// the goroutines that they use to prefetch pages in ReadModeAsync; those are
+// released when the readers are closed.
+//
+// Calling Close multiple times is safe, calls after the first one are no-ops.
+func (f *File) Close() error {
+	atomic.StoreUint32(&f.closed, 1)
+	return nil
+}
+
+// RowsChan streams the rows of f to the returned channel, in the order that
+// they appear in the file.
+//
+// The buffer argument sets the capacity of the row channel, which bounds the
+// number of rows read ahead of the consumer; reads from the file are paused
+// while the channel is full. A zero or negative buffer creates an unbuffered
+// channel.
+//
+// Both channels are closed once all rows were produced, after an error
+// occurred, or after ctx was canceled. At most one error is sent to the error
+// channel, which is buffered so the producer never blocks on it; when the
+// context is canceled, the error is ctx.Err().
+//
+// Rows sent to the channel are owned by the receiver and remain valid after
+// subsequent rows were received.
+func (f *File) RowsChan(ctx context.Context, buffer int) (<-chan Row, <-chan error) {
+	if buffer < 0 {
+		buffer = 0
+	}
+	rows := make(chan Row, buffer)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(rows)
+
+		if err := f.sendRows(ctx, rows); err != nil {
+			errs <- err
+		}
+	}()
+
+	return rows, errs
+}
+
+func (f *File) sendRows(ctx context.Context, ch chan<- Row) error {
+	buf := make([]Row, defaultRowBufferSize)
+
+	for _, rowGroup := range f.rowGroups {
+		if err := func() error {
+			rows := rowGroup.Rows()
+			defer rows.Close()
+
+			for {
+				n, err := rows.ReadRows(buf)
+
+				for _, row := range buf[:n] {
+					select {
+					case ch <- row.Clone():
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+
+				if err != nil {
+					if err == io.EOF {
+						err = nil
+					}
+					return err
+				}
+			}
+		}(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ColumnIndexes returns the page index of the parquet file f.
 //
 // If the file did not contain a column index, the method returns an empty slice
-// and nil error.
+// and nil error. The method also returns an empty slice if the file was opened
+// with LazyColumnMetadata, in which case the column index of each column chunk
+// is read when the column chunk is first used.
 func (f *File) ColumnIndexes() []format.ColumnIndex { return f.columnIndexes }
 
 // OffsetIndexes returns the page index of the parquet file f.
 //
 // If the file did not contain an offset index, the method returns an empty
-// slice and nil error.
+// slice and nil error. The method also returns an empty slice if the file was
+// opened with LazyColumnMetadata, in which case the offset index of each column
+// chunk is read when the column chunk is first used.
 func (f *File) OffsetIndexes() []format.OffsetIndex { return f.offsetIndexes }
 
 // Lookup returns the value associated with the given key in the file key/value
@@ -339,7 +656,13 @@ func (f *File) hasIndexes() bool {
 	return f.columnIndexes != nil && f.offsetIndexes != nil
 }
 
-var _ io.ReaderAt = (*File)(nil)
+var (
+	_ io.ReaderAt = (*File)(nil)
+	_ io.Closer   = (*File)(nil)
+	_ Stats       = (*File)(nil)
+
+	_ RowGroupKeyValueMetadata = (*fileRowGroup)(nil)
+)
 
 func sortKeyValueMetadata(keyValueMetadata []format.KeyValue) {
 	sort.Slice(keyValueMetadata, func(i, j int) bool {
@@ -406,6 +729,119 @@ func (g *fileRowGroup) init(file *File, schema *Schema, columns []*Column, rowGr
 	}
 }
 
+// Lookup returns the value associated with the given key in the key/value
+// metadata of the row group, which is read from the key/value metadata of the
+// first column chunk, see RowGroupKeyValueMetadata.
+//
+// The ok boolean will be true if the key was found, false otherwise.
+func (g *fileRowGroup) Lookup(key string) (value string, ok bool) {
+	if len(g.columns) == 0 {
+		return "", false
+	}
+	c := g.columns[0].(*fileColumnChunk)
+	if err := c.load(); err != nil {
+		return "", false
+	}
+	// The metadata may not be sorted in files written by other libraries.
+	for _, kv := range c.chunk.MetaData.KeyValueMetadata {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// Load reads the column chunks of the row group in memory, and returns a row
+// group with the same schema and columns which serves their pages from memory.
+// Column chunks which are contiguous in the file are fetched with a single
+// sequential read, instead of the many small reads of pages made when reading
+// the row group; this is best for files in remote storage when the whole row
+// group will be consumed anyway. Row groups of files opened with OpenFile
+// expose the method, which can be accessed with a type assertion:
+//
+//	loader := rowGroup.(interface {
+//		Load(context.Context, ...string) (parquet.RowGroup, error)
+//	})
+//	rowGroup, err := loader.Load(ctx, "id", "name")
+//
+// When column names are passed, only the column chunks of the top-level fields
+// of the schema with those names are loaded, the pages of other columns are
+// still read from the file when needed. Column chunks that the ColumnAccess
+// hook denies access to are never loaded.
+//
+// The context is checked for cancellation before each read. The memory held
+// by the returned row group is released when it is garbage collected; reads of
+// its pages fail with io.ErrClosedPipe after the file was closed, like those of
+// other row groups of the file.
+func (g *fileRowGroup) Load(ctx context.Context, columns ...string) (RowGroup, error) {
+	selected := make(map[string]bool, len(columns))
+	for _, name := range columns {
+		if fieldByName(g.schema, name) == nil {
+			return nil, fmt.Errorf("cannot load column %q which does not exist in the row group", name)
+		}
+		selected[name] = true
+	}
+
+	type loadColumn struct {
+		index          int
+		chunk          *fileColumnChunk
+		offset, length int64
+	}
+	loads := make([]loadColumn, 0, len(g.columns))
+	for i, column := range g.columns {
+		c := column.(*fileColumnChunk)
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+		if len(selected) != 0 && !selected[c.column.Path()[0]] {
+			continue
+		}
+		if c.checkAccess() != nil {
+			continue
+		}
+		offset, length := c.section()
+		loads = append(loads, loadColumn{index: i, chunk: c, offset: offset, length: length})
+	}
+	sort.Slice(loads, func(i, j int) bool { return loads[i].offset < loads[j].offset })
+
+	loaded := *g
+	loaded.columns = make([]ColumnChunk, len(g.columns))
+	copy(loaded.columns, g.columns)
+
+	for i := 0; i < len(loads); {
+		start, end := loads[i].offset, loads[i].offset+loads[i].length
+		j := i + 1
+		for j < len(loads) && loads[j].offset == end {
+			end += loads[j].length
+			j++
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		file := loads[i].chunk.file
+		if cast, ok := file.reader.(interface{ SetColumnChunkSection(offset, length int64) }); ok {
+			for _, load := range loads[i:j] {
+				cast.SetColumnChunkSection(load.offset, load.length)
+			}
+		}
+		data := make([]byte, end-start)
+		if _, err := file.ReadAt(data, start); err != nil {
+			return nil, fmt.Errorf("loading column chunks of row group %d: %w", g.rowGroup.Ordinal, err)
+		}
+
+		for _, load := range loads[i:j] {
+			c := *load.chunk
+			offset := load.offset - start
+			c.data = data[offset : offset+load.length : offset+load.length]
+			loaded.columns[load.index] = &c
+		}
+		i = j
+	}
+
+	return &loaded, nil
+}
+
 func (g *fileRowGroup) Schema() *Schema                 { return g.schema }
 func (g *fileRowGroup) NumRows() int64                  { return g.rowGroup.NumRows }
 func (g *fileRowGroup) ColumnChunks() []ColumnChunk     { return g.columns }
@@ -444,6 +880,50 @@ type fileColumnChunk struct {
 	columnIndex *format.ColumnIndex
 	offsetIndex *format.OffsetIndex
 	chunk       *format.ColumnChunk
+	// Set when the metadata of the column chunk is decoded on first use.
+	lazy *lazyColumnChunk
+	// Content of the column chunk when it was loaded in memory by
+	// fileRowGroup.Load, nil if pages are read from the file.
+	data []byte
+}
+
+// section returns the location of the pages of the column chunk in the file.
+func (c *fileColumnChunk) section() (offset, length int64) {
+	offset = c.chunk.MetaData.DataPageOffset
+	if c.chunk.MetaData.DictionaryPageOffset != 0 {
+		offset = c.chunk.MetaData.DictionaryPageOffset
+	}
+	return offset, c.chunk.MetaData.TotalCompressedSize
+}
+
+// readBloomFilter reads the header of the bloom filter of the column chunk, if
+// it has one, using the buffered reader and decoder passed as arguments to read
+// from the section of the file.
+func (c *fileColumnChunk) readBloomFilter(section *io.SectionReader, rbuf *bufio.Reader, decoder *thrift.Decoder) error {
+	offset := c.chunk.MetaData.BloomFilterOffset
+	if offset <= 0 {
+		return nil
+	}
+
+	section.Seek(offset, io.SeekStart)
+	rbuf.Reset(section)
+
+	header := format.BloomFilterHeader{}
+	if err := decoder.Decode(&header); err != nil {
+		return fmt.Errorf("decoding bloom filter header: %w", err)
+	}
+
+	offset, _ = section.Seek(0, io.SeekCurrent)
+	offset -= int64(rbuf.Buffered())
+
+	if cast, ok := c.file.reader.(interface{ SetBloomFilterSection(offset, length int64) }); ok {
+		bloomFilterOffset := c.chunk.MetaData.BloomFilterOffset
+		bloomFilterLength := (offset - bloomFilterOffset) + int64(header.NumBytes)
+		cast.SetBloomFilterSection(bloomFilterOffset, bloomFilterLength)
+	}
+
+	c.bloomFilter = newBloomFilter(c.file.reader, offset, &header)
+	return nil
 }
 
 func (c *fileColumnChunk) Type() Type {
@@ -455,41 +935,101 @@ func (c *fileColumnChunk) Column() int {
 }
 
 func (c *fileColumnChunk) Pages() Pages {
+	if err := c.load(); err != nil {
+		return &errorPages{err: err}
+	}
+	if err := c.checkAccess(); err != nil {
+		return &errorPages{err: err}
+	}
 	r := new(filePages)
 	r.init(c)
 	return r
 }
 
+// checkAccess invokes the column access hook of the file, returning a non-nil
+// error if access to the column is denied.
+func (c *fileColumnChunk) checkAccess() error {
+	config := c.file.config
+	if config.ColumnAccess == nil {
+		return nil
+	}
+	ctx := config.AccessContext
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.ColumnAccess(ctx, c.column.Path()); err != nil {
+		return fmt.Errorf("opening column %s: %w", columnPath(c.column.Path()), err)
+	}
+	return nil
+}
+
 func (c *fileColumnChunk) ColumnIndex() ColumnIndex {
-	if c.columnIndex == nil {
+	if c.load(); c.columnIndex == nil || c.checkAccess() != nil {
 		return nil
 	}
 	return fileColumnIndex{c}
 }
 
 func (c *fileColumnChunk) OffsetIndex() OffsetIndex {
-	if c.offsetIndex == nil {
+	if c.load(); c.offsetIndex == nil {
 		return nil
 	}
 	return (*fileOffsetIndex)(c.offsetIndex)
 }
 
 func (c *fileColumnChunk) BloomFilter() BloomFilter {
-	if c.bloomFilter == nil {
+	if c.load(); c.bloomFilter == nil || c.checkAccess() != nil {
 		return nil
 	}
 	return c.bloomFilter
 }
 
 func (c *fileColumnChunk) NumValues() int64 {
+	c.load()
 	return c.chunk.MetaData.NumValues
 }
 
+// Dictionary reads and decodes the dictionary page of the column chunk,
+// returning nil if the chunk is not dictionary encoded.
+//
+// Data pages of dictionary encoded column chunks hold indexes into the
+// dictionary, programs can keep values dictionary encoded until they need to
+// be materialized by combining the dictionary with DictionaryIndexes. Column
+// chunks of files opened with OpenFile expose the method, which can be
+// accessed with a type assertion:
+//
+//	chunk := rowGroup.ColumnChunks()[0].(interface {
+//		Dictionary() (parquet.Dictionary, error)
+//	})
+//	dict, err := chunk.Dictionary()
+//
+// The dictionary is decoded on each call, programs are expected to retain it
+// for as long as they need it.
+func (c *fileColumnChunk) Dictionary() (Dictionary, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	if err := c.checkAccess(); err != nil {
+		return nil, err
+	}
+	if !hasDictionaryFormat(c.chunk.MetaData.Encoding) {
+		return nil, nil
+	}
+	pages := new(filePages)
+	pages.init(c)
+	defer pages.Close()
+	if err := pages.readDictionary(); err != nil {
+		return nil, fmt.Errorf("reading dictionary of column %q: %w", pages.columnPath(), err)
+	}
+	return pages.dictionary, nil
+}
+
 type filePages struct {
 	chunk    *fileColumnChunk
 	rbuf     *bufio.Reader
 	rbufpool *sync.Pool
 	section  io.SectionReader
+	timed    timedReader
 
 	protocol thrift.CompactProtocol
 	decoder  thrift.Decoder
@@ -500,12 +1040,17 @@ type filePages struct {
 	index      int
 	skip       int64
 	dictionary Dictionary
+	// Dictionary entries referenced by the pages read, only tracked when
+	// statistics are collected.
+	indexes bitmap
 
 	bufferSize int
+	transform  PageTransform
 }
 
 func (f *filePages) init(c *fileColumnChunk) {
 	f.chunk = c
+	f.transform = searchPageTransform(c.file.config.PageTransforms, c.column.Path())
 	f.baseOffset = c.chunk.MetaData.DataPageOffset
 	f.dataOffset = f.baseOffset
 	f.bufferSize = c.file.config.ReadBufferSize
@@ -515,11 +1060,43 @@ func (f *filePages) init(c *fileColumnChunk) {
 		f.dictOffset = f.baseOffset
 	}
 
-	f.section = *io.NewSectionReader(c.file, f.baseOffset, c.chunk.MetaData.TotalCompressedSize)
-	f.rbuf, f.rbufpool = getBufioReader(&f.section, f.bufferSize)
+	if c.data != nil {
+		f.section = *io.NewSectionReader(&loadedColumnChunk{file: c.file, data: c.data}, 0, int64(len(c.data)))
+	} else {
+		if cast, ok := c.file.reader.(interface{ SetColumnChunkSection(offset, length int64) }); ok {
+			cast.SetColumnChunkSection(f.baseOffset, c.chunk.MetaData.TotalCompressedSize)
+		}
+		f.section = *io.NewSectionReader(c.file, f.baseOffset, c.chunk.MetaData.TotalCompressedSize)
+	}
+	f.rbuf, f.rbufpool = getBufioReader(f.source(), f.bufferSize)
 	f.decoder.Reset(f.protocol.NewReader(f.rbuf))
 }
 
+// source returns the reader that pages are read from, which measures the time
+// spent reading from the file when statistics are collected.
+func (f *filePages) source() io.Reader {
+	if stats := f.chunk.file.stats; stats != nil {
+		f.timed = timedReader{reader: &f.section, stats: stats}
+		return &f.timed
+	}
+	return &f.section
+}
+
+func (f *filePages) decodeHeader(header *format.PageHeader) error {
+	stats := f.chunk.file.stats
+	if stats == nil {
+		return f.decoder.Decode(header)
+	}
+	// The time spent reading from the file while decoding the header is
+	// accounted for by the timed reader, it is excluded from the time spent
+	// parsing the header.
+	start, elapsed := time.Now(), f.timed.elapsed
+	err := f.decoder.Decode(header)
+	stats.add(readStageHeaderParse, time.Since(start)-(f.timed.elapsed-elapsed))
+	stats.addPage()
+	return err
+}
+
 func (f *filePages) ReadPage() (Page, error) {
 	if f.chunk == nil {
 		return nil, io.EOF
@@ -539,7 +1116,7 @@ func (f *filePages) ReadPage() (Page, error) {
 		// issues.
 		// https://github.com/parquet-go/parquet-go/issues/70
 		header := new(format.PageHeader)
-		if err := f.decoder.Decode(header); err != nil {
+		if err := f.decodeHeader(header); err != nil {
 			return nil, err
 		}
 		data, err := f.readPage(header, f.rbuf)
@@ -572,6 +1149,7 @@ func (f *filePages) ReadPage() (Page, error) {
 			continue
 		}
 
+		f.recordDictionaryStats(page)
 		f.index++
 		if f.skip == 0 {
 			return page, nil
@@ -595,7 +1173,11 @@ func (f *filePages) ReadPage() (Page, error) {
 }
 
 func (f *filePages) readDictionary() error {
-	chunk := io.NewSectionReader(f.chunk.file, f.baseOffset, f.chunk.chunk.MetaData.TotalCompressedSize)
+	var chunk io.Reader = io.NewSectionReader(f.chunk.file, f.baseOffset, f.chunk.chunk.MetaData.TotalCompressedSize)
+	if stats := f.chunk.file.stats; stats != nil {
+		chunk = &timedReader{reader: chunk, stats: stats}
+		stats.addPage()
+	}
 	rbuf, pool := getBufioReader(chunk, f.bufferSize)
 	defer putBufioReader(rbuf, pool)
 
@@ -607,13 +1189,22 @@ func (f *filePages) readDictionary() error {
 		return err
 	}
 
-	page := buffers.get(int(header.CompressedPageSize))
-	defer page.unref()
+	page := f.chunk.column.pagePool().get(int(header.CompressedPageSize))
+	defer func() { page.unref() }()
 
 	if _, err := io.ReadFull(rbuf, page.data); err != nil {
 		return err
 	}
 
+	if f.transform != nil {
+		decoded, err := decodePage(f.chunk.column.pagePool(), f.transform, page)
+		if err != nil {
+			return fmt.Errorf("decoding transformed dictionary page of column %q: %w", f.columnPath(), err)
+		}
+		page.unref()
+		page = decoded
+	}
+
 	return f.readDictionaryPage(header, page)
 }
 
@@ -621,14 +1212,58 @@ func (f *filePages) readDictionaryPage(header *format.PageHeader, page *buffer)
 	if header.DictionaryPageHeader == nil {
 		return ErrMissingPageHeader
 	}
-	d, err := f.chunk.column.decodeDictionary(DictionaryPageHeader{header.DictionaryPageHeader}, page, header.UncompressedPageSize)
+	var d Dictionary
+	var err error
+	dictHeader := DictionaryPageHeader{header.DictionaryPageHeader}
+
+	if limit := f.chunk.file.config.LazyDictionarySize; limit > 0 && int(header.UncompressedPageSize) > limit {
+		d, err = f.chunk.column.decodeLazyDictionary(dictHeader, page, header.UncompressedPageSize)
+	} else {
+		d, err = f.chunk.column.decodeDictionary(dictHeader, page, header.UncompressedPageSize)
+	}
 	if err != nil {
 		return err
 	}
 	f.dictionary = d
+	if stats := f.chunk.file.stats; stats != nil {
+		columnIndex := int(f.chunk.column.index)
+		stats.addDictionary(columnIndex, dictionaryPages, 1)
+		stats.addDictionary(columnIndex, dictionaryEntries, int64(d.Len()))
+		f.indexes.reset(d.Len())
+	}
 	return nil
 }
 
+// recordDictionaryStats records the dictionary statistics of a data page read
+// from the column chunk, when statistics are collected.
+func (f *filePages) recordDictionaryStats(page Page) {
+	stats := f.chunk.file.stats
+	if stats == nil || f.dictionary == nil {
+		return
+	}
+	columnIndex := int(f.chunk.column.index)
+	numValues := page.NumValues() - page.NumNulls()
+
+	if page.Dictionary() == nil {
+		stats.addDictionary(columnIndex, fallbackPages, 1)
+		stats.addDictionary(columnIndex, fallbackValues, numValues)
+		return
+	}
+
+	stats.addDictionary(columnIndex, indexedPages, 1)
+	stats.addDictionary(columnIndex, indexedValues, numValues)
+
+	distinct, bits := int64(0), f.indexes.bits
+	data := page.Data()
+	for _, i := range data.Int32() {
+		if x, bit := uint(i)/64, uint64(1)<<(uint(i)%64); x < uint(len(bits)) && bits[x]&bit == 0 {
+			bits[x] |= bit
+			distinct++
+		}
+	}
+	stats.addDictionary(columnIndex, distinctIndexes, distinct)
+}
+
 func (f *filePages) readDataPageV1(header *format.PageHeader, page *buffer) (Page, error) {
 	if header.DataPageHeader == nil {
 		return nil, ErrMissingPageHeader
@@ -657,7 +1292,7 @@ func (f *filePages) readDataPageV2(header *format.PageHeader, page *buffer) (Pag
 }
 
 func (f *filePages) readPage(header *format.PageHeader, reader *bufio.Reader) (*buffer, error) {
-	page := buffers.get(int(header.CompressedPageSize))
+	page := f.chunk.column.pagePool().get(int(header.CompressedPageSize))
 	defer page.unref()
 
 	if _, err := io.ReadFull(reader, page.data); err != nil {
@@ -686,6 +1321,14 @@ func (f *filePages) readPage(header *format.PageHeader, reader *bufio.Reader) (*
 		}
 	}
 
+	if f.transform != nil {
+		decoded, err := decodePage(f.chunk.column.pagePool(), f.transform, page)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transformed page of column %q: %w", f.columnPath(), err)
+		}
+		return decoded, nil
+	}
+
 	page.ref()
 	return page, nil
 }
@@ -713,7 +1356,7 @@ func (f *filePages) SeekToRow(rowIndex int64) (err error) {
 		f.skip = rowIndex - pages[index].FirstRowIndex
 		f.index = index
 	}
-	f.rbuf.Reset(&f.section)
+	f.rbuf.Reset(f.source())
 	return err
 }
 
@@ -721,6 +1364,7 @@ func (f *filePages) Close() error {
 	putBufioReader(f.rbuf, f.rbufpool)
 	f.chunk = nil
 	f.section = io.SectionReader{}
+	f.timed = timedReader{}
 	f.rbuf = nil
 	f.rbufpool = nil
 	f.baseOffset = 0
@@ -775,9 +1419,33 @@ func getBufioReaderPool(size int) *sync.Pool {
 }
 
 func (f *File) readAt(p []byte, off int64) (int, error) {
+	if atomic.LoadUint32(&f.closed) != 0 {
+		return 0, io.ErrClosedPipe
+	}
 	return readAt(f.reader, p, off)
 }
 
+// loadedColumnChunk is the io.ReaderAt of column chunks loaded in memory,
+// which fails once the file is closed.
+type loadedColumnChunk struct {
+	file *File
+	data []byte
+}
+
+func (c *loadedColumnChunk) ReadAt(b []byte, off int64) (int, error) {
+	if atomic.LoadUint32(&c.file.closed) != 0 {
+		return 0, io.ErrClosedPipe
+	}
+	if off < 0 || off >= int64(len(c.data)) {
+		return 0, io.EOF
+	}
+	n := copy(b, c.data[off:])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
 func readAt(r io.ReaderAt, p []byte, off int64) (n int, err error) {
 	n, err = r.ReadAt(p, off)
 	if n == len(p) {
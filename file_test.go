@@ -1,13 +1,26 @@
 package parquet_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
 )
 
 var testdataFiles []string
@@ -48,6 +61,10 @@ func TestOpenFile(t *testing.T) {
 			t.Log(b)
 
 			printColumns(t, p.Root(), "")
+
+			for _, w := range p.Warnings() {
+				t.Log(w)
+			}
 		})
 	}
 }
@@ -145,3 +162,1142 @@ func TestFileKeyValueMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestOpenEncryptedFile(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, []struct{ ID int64 }{{ID: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+	copy(data, "PARE")
+	copy(data[len(data)-4:], "PARE")
+
+	_, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if !errors.Is(err, parquet.ErrEncryptedFile) {
+		t.Errorf("error mismatch: want=%v got=%v", parquet.ErrEncryptedFile, err)
+	}
+}
+
+func TestOpenEncryptedFooterFile(t *testing.T) {
+	// Files with an encrypted footer start and end with the "PARE" magic, and
+	// their footer holds the plaintext FileCryptoMetaData followed by the
+	// encrypted FileMetaData, made of a length, a nonce, the ciphertext, and a
+	// tag.
+	cryptoMetaData, err := thrift.Marshal(new(thrift.CompactProtocol), &format.FileCryptoMetaData{
+		EncryptionAlgorithm: format.EncryptionAlgorithm{
+			AesGcmV1: &format.AesGcmV1{AadFileUnique: []byte("file-unique")},
+		},
+		KeyMetadata: []byte("footer-key"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prng := rand.New(rand.NewSource(0))
+	columnData := make([]byte, 128)
+	prng.Read(columnData)
+	encryptedFooter := make([]byte, 4+12+64+16)
+	prng.Read(encryptedFooter)
+	binary.LittleEndian.PutUint32(encryptedFooter, uint32(len(encryptedFooter)-4))
+
+	footer := append(cryptoMetaData, encryptedFooter...)
+	data := []byte("PARE")
+	data = append(data, columnData...)
+	data = append(data, footer...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(footer)))
+	data = append(data, "PARE"...)
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.FileOption
+	}{
+		{scenario: "default"},
+		{scenario: "find last footer", options: []parquet.FileOption{parquet.FindLastFooter(true)}},
+		{scenario: "lazy column metadata", options: []parquet.FileOption{parquet.LazyColumnMetadata(true)}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), test.options...)
+			if !errors.Is(err, parquet.ErrEncryptedFile) {
+				t.Errorf("error mismatch: want=%v got=%v", parquet.ErrEncryptedFile, err)
+			}
+		})
+	}
+}
+
+func TestOpenFileFindLastFooter(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := []Row{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}}
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	size := int64(buffer.Len())
+
+	for _, test := range []struct {
+		scenario string
+		trailer  []byte
+	}{
+		{scenario: "garbage", trailer: []byte("garbage")},
+		{scenario: "fake footer", trailer: []byte("\xff\xff\xff\x00PAR1\x10\x00\x00\x00PAR1")},
+		{scenario: "large trailer", trailer: bytes.Repeat([]byte("PAR1"), 50000)},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			data := append(buffer.Bytes()[:size:size], test.trailer...)
+
+			if _, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data))); err == nil {
+				t.Error("opening a file with trailing data must fail by default")
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), parquet.FindLastFooter(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Size() != size {
+				t.Errorf("file size mismatch: want=%d got=%d", size, f.Size())
+			}
+
+			values := make([]Row, len(rows)+1)
+			reader := parquet.NewGenericReader[Row](f)
+			defer reader.Close()
+			n, err := reader.Read(values)
+			if err != io.EOF {
+				t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+			}
+			if !reflect.DeepEqual(values[:n], rows) {
+				t.Errorf("rows mismatch: want=%+v got=%+v", rows, values[:n])
+			}
+		})
+	}
+
+	if _, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()[:size-1]), size-1, parquet.FindLastFooter(true)); err == nil {
+		t.Error("opening a file without a valid footer must fail")
+	}
+}
+
+type sectionRecorder struct {
+	io.ReaderAt
+	columnChunks [][2]int64
+}
+
+func (r *sectionRecorder) SetColumnChunkSection(offset, length int64) {
+	r.columnChunks = append(r.columnChunks, [2]int64{offset, length})
+}
+
+func TestFileColumnChunkSectionHint(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, []Row{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &sectionRecorder{ReaderAt: bytes.NewReader(buffer.Bytes())}
+	f, err := parquet.OpenFile(r, int64(buffer.Len()), parquet.ReadBufferSize(64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.columnChunks) != 0 {
+		t.Fatalf("column chunk sections reported before reading pages: %v", r.columnChunks)
+	}
+
+	if _, err := parquet.NewGenericReader[Row](f).Read(make([]Row, 2)); err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+
+	var want [][2]int64
+	for _, column := range f.Metadata().RowGroups[0].Columns {
+		offset := column.MetaData.DataPageOffset
+		if column.MetaData.DictionaryPageOffset != 0 {
+			offset = column.MetaData.DictionaryPageOffset
+		}
+		want = append(want, [2]int64{offset, column.MetaData.TotalCompressedSize})
+	}
+	if !reflect.DeepEqual(r.columnChunks, want) {
+		t.Errorf("column chunk sections mismatch: want=%v got=%v", want, r.columnChunks)
+	}
+}
+
+type readCounter struct {
+	io.ReaderAt
+	reads int
+}
+
+func (r *readCounter) ReadAt(b []byte, off int64) (int, error) {
+	r.reads++
+	return r.ReaderAt.ReadAt(b, off)
+}
+
+func TestFileRowGroupLoad(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name string   `parquet:"name,dict"`
+		Tags []string `parquet:"tags,list"`
+	}
+	type loader interface {
+		Load(context.Context, ...string) (parquet.RowGroup, error)
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i%10), Tags: []string{"a", "b", "c"}[:i%4]}
+	}
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256), parquet.MaxRowsPerRowGroup(500)); err != nil {
+		t.Fatal(err)
+	}
+
+	openFile := func(t *testing.T) (*parquet.File, *readCounter) {
+		r := &readCounter{ReaderAt: bytes.NewReader(buffer.Bytes())}
+		f, err := parquet.OpenFile(r, int64(buffer.Len()), parquet.ReadBufferSize(64))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.reads = 0
+		return f, r
+	}
+	readRows := func(t *testing.T, rowGroup parquet.RowGroup) []Row {
+		reader := parquet.NewGenericRowGroupReader[Row](rowGroup)
+		defer reader.Close()
+		values := make([]Row, rowGroup.NumRows())
+		n, err := reader.Read(values)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		return values[:n]
+	}
+
+	t.Run("all columns", func(t *testing.T) {
+		f, r := openFile(t)
+		rowGroup, err := f.RowGroups()[1].(loader).Load(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.reads != 1 {
+			t.Errorf("column chunks must be loaded with a single read: got %d reads", r.reads)
+		}
+		if values := readRows(t, rowGroup); !reflect.DeepEqual(values, rows[500:]) {
+			t.Error("rows of the loaded row group mismatch")
+		}
+		if r.reads != 1 {
+			t.Errorf("pages of loaded row groups must be read from memory: got %d reads", r.reads)
+		}
+
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		pages := rowGroup.ColumnChunks()[0].Pages()
+		defer pages.Close()
+		if _, err := pages.ReadPage(); !errors.Is(err, io.ErrClosedPipe) {
+			t.Errorf("reading pages of a loaded row group after closing the file: want=%v got=%v", io.ErrClosedPipe, err)
+		}
+	})
+
+	t.Run("selected columns", func(t *testing.T) {
+		f, r := openFile(t)
+		rowGroup, err := f.RowGroups()[0].(loader).Load(context.Background(), "tags")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.reads != 1 {
+			t.Errorf("column chunks must be loaded with a single read: got %d reads", r.reads)
+		}
+		if values := readRows(t, rowGroup); !reflect.DeepEqual(values, rows[:500]) {
+			t.Error("rows of the loaded row group mismatch")
+		}
+		if r.reads == 1 {
+			t.Error("pages of columns which were not loaded must be read from the file")
+		}
+
+		if _, err := f.RowGroups()[0].(loader).Load(context.Background(), "missing"); err == nil {
+			t.Error("loading a column which does not exist must fail")
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		f, r := openFile(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := f.RowGroups()[0].(loader).Load(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("loading a row group with a canceled context: want=%v got=%v", context.Canceled, err)
+		}
+		if r.reads != 0 {
+			t.Errorf("canceled loads must not read from the file: got %d reads", r.reads)
+		}
+	})
+}
+
+func TestOpenFileSection(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := []Row{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}}
+
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("header of the container")
+	offset := int64(buffer.Len())
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	size := int64(buffer.Len()) - offset
+	buffer.WriteString("trailer of the container")
+
+	r := &sectionRecorder{ReaderAt: bytes.NewReader(buffer.Bytes())}
+	f, err := parquet.OpenFileSection(r, offset, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Size() != size {
+		t.Errorf("file size mismatch: want=%d got=%d", size, f.Size())
+	}
+
+	values := make([]Row, len(rows)+1)
+	n, err := parquet.NewGenericReader[Row](f).Read(values)
+	if err != io.EOF {
+		t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+	}
+	if !reflect.DeepEqual(values[:n], rows) {
+		t.Errorf("rows mismatch: want=%+v got=%+v", rows, values[:n])
+	}
+
+	for _, section := range r.columnChunks {
+		if section[0] < offset || section[0]+section[1] > offset+size {
+			t.Errorf("column chunk section out of the parquet file: %v", section)
+		}
+	}
+	if len(r.columnChunks) == 0 {
+		t.Error("no column chunk sections were reported")
+	}
+
+	if _, err := parquet.OpenFileSection(r, 0, size); err == nil {
+		t.Error("opening a section which does not start with a parquet file must fail")
+	}
+}
+
+func TestFileColumnAccess(t *testing.T) {
+	type Row struct {
+		Name   string `parquet:"name"`
+		Secret string `parquet:"secret"`
+	}
+	type Projection struct {
+		Name string `parquet:"name"`
+	}
+	type tenantKey struct{}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, []Row{{Name: "a", Secret: "x"}, {Name: "b", Secret: "y"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var opened []string
+	check := func(ctx context.Context, path []string) error {
+		opened = append(opened, strings.Join(path, "."))
+		if ctx.Value(tenantKey{}) != "admin" && strings.Join(path, ".") == "secret" {
+			return parquet.ErrAccessDenied
+		}
+		return nil
+	}
+
+	open := func(tenant string) *parquet.File {
+		ctx := context.WithValue(context.Background(), tenantKey{}, tenant)
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.ColumnAccess(ctx, check))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	f := open("guest")
+	rows, err := parquet.NewGenericReader[Projection](f).Read(make([]Projection, 2))
+	if err != nil && !errors.Is(err, io.EOF) {
+		t.Fatal(err)
+	}
+	if rows != 2 {
+		t.Errorf("want=2 got=%d", rows)
+	}
+	if want := []string{"name"}; !reflect.DeepEqual(opened, want) {
+		t.Errorf("want=%q got=%q", want, opened)
+	}
+
+	_, err = parquet.NewGenericReader[Row](f).Read(make([]Row, 2))
+	if !errors.Is(err, parquet.ErrAccessDenied) {
+		t.Errorf("want=%v got=%v", parquet.ErrAccessDenied, err)
+	}
+	if columnIndex := f.RowGroups()[0].ColumnChunks()[1].ColumnIndex(); columnIndex != nil {
+		t.Error("column index of denied column is exposed")
+	}
+	if _, err := f.Root().Column("secret").Pages().ReadPage(); !errors.Is(err, parquet.ErrAccessDenied) {
+		t.Errorf("reading pages of denied column: want=%v got=%v", parquet.ErrAccessDenied, err)
+	}
+	if _, err := f.Root().Column("name").Pages().ReadPage(); err != nil {
+		t.Errorf("reading pages of allowed column: %v", err)
+	}
+	columnIndexes, _, err := f.ReadPageIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columnIndexes[0].NullPages) == 0 {
+		t.Error("column index of allowed column is missing from the page index")
+	}
+	if len(columnIndexes[1].NullPages) != 0 {
+		t.Error("column index of denied column is exposed by the page index")
+	}
+
+	f = open("admin")
+	got := make([]Row, 2)
+	if _, err := parquet.NewGenericReader[Row](f).Read(got); err != nil && !errors.Is(err, io.EOF) {
+		t.Fatal(err)
+	}
+	if got[1].Secret != "y" {
+		t.Errorf("want=%q got=%q", "y", got[1].Secret)
+	}
+}
+
+func TestFileClose(t *testing.T) {
+	type Row struct {
+		Name string
+	}
+
+	f, err := createParquetFile(makeRows([]Row{{Name: "A"}, {Name: "B"}, {Name: "C"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+	defer pages.Close()
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal("closing the file twice must not fail:", err)
+	}
+	if _, err := pages.ReadPage(); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("reading pages of a closed file: want=%v got=%v", io.ErrClosedPipe, err)
+	}
+	if f.NumRows() != 3 {
+		t.Errorf("metadata must remain available after closing the file: want=3 rows got=%d", f.NumRows())
+	}
+}
+
+func TestFileCloseReaders(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("row-%d", i)}
+	}
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+
+	numGoroutines := runtime.NumGoroutine()
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()),
+		parquet.FileReadMode(parquet.ReadModeAsync),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := parquet.NewGenericReader[Row](f)
+
+	if n, err := reader.Read(make([]Row, 10)); err != nil {
+		t.Fatalf("reading rows before closing the file: n=%d err=%v", n, err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pages prefetched before the file was closed may still be returned, but
+	// the reader must not reach the end of the file.
+	for {
+		_, err := reader.Read(make([]Row, 10))
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, io.ErrClosedPipe) {
+			t.Errorf("reading rows after closing the file: want=%v got=%v", io.ErrClosedPipe, err)
+		}
+		break
+	}
+
+	if err := reader.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing the reader stops the goroutines that were prefetching pages.
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > numGoroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines leaked after closing the reader: want<=%d got=%d", numGoroutines, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFileRowsChan(t *testing.T) {
+	type Row struct {
+		ID   int64
+		Name string
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("row-%d", i)}
+	}
+
+	f, err := createParquetFile(makeRows(rows), parquet.MaxRowsPerRowGroup(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 10 {
+		t.Fatalf("number of row groups mismatch: want=10 got=%d", n)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		ch, errs := f.RowsChan(context.Background(), 10)
+		received := make([]parquet.Row, 0, len(rows))
+		for row := range ch {
+			received = append(received, row)
+		}
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+		if len(received) != len(rows) {
+			t.Fatalf("number of rows mismatch: want=%d got=%d", len(rows), len(received))
+		}
+		for i, row := range received {
+			if id, name := row[0].Int64(), row[1].String(); id != rows[i].ID || name != rows[i].Name {
+				t.Fatalf("row %d mismatch: want=%+v got=%v", i, rows[i], row)
+			}
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch, errs := f.RowsChan(ctx, 0)
+
+		numRows := 0
+		for range ch {
+			if numRows++; numRows == 150 {
+				cancel()
+			}
+		}
+		if err := <-errs; !errors.Is(err, context.Canceled) {
+			t.Errorf("error mismatch: want=%v got=%v", context.Canceled, err)
+		}
+		if numRows >= len(rows) {
+			t.Errorf("rows must stop being produced after the context was canceled: got=%d", numRows)
+		}
+	})
+}
+
+func TestFileLazyDictionarySize(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name,dict,zstd"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{Name: fmt.Sprintf("name-%d", (i*7)%300)}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{0, 1, 1 << 20} {
+		t.Run(fmt.Sprintf("size=%d", size), func(t *testing.T) {
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.LazyDictionarySize(size))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reader := parquet.NewGenericReader[Row](f)
+			defer reader.Close()
+
+			values := make([]Row, len(rows)+1)
+			n, err := reader.Read(values)
+			if err != io.EOF {
+				t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+			}
+			if n != len(rows) {
+				t.Fatalf("number of rows mismatch: want=%d got=%d", len(rows), n)
+			}
+			for i := range rows {
+				if values[i] != rows[i] {
+					t.Fatalf("row %d mismatch: want=%+v got=%+v", i, rows[i], values[i])
+				}
+			}
+
+			pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+			defer pages.Close()
+
+			page, err := pages.ReadPage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer parquet.Release(page)
+
+			dict := page.Dictionary()
+			if dict == nil {
+				t.Fatal("page has no dictionary")
+			}
+			if n := dict.Len(); n != 300 {
+				t.Fatalf("dictionary length mismatch: want=300 got=%d", n)
+			}
+			for i := 0; i < dict.Len(); i++ {
+				if v := dict.Index(int32(i)).String(); v != fmt.Sprintf("name-%d", (i*7)%300) {
+					t.Fatalf("dictionary value %d mismatch: got=%q", i, v)
+				}
+			}
+
+			min, max := dict.Bounds([]int32{5, 0, 299, 42})
+			if min.String() != "name-0" || max.String() != "name-35" {
+				t.Errorf("dictionary bounds mismatch: want=[name-0,name-35] got=[%s,%s]", min, max)
+			}
+		})
+	}
+}
+
+func TestFileColumnChunkDictionary(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i%10)}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type dictionaryChunk interface {
+		Dictionary() (parquet.Dictionary, error)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+
+	dict, err := columns[0].(dictionaryChunk).Dictionary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dict != nil {
+		t.Errorf("column without dictionary encoding has a dictionary of length %d", dict.Len())
+	}
+
+	dict, err = columns[1].(dictionaryChunk).Dictionary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dict == nil {
+		t.Fatal("column chunk has no dictionary")
+	}
+	if n := dict.Len(); n != 10 {
+		t.Fatalf("dictionary length mismatch: want=10 got=%d", n)
+	}
+
+	pages := columns[1].Pages()
+	defer pages.Close()
+
+	var names []string
+	for {
+		page, err := pages.ReadPage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexes := parquet.DictionaryIndexes(page)
+		if indexes == nil {
+			t.Fatal("page has no dictionary indexes")
+		}
+		values := make([]parquet.Value, len(indexes))
+		dict.Lookup(indexes, values)
+		for _, v := range values {
+			names = append(names, v.String())
+		}
+		parquet.Release(page)
+	}
+
+	if len(names) != len(rows) {
+		t.Fatalf("number of values mismatch: want=%d got=%d", len(rows), len(names))
+	}
+	for i, name := range names {
+		if name != rows[i].Name {
+			t.Fatalf("value %d mismatch: want=%q got=%q", i, rows[i].Name, name)
+		}
+	}
+}
+
+func TestFileCollectReadStats(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id,zstd"`
+		Name string   `parquet:"name,dict,zstd"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Name: fmt.Sprintf("name-%d", i%100),
+			Tags: []string{"a", "b"}[:i%3],
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+
+	readAll := func(t *testing.T, f *parquet.File) {
+		reader := parquet.NewGenericReader[Row](f)
+		defer reader.Close()
+
+		values := make([]Row, len(rows)+1)
+		n, err := reader.Read(values)
+		if err != io.EOF {
+			t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+		}
+		if n != len(rows) {
+			t.Fatalf("number of rows mismatch: want=%d got=%d", len(rows), n)
+		}
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.CollectReadStats(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		readAll(t, f)
+
+		stats := f.Stats()
+		if stats.Pages <= int64(len(f.Schema().Columns())) {
+			t.Errorf("expected multiple pages per column: got=%d", stats.Pages)
+		}
+		for _, stage := range []struct {
+			name     string
+			duration time.Duration
+		}{
+			{"seek", stats.Seek},
+			{"header parse", stats.HeaderParse},
+			{"decompress", stats.Decompress},
+			{"level decode", stats.LevelDecode},
+			{"value decode", stats.ValueDecode},
+		} {
+			if stage.duration <= 0 {
+				t.Errorf("%s duration was not measured: got=%v", stage.name, stage.duration)
+			}
+		}
+
+		dictionaries := f.DictionaryStats()
+		if len(dictionaries) != 3 {
+			t.Fatalf("number of dictionary statistics mismatch: want=3 got=%d", len(dictionaries))
+		}
+		if dictionaries[0] != (parquet.DictionaryStats{}) {
+			t.Errorf("dictionary statistics of a column without dictionary: %+v", dictionaries[0])
+		}
+		// The names are all in the dictionary of the only column chunk.
+		name := dictionaries[1]
+		if name.DictionaryPages != 1 || name.DictionaryEntries != 100 || name.DistinctIndexes != 100 {
+			t.Errorf("dictionary statistics mismatch: %+v", name)
+		}
+		if name.IndexedPages <= 1 || name.IndexedValues != int64(len(rows)) || name.FallbackPages != 0 {
+			t.Errorf("data page statistics mismatch: %+v", name)
+		}
+		if hitRate := name.HitRate(); hitRate != 1 {
+			t.Errorf("hit rate mismatch: want=1 got=%g", hitRate)
+		}
+		if reuse := name.Reuse(); reuse != 10 {
+			t.Errorf("reuse mismatch: want=10 got=%g", reuse)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		readAll(t, f)
+
+		if stats := f.Stats(); stats != (parquet.ReadStats{}) {
+			t.Errorf("statistics collected without the option: %+v", stats)
+		}
+		if stats := f.DictionaryStats(); stats != nil {
+			t.Errorf("dictionary statistics collected without the option: %+v", stats)
+		}
+	})
+}
+
+func TestFileLazyColumnMetadata(t *testing.T) {
+	for _, path := range testdataFiles {
+		t.Run(path, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			eager, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Skip(err)
+			}
+			lazy, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), parquet.LazyColumnMetadata(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			eagerRowGroups := eager.RowGroups()
+			lazyRowGroups := lazy.RowGroups()
+			if len(eagerRowGroups) != len(lazyRowGroups) {
+				t.Fatalf("number of row groups mismatch: want=%d got=%d", len(eagerRowGroups), len(lazyRowGroups))
+			}
+
+			for i := range eagerRowGroups {
+				eagerColumns := eagerRowGroups[i].ColumnChunks()
+				lazyColumns := lazyRowGroups[i].ColumnChunks()
+
+				for j := range eagerColumns {
+					want, got := eagerColumns[j], lazyColumns[j]
+
+					if want.NumValues() != got.NumValues() {
+						t.Errorf("row group %d column %d: number of values mismatch: want=%d got=%d", i, j, want.NumValues(), got.NumValues())
+					}
+					if wantIndex := want.OffsetIndex(); wantIndex != nil && wantIndex.NumPages() > 0 {
+						if gotIndex := got.OffsetIndex(); gotIndex == nil {
+							t.Errorf("row group %d column %d: missing offset index", i, j)
+						} else if wantIndex.NumPages() != gotIndex.NumPages() {
+							t.Errorf("row group %d column %d: number of pages mismatch: want=%d got=%d", i, j, wantIndex.NumPages(), gotIndex.NumPages())
+						}
+					}
+					if (want.BloomFilter() == nil) != (got.BloomFilter() == nil) {
+						t.Errorf("row group %d column %d: bloom filter mismatch", i, j)
+					}
+				}
+			}
+
+			if !reflect.DeepEqual(eager.Metadata(), lazy.Metadata()) {
+				t.Error("file metadata mismatch")
+			}
+		})
+	}
+
+	t.Run("read", func(t *testing.T) {
+		type Row struct {
+			A int64  `parquet:"a"`
+			B string `parquet:"b,dict"`
+			C int32  `parquet:"c"`
+			D bool   `parquet:"d"`
+			E []byte `parquet:"e,zstd"`
+		}
+		type Projection struct {
+			B string `parquet:"b"`
+			D bool   `parquet:"d"`
+		}
+
+		rows := make([]Row, 100)
+		for i := range rows {
+			rows[i] = Row{A: int64(i), B: fmt.Sprint(i % 7), C: int32(-i), D: i%2 == 0, E: []byte{byte(i)}}
+		}
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows, parquet.MaxRowsPerRowGroup(30), parquet.BloomFilters(parquet.SplitBlockFilter(10, "b"))); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.LazyColumnMetadata(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reader := parquet.NewGenericReader[Projection](f)
+		defer reader.Close()
+
+		values := make([]Projection, len(rows)+1)
+		n, err := reader.Read(values)
+		if err != io.EOF {
+			t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+		}
+		if n != len(rows) {
+			t.Fatalf("number of rows mismatch: want=%d got=%d", len(rows), n)
+		}
+		for i, v := range values[:n] {
+			if want := (Projection{B: rows[i].B, D: rows[i].D}); v != want {
+				t.Fatalf("row %d mismatch: want=%+v got=%+v", i, want, v)
+			}
+		}
+
+		if bloomFilter := f.RowGroups()[0].ColumnChunks()[1].BloomFilter(); bloomFilter == nil {
+			t.Error("missing bloom filter")
+		} else if ok, err := bloomFilter.Check(parquet.ValueOf("3")); err != nil || !ok {
+			t.Errorf("bloom filter check failed: ok=%t err=%v", ok, err)
+		}
+	})
+}
+
+type mislabeledCodec struct {
+	compress.Codec
+	label format.CompressionCodec
+}
+
+func (c *mislabeledCodec) CompressionCodec() format.CompressionCodec { return c.label }
+
+func TestFileDetectCompression(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i)}
+	}
+
+	for _, test := range []struct {
+		codec compress.Codec
+		label format.CompressionCodec
+	}{
+		{codec: &parquet.Gzip, label: format.Snappy},
+		{codec: &parquet.Zstd, label: format.Gzip},
+		{codec: &parquet.Snappy, label: format.Zstd},
+	} {
+		t.Run(test.codec.String(), func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			codec := &mislabeledCodec{Codec: test.codec, label: test.label}
+			if err := parquet.Write(buffer, rows, parquet.Compression(codec)); err != nil {
+				t.Fatal(err)
+			}
+			input := bytes.NewReader(buffer.Bytes())
+
+			f, err := parquet.OpenFile(input, input.Size())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := parquet.NewGenericReader[Row](f).Read(make([]Row, len(rows))); err == nil || err == io.EOF {
+				t.Fatalf("reading mislabeled pages must fail without detection, got %v", err)
+			}
+
+			var mismatches []parquet.CompressionMismatch
+			var mutex sync.Mutex
+			f, err = parquet.OpenFile(input, input.Size(), parquet.DetectCompression(func(m parquet.CompressionMismatch) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				mismatches = append(mismatches, m)
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			values := make([]Row, len(rows)+1)
+			n, err := parquet.NewGenericReader[Row](f).Read(values)
+			if err != io.EOF {
+				t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+			}
+			if !reflect.DeepEqual(values[:n], rows) {
+				t.Error("rows mismatch")
+			}
+			if len(mismatches) == 0 {
+				t.Fatal("no compression mismatches were reported")
+			}
+			want := test.codec.CompressionCodec()
+			for _, m := range mismatches {
+				if m.Declared != test.label || m.Detected != want {
+					t.Errorf("mismatch of column %q: want=%v/%v got=%v/%v", m.Path, test.label, want, m.Declared, m.Detected)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenFileHadoopLz4(t *testing.T) {
+	// The rows mirror the content of the hadoop_lz4_compressed.parquet and
+	// hadoop_lz4_compressed_larger.parquet files of parquet-testing, which
+	// parquet-mr wrote with the Hadoop framing of LZ4 blocks.
+	type Row struct {
+		C0  int64   `parquet:"c0"`
+		C1  []byte  `parquet:"c1"`
+		V11 float64 `parquet:"v11"`
+	}
+	t.Run("hadoop_lz4_compressed", func(t *testing.T) {
+		testOpenFileHadoopLz4(t, []Row{
+			{C0: 1593604800, C1: []byte("abc"), V11: 42.0},
+			{C0: 1593604800, C1: []byte("def"), V11: 7.7},
+			{C0: 1593604801, C1: []byte("abc"), V11: 42.125},
+			{C0: 1593604801, C1: []byte("def"), V11: 7.7},
+		})
+	})
+
+	type LargerRow struct {
+		A string `parquet:"a"`
+	}
+	t.Run("hadoop_lz4_compressed_larger", func(t *testing.T) {
+		rows := make([]LargerRow, 10000)
+		for i := range rows {
+			rows[i].A = fmt.Sprintf("%08x-d5b0-4863-b199-%012x", i*7919, i)
+		}
+		testOpenFileHadoopLz4(t, rows, parquet.PageBufferSize(4096))
+	})
+}
+
+func testOpenFileHadoopLz4[Row any](t *testing.T, rows []Row, options ...parquet.WriterOption) {
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, append(options, parquet.Compression(&parquet.Lz4))...); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rowGroup := range f.Metadata().RowGroups {
+		for _, column := range rowGroup.Columns {
+			if codec := column.MetaData.Codec; codec != format.Lz4 {
+				t.Errorf("column %q: compression codec mismatch: want=%v got=%v", column.MetaData.PathInSchema, format.Lz4, codec)
+			}
+		}
+	}
+
+	values := make([]Row, len(rows)+1)
+	n, err := parquet.NewGenericReader[Row](f).Read(values)
+	if err != io.EOF {
+		t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+	}
+	if !reflect.DeepEqual(values[:n], rows) {
+		t.Error("rows mismatch")
+	}
+}
+
+func TestOpenFileHadoopLz4Fixtures(t *testing.T) {
+	open := func(t *testing.T, name string) *parquet.File {
+		t.Helper()
+		// The fixtures come from the data directory of parquet-testing.
+		path := filepath.Join("testdata", name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			t.Skipf("%s is missing, copy it from apache/parquet-testing to run the test", path)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	t.Run("hadoop_lz4_compressed", func(t *testing.T) {
+		type Row struct {
+			C0  int64   `parquet:"c0"`
+			C1  []byte  `parquet:"c1"`
+			V11 float64 `parquet:"v11"`
+		}
+		f := open(t, "hadoop_lz4_compressed.parquet")
+		want := []Row{
+			{C0: 1593604800, C1: []byte("abc"), V11: 42.0},
+			{C0: 1593604800, C1: []byte("def"), V11: 7.7},
+			{C0: 1593604801, C1: []byte("abc"), V11: 42.125},
+			{C0: 1593604801, C1: []byte("def"), V11: 7.7},
+		}
+		got := make([]Row, len(want)+1)
+		n, err := parquet.NewGenericReader[Row](f).Read(got)
+		if err != io.EOF {
+			t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+		}
+		if !reflect.DeepEqual(got[:n], want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got[:n])
+		}
+	})
+
+	t.Run("hadoop_lz4_compressed_larger", func(t *testing.T) {
+		type Row struct {
+			A string `parquet:"a"`
+		}
+		f := open(t, "hadoop_lz4_compressed_larger.parquet")
+		got := make([]Row, 10001)
+		n, err := parquet.NewGenericReader[Row](f).Read(got)
+		if err != io.EOF {
+			t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+		}
+		if n != 10000 {
+			t.Fatalf("number of rows mismatch: want=10000 got=%d", n)
+		}
+		if want := "c7ce6bef-d5b0-4863-b199-8ea8c7fb117b"; got[0].A != want {
+			t.Errorf("first row mismatch: want=%q got=%q", want, got[0].A)
+		}
+		for i, row := range got[:n] {
+			if len(row.A) != 36 {
+				t.Fatalf("row %d is not a UUID: %q", i, row.A)
+			}
+		}
+	})
+}
+
+func TestFileWarnings(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := []Row{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}}
+
+	open := func(t *testing.T, writerOptions []parquet.WriterOption, fileOptions ...parquet.FileOption) *parquet.File {
+		t.Helper()
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows, writerOptions...); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), fileOptions...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	t.Run("none", func(t *testing.T) {
+		if warnings := open(t, nil).Warnings(); len(warnings) != 0 {
+			t.Errorf("unexpected warnings: %v", warnings)
+		}
+	})
+
+	t.Run("missing statistics", func(t *testing.T) {
+		f := open(t, []parquet.WriterOption{parquet.OmitStatistics("name"), parquet.MaxRowsPerRowGroup(2)})
+		want := []parquet.Warning{{Path: []string{"name"}, Message: "column chunk has values but no statistics", Count: 2}}
+		if warnings := f.Warnings(); !reflect.DeepEqual(warnings, want) {
+			t.Errorf("warnings mismatch: want=%v got=%v", want, warnings)
+		}
+	})
+
+	t.Run("detected compression", func(t *testing.T) {
+		codec := &mislabeledCodec{Codec: &parquet.Gzip, label: format.Snappy}
+		f := open(t, []parquet.WriterOption{parquet.Compression(codec)}, parquet.DetectCompression(nil))
+		if warnings := f.Warnings(); len(warnings) != 0 {
+			t.Errorf("unexpected warnings before reading pages: %v", warnings)
+		}
+		if _, err := parquet.NewGenericReader[Row](f).Read(make([]Row, len(rows))); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		want := []parquet.Warning{
+			{Path: []string{"id"}, Message: "page compressed with GZIP was read from a column chunk declaring SNAPPY", Count: 1},
+			{Path: []string{"name"}, Message: "page compressed with GZIP was read from a column chunk declaring SNAPPY", Count: 1},
+		}
+		if warnings := f.Warnings(); !reflect.DeepEqual(warnings, want) {
+			t.Errorf("warnings mismatch: want=%v got=%v", want, warnings)
+		}
+	})
+}
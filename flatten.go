@@ -0,0 +1,308 @@
+package parquet
+
+import "sort"
+
+// FlattenSchema returns a flat schema with one top-level column for each leaf
+// column of schema, named after the dot-separated path of the leaf column (e.g.
+// "user.address.zip"), for example to export nested data to CSV files or
+// staging tables of data warehouses.
+//
+// A column is optional when the leaf column or any of its parent fields is
+// optional or repeated, and required otherwise. Like in all groups, the columns
+// are ordered by name in the flat schema.
+func FlattenSchema(schema *Schema) *Schema {
+	group := make(Group)
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		if leaf.maxDefinitionLevel > 0 {
+			group[leaf.path.String()] = Optional(leaf.node)
+		} else {
+			group[leaf.path.String()] = Required(leaf.node)
+		}
+	})
+	return NewSchema(schema.Name(), group)
+}
+
+// FlattenRowReader returns a row reader which flattens the rows of schema read
+// from rows into rows of the schema returned by FlattenSchema.
+//
+// Repeated fields are exploded into multiple rows, like with a relational
+// UNNEST: each row read from rows produces one row for each element of its
+// repeated fields, where the values of the element are combined with the values
+// of its parent fields. Values of fields nested in the same repeated group stay
+// aligned, while the elements of independent repeated fields are combined with
+// each other, producing the cartesian product of their elements. Rows where a
+// repeated field is null or empty are not dropped, the columns nested in the
+// field are null instead.
+//
+// The number of rows read from the returned reader is therefore larger than
+// the number of rows read from rows when repeated fields have more than one
+// element.
+func FlattenRowReader(rows RowReader, schema *Schema) RowReaderWithSchema {
+	f := &flattenedRows{
+		rows:   rows,
+		schema: FlattenSchema(schema),
+		root:   new(flattenScope),
+	}
+	f.init(schema, nil, []*flattenScope{f.root}, 0)
+	f.values = make([][]flattenValue, len(f.columns))
+	return f
+}
+
+type flattenedRows struct {
+	rows    RowReader
+	schema  *Schema
+	root    *flattenScope
+	columns []flattenColumn
+	// Values of each column of the rows being flattened, with the indexes of
+	// the elements of repeated fields that they belong to.
+	values   [][]flattenValue
+	elements []int
+	row      Row
+	source   []Row
+	buffer   []Row
+	pending  []Row
+	err      error
+}
+
+// flattenScope represents the root of the schema or a repeated field. Each
+// element of a repeated field sets the values of the leaf columns of the scope,
+// and is combined with the elements of the repeated fields nested in it.
+type flattenScope struct {
+	repetitionLevel int
+	definitionLevel byte
+	// Leaf columns of the scope which are not nested in other repeated fields.
+	leaves []int
+	// Repeated fields nested in the scope.
+	scopes []*flattenScope
+	// All the leaf columns nested in the scope.
+	columns []int
+}
+
+type flattenColumn struct {
+	// Index of the column in the flat schema.
+	index              int
+	maxRepetitionLevel int
+	maxDefinitionLevel byte
+}
+
+type flattenValue struct {
+	value    Value
+	elements []int
+}
+
+func (f *flattenedRows) init(node Node, path columnPath, scopes []*flattenScope, definitionLevel byte) {
+	switch {
+	case node.Optional():
+		definitionLevel++
+	case node.Repeated():
+		definitionLevel++
+		parent := scopes[len(scopes)-1]
+		scope := &flattenScope{
+			repetitionLevel: len(scopes),
+			definitionLevel: definitionLevel,
+		}
+		parent.scopes = append(parent.scopes, scope)
+		scopes = append(scopes[:len(scopes):len(scopes)], scope)
+	}
+
+	if !node.Leaf() {
+		for _, field := range node.Fields() {
+			f.init(field, path.append(field.Name()), scopes, definitionLevel)
+		}
+		return
+	}
+
+	leaf, _ := f.schema.Lookup(path.String())
+	columnIndex := len(f.columns)
+	f.columns = append(f.columns, flattenColumn{
+		index:              leaf.ColumnIndex,
+		maxRepetitionLevel: len(scopes) - 1,
+		maxDefinitionLevel: definitionLevel,
+	})
+	scope := scopes[len(scopes)-1]
+	scope.leaves = append(scope.leaves, columnIndex)
+	for _, s := range scopes {
+		s.columns = append(s.columns, columnIndex)
+	}
+}
+
+func (f *flattenedRows) ReadRows(rows []Row) (int, error) {
+	for len(f.pending) == 0 {
+		if f.err != nil {
+			return 0, f.err
+		}
+		// Rows are only read from the underlying reader when all the flattened
+		// rows were returned, since their values may reference buffers that
+		// get reused on the next read.
+		size := max(len(rows), 1)
+		if len(f.source) < size {
+			f.source = make([]Row, size)
+		}
+		n, err := f.rows.ReadRows(f.source[:size])
+		f.err = err
+		f.buffer = f.buffer[:0]
+		for _, row := range f.source[:n] {
+			f.flatten(row)
+		}
+		f.pending = f.buffer
+	}
+
+	n := min(len(rows), len(f.pending))
+	for i := range rows[:n] {
+		rows[i] = append(rows[i][:0], f.pending[i]...)
+	}
+	f.pending = f.pending[n:]
+	return n, nil
+}
+
+func (f *flattenedRows) Schema() *Schema { return f.schema }
+
+func (f *flattenedRows) flatten(row Row) {
+	for i := range f.values {
+		f.values[i] = f.values[i][:0]
+	}
+	f.elements = f.elements[:0]
+
+	// Each value of a column nested in repeated fields is assigned the indexes
+	// of the elements it belongs to at each repetition level. Values are in
+	// the order of the elements, which makes the indexes sorted.
+	for _, v := range row {
+		columnIndex := v.Column()
+		if columnIndex < 0 || columnIndex >= len(f.values) {
+			continue
+		}
+		values := f.values[columnIndex]
+		start := len(f.elements)
+		if len(values) == 0 || v.repetitionLevel == 0 {
+			for i := 0; i < f.columns[columnIndex].maxRepetitionLevel; i++ {
+				f.elements = append(f.elements, 0)
+			}
+		} else {
+			prev := values[len(values)-1].elements
+			f.elements = append(f.elements, prev...)
+			e := f.elements[start:]
+			e[v.repetitionLevel-1]++
+			for i := int(v.repetitionLevel); i < len(e); i++ {
+				e[i] = 0
+			}
+		}
+		f.values[columnIndex] = append(values, flattenValue{
+			value:    v,
+			elements: f.elements[start:len(f.elements):len(f.elements)],
+		})
+	}
+
+	if cap(f.row) < len(f.columns) {
+		f.row = make(Row, len(f.columns))
+	}
+	f.row = f.row[:len(f.columns)]
+	f.setLeaves(f.root, nil)
+	f.explode(f.scopesOf(f.root, nil, nil))
+}
+
+// flattenItem is a repeated field of the element identified by prefix, where
+// prefix holds the indexes of the parent elements at each repetition level.
+type flattenItem struct {
+	scope  *flattenScope
+	prefix []int
+}
+
+func (f *flattenedRows) scopesOf(scope *flattenScope, prefix []int, work []flattenItem) []flattenItem {
+	items := make([]flattenItem, 0, len(scope.scopes)+len(work))
+	for _, s := range scope.scopes {
+		items = append(items, flattenItem{scope: s, prefix: prefix})
+	}
+	return append(items, work...)
+}
+
+// explode produces the rows of the cartesian product of the elements of the
+// repeated fields in work, each field producing a single row of null values
+// when it has no elements.
+func (f *flattenedRows) explode(work []flattenItem) {
+	if len(work) == 0 {
+		f.emit()
+		return
+	}
+
+	item, work := work[0], work[1:]
+	n := f.numElements(item.scope, item.prefix)
+	if n == 0 {
+		for _, c := range item.scope.columns {
+			columnIndex := f.columns[c].index
+			f.row[columnIndex] = Value{}.Level(0, 0, columnIndex)
+		}
+		f.explode(work)
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		prefix := append(item.prefix[:len(item.prefix):len(item.prefix)], i)
+		f.setLeaves(item.scope, prefix)
+		f.explode(f.scopesOf(item.scope, prefix, work))
+	}
+}
+
+func (f *flattenedRows) emit() {
+	n := len(f.buffer)
+	if n < cap(f.buffer) {
+		f.buffer = f.buffer[:n+1]
+		f.buffer[n] = append(f.buffer[n][:0], f.row...)
+	} else {
+		f.buffer = append(f.buffer, f.row.Clone())
+	}
+}
+
+// numElements returns the number of elements of the repeated field of scope
+// in the parent element identified by prefix.
+func (f *flattenedRows) numElements(scope *flattenScope, prefix []int) int {
+	values := f.values[scope.columns[0]]
+	i := searchFlattenValues(values, prefix, 0)
+	j := searchFlattenValues(values, prefix, 1)
+	if i == j || values[i].value.definitionLevel < scope.definitionLevel {
+		// The repeated field or one of its parent groups is null or empty.
+		return 0
+	}
+	return values[j-1].elements[scope.repetitionLevel-1] + 1
+}
+
+// setLeaves sets the values of the leaf columns of scope in the element
+// identified by prefix.
+func (f *flattenedRows) setLeaves(scope *flattenScope, prefix []int) {
+	for _, c := range scope.leaves {
+		column := &f.columns[c]
+		values := f.values[c]
+		v, definitionLevel := Value{}, 0
+
+		if i := searchFlattenValues(values, prefix, 0); i < len(values) {
+			if x := values[i].value; x.definitionLevel == column.maxDefinitionLevel && !x.IsNull() {
+				v = x
+				if column.maxDefinitionLevel > 0 {
+					definitionLevel = 1
+				}
+			}
+		}
+
+		f.row[column.index] = v.Level(0, definitionLevel, column.index)
+	}
+}
+
+// searchFlattenValues returns the index of the first value of values where
+// the element indexes are greater than or equal to prefix when cmp is 0, or
+// strictly greater than prefix when cmp is 1.
+func searchFlattenValues(values []flattenValue, prefix []int, cmp int) int {
+	return sort.Search(len(values), func(i int) bool {
+		return compareElements(values[i].elements[:len(prefix)], prefix) >= cmp
+	})
+}
+
+func compareElements(a, b []int) int {
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return +1
+		}
+	}
+	return 0
+}
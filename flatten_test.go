@@ -0,0 +1,147 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestFlattenRowReader(t *testing.T) {
+	type Item struct {
+		SKU      string `parquet:"sku"`
+		Quantity int32  `parquet:"quantity"`
+	}
+	type Order struct {
+		ID    int64    `parquet:"id"`
+		Items []Item   `parquet:"items"`
+		Tags  []string `parquet:"tags,list"`
+		Notes *string  `parquet:"notes,optional"`
+	}
+	type Flat struct {
+		ID            int64   `parquet:"id"`
+		ItemsQuantity *int32  `parquet:"items.quantity,optional"`
+		ItemsSKU      *string `parquet:"items.sku,optional"`
+		Notes         *string `parquet:"notes,optional"`
+		Tags          *string `parquet:"tags.list.element,optional"`
+	}
+
+	note := "gift"
+	orders := []Order{
+		{ID: 1, Items: []Item{{"a", 1}, {"b", 2}}, Tags: []string{"x", "y"}, Notes: &note},
+		{ID: 2, Items: []Item{{"c", 3}}},
+		{ID: 3, Tags: []string{"z"}},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, orders); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(f)
+	defer reader.Close()
+	flattened := parquet.FlattenRowReader(reader, f.Schema())
+
+	wantFields, gotFields := parquet.SchemaOf(Flat{}).Fields(), flattened.Schema().Fields()
+	if len(wantFields) != len(gotFields) {
+		t.Fatalf("number of fields mismatch: want=%d got=%d", len(wantFields), len(gotFields))
+	}
+	for i, want := range wantFields {
+		got := gotFields[i]
+		if want.Name() != got.Name() || want.Optional() != got.Optional() || want.Type().Kind() != got.Type().Kind() {
+			t.Errorf("field %d mismatch:\nwant = %s %s optional=%t\ngot  = %s %s optional=%t", i,
+				want.Name(), want.Type(), want.Optional(), got.Name(), got.Type(), got.Optional())
+		}
+	}
+
+	// Read one row at a time to exercise rows left pending between calls.
+	var rows []parquet.Row
+	buf := make([]parquet.Row, 1)
+	for {
+		n, err := flattened.ReadRows(buf)
+		for _, row := range buf[:n] {
+			rows = append(rows, row.Clone())
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	schema := parquet.SchemaOf(Flat{})
+	values := make([]Flat, len(rows))
+	for i, row := range rows {
+		if err := schema.Reconstruct(&values[i], row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	str := func(s string) *string { return &s }
+	i32 := func(i int32) *int32 { return &i }
+	expected := []Flat{
+		{ID: 1, ItemsSKU: str("a"), ItemsQuantity: i32(1), Tags: str("x"), Notes: &note},
+		{ID: 1, ItemsSKU: str("a"), ItemsQuantity: i32(1), Tags: str("y"), Notes: &note},
+		{ID: 1, ItemsSKU: str("b"), ItemsQuantity: i32(2), Tags: str("x"), Notes: &note},
+		{ID: 1, ItemsSKU: str("b"), ItemsQuantity: i32(2), Tags: str("y"), Notes: &note},
+		{ID: 2, ItemsSKU: str("c"), ItemsQuantity: i32(3)},
+		{ID: 3, Tags: str("z")},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", expected, values)
+	}
+}
+
+func TestFlattenRowReaderNestedRepeatedFields(t *testing.T) {
+	type Group struct {
+		Values []int32 `parquet:"values"`
+	}
+	type Row struct {
+		ID     int64   `parquet:"id"`
+		Groups []Group `parquet:"groups"`
+	}
+	type Flat struct {
+		GroupsValues *int32 `parquet:"groups.values,optional"`
+		ID           int64  `parquet:"id"`
+	}
+
+	rows := []Row{
+		{ID: 1, Groups: []Group{{[]int32{1, 2}}, {}, {[]int32{3}}}},
+		{ID: 2},
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	if _, err := buffer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+
+	flattened := parquet.FlattenRowReader(buffer.Rows(), buffer.Schema())
+	output := parquet.NewGenericBuffer[Flat]()
+	if _, err := parquet.CopyRows(output, flattened); err != nil {
+		t.Fatal(err)
+	}
+
+	values := make([]Flat, output.NumRows())
+	if _, err := parquet.NewGenericRowGroupReader[Flat](output).Read(values); err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+
+	i32 := func(i int32) *int32 { return &i }
+	expected := []Flat{
+		{ID: 1, GroupsValues: i32(1)},
+		{ID: 1, GroupsValues: i32(2)},
+		{ID: 1},
+		{ID: 1, GroupsValues: i32(3)},
+		{ID: 2},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", expected, values)
+	}
+}
@@ -96,6 +96,10 @@ type Statistics struct {
 	// arrays do not include a length prefix.
 	MaxValue []byte `thrift:"5"`
 	MinValue []byte `thrift:"6"`
+	// If true, max_value is the actual maximum value for a column.
+	IsMaxValueExact *bool `thrift:"7,optional"`
+	// If true, min_value is the actual minimum value for a column.
+	IsMinValueExact *bool `thrift:"8,optional"`
 }
 
 // Empty structs to use as logical type annotations.
@@ -743,6 +747,13 @@ type ColumnMetaData struct {
 
 	// Byte offset from beginning of file to Bloom filter data.
 	BloomFilterOffset int64 `thrift:"14,optional"`
+
+	// Size of Bloom filter data including the serialized header, in bytes.
+	// Added in 2.10 so readers may not read this field from old files and
+	// it can be obtained after the BloomFilterHeader has been deserialized.
+	// Writers should write this field so readers can read the bloom filter
+	// in a single I/O.
+	BloomFilterLength int32 `thrift:"15,optional"`
 }
 
 type EncryptionWithFooterKey struct{}
@@ -0,0 +1,130 @@
+//go:build go1.23
+
+package parquet
+
+import (
+	"io"
+	"iter"
+)
+
+// Rows returns an iterator over the rows of f, in the order that they appear
+// in the file.
+//
+// Iteration stops after the first error, which is yielded with a nil row.
+// Breaking out of the loop early releases the resources held by the iterator.
+//
+// The rows are only valid until the next iteration step; programs that need to
+// retain them must use Row.Clone.
+func (f *File) Rows() iter.Seq2[Row, error] {
+	return func(yield func(Row, error) bool) {
+		for _, rowGroup := range f.rowGroups {
+			if !yieldRows(rowGroup.Rows(), yield) {
+				return
+			}
+		}
+	}
+}
+
+// RowsSeq returns an iterator over the rows of the given RowGroup.
+//
+// The iterator has the same semantics as the one returned by File.Rows.
+func RowsSeq(rowGroup RowGroup) iter.Seq2[Row, error] {
+	return func(yield func(Row, error) bool) {
+		yieldRows(rowGroup.Rows(), yield)
+	}
+}
+
+// PagesSeq returns an iterator over the pages of the given ColumnChunk.
+//
+// Pages are released when the iteration moves to the next page, programs must
+// not retain them (or values that they hold) past the iteration step.
+//
+// Iteration stops after the first error, which is yielded with a nil page.
+func PagesSeq(columnChunk ColumnChunk) iter.Seq2[Page, error] {
+	return func(yield func(Page, error) bool) {
+		pages := columnChunk.Pages()
+		defer pages.Close()
+
+		for {
+			p, err := pages.ReadPage()
+			if err != nil {
+				if err != io.EOF {
+					yield(nil, err)
+				}
+				return
+			}
+			more := yield(p, nil)
+			Release(p)
+			if !more {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over the values of the given ColumnChunk,
+// spanning across all of its pages.
+//
+// Values may reference memory of the page they were read from and are only
+// valid until the next iteration step; programs that need to retain them must
+// use Value.Clone.
+//
+// Iteration stops after the first error, which is yielded with a zero value.
+func ValuesSeq(columnChunk ColumnChunk) iter.Seq2[Value, error] {
+	return func(yield func(Value, error) bool) {
+		buffer := make([]Value, defaultValueBufferSize)
+
+		for p, err := range PagesSeq(columnChunk) {
+			if err != nil {
+				yield(Value{}, err)
+				return
+			}
+			if !yieldValues(p.Values(), buffer, yield) {
+				return
+			}
+		}
+	}
+}
+
+func yieldRows(rows Rows, yield func(Row, error) bool) bool {
+	defer rows.Close()
+	buffer := make([]Row, defaultRowBufferSize)
+
+	for {
+		n, err := rows.ReadRows(buffer)
+
+		for _, row := range buffer[:n] {
+			if !yield(row, nil) {
+				return false
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return true
+			}
+			yield(nil, err)
+			return false
+		}
+	}
+}
+
+func yieldValues(values ValueReader, buffer []Value, yield func(Value, error) bool) bool {
+	for {
+		n, err := values.ReadValues(buffer)
+
+		for _, value := range buffer[:n] {
+			if !yield(value, nil) {
+				return false
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return true
+			}
+			yield(Value{}, err)
+			return false
+		}
+	}
+}
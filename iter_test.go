@@ -0,0 +1,107 @@
+//go:build go1.23
+
+package parquet_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestIterators(t *testing.T) {
+	type Row struct {
+		ID   int64
+		Name string
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("row-%d", i)}
+	}
+
+	f, err := createParquetFile(makeRows(rows), parquet.MaxRowsPerRowGroup(300), parquet.PageBufferSize(512))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		i := 0
+		for row, err := range f.Rows() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id := row[0].Int64(); id != rows[i].ID {
+				t.Fatalf("row %d mismatch: want=%d got=%d", i, rows[i].ID, id)
+			}
+			i++
+		}
+		if i != len(rows) {
+			t.Errorf("number of rows mismatch: want=%d got=%d", len(rows), i)
+		}
+	})
+
+	t.Run("rows-break", func(t *testing.T) {
+		i := 0
+		for range f.Rows() {
+			if i++; i == 500 {
+				break
+			}
+		}
+		if i != 500 {
+			t.Errorf("number of rows mismatch: want=500 got=%d", i)
+		}
+	})
+
+	t.Run("row-group-rows", func(t *testing.T) {
+		numRows := int64(0)
+		for _, rowGroup := range f.RowGroups() {
+			n := int64(0)
+			for _, err := range parquet.RowsSeq(rowGroup) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				n++
+			}
+			if n != rowGroup.NumRows() {
+				t.Errorf("number of rows mismatch: want=%d got=%d", rowGroup.NumRows(), n)
+			}
+			numRows += n
+		}
+		if numRows != int64(len(rows)) {
+			t.Errorf("number of rows mismatch: want=%d got=%d", len(rows), numRows)
+		}
+	})
+
+	t.Run("pages-and-values", func(t *testing.T) {
+		for _, rowGroup := range f.RowGroups() {
+			columnChunk := rowGroup.ColumnChunks()[1]
+
+			numPages, numValues := 0, int64(0)
+			for p, err := range parquet.PagesSeq(columnChunk) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				numPages++
+				numValues += p.NumValues()
+			}
+			if numPages < 2 {
+				t.Errorf("expected column chunk to have multiple pages: got=%d", numPages)
+			}
+
+			n := int64(0)
+			for v, err := range parquet.ValuesSeq(columnChunk) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v.Column() != 1 {
+					t.Fatalf("value belongs to the wrong column: want=1 got=%d", v.Column())
+				}
+				n++
+			}
+			if n != numValues || n != rowGroup.NumRows() {
+				t.Errorf("number of values mismatch: want=%d got=%d (pages=%d)", rowGroup.NumRows(), n, numValues)
+			}
+		}
+	})
+}
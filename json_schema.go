@@ -0,0 +1,209 @@
+package parquet
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// MarshalJSONSchema returns a JSON Schema document (draft 2020-12) describing
+// the logical structure of rows of the given node.
+//
+// Groups are represented as JSON objects, LIST and MAP groups as arrays and
+// objects with additional properties, and leaf columns by the JSON type that
+// best matches their logical type (e.g. "string" with "date-time" format for
+// timestamps, or "integer" with bounds matching the bit width of integers).
+// Optional columns accept null values, and required columns are listed in the
+// "required" property of their parent object.
+//
+// The name is used as title of the JSON Schema document.
+func MarshalJSONSchema(name string, node Node) ([]byte, error) {
+	schema := jsonSchemaOf(node)
+	schema["$schema"] = jsonSchemaDialect
+	if name != "" {
+		schema["title"] = name
+	}
+	return json.Marshal(schema)
+}
+
+type jsonSchema = map[string]any
+
+func jsonSchemaOf(node Node) jsonSchema {
+	var schema jsonSchema
+
+	switch {
+	case node.Leaf():
+		schema = jsonSchemaOfLeaf(node.Type())
+	case isListGroup(node):
+		schema = jsonSchemaOfList(node)
+	case isMapGroup(node):
+		schema = jsonSchemaOfMap(node)
+	default:
+		schema = jsonSchemaOfGroup(node)
+	}
+
+	switch {
+	case node.Repeated():
+		schema = jsonSchema{"type": "array", "items": schema}
+	case node.Optional():
+		schema = jsonSchemaNullable(schema)
+	}
+	return schema
+}
+
+func jsonSchemaOfGroup(node Node) jsonSchema {
+	fields := node.Fields()
+	properties := make(jsonSchema, len(fields))
+	required := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		properties[field.Name()] = jsonSchemaOf(field)
+		if field.Required() {
+			required = append(required, field.Name())
+		}
+	}
+
+	schema := jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func jsonSchemaOfList(node Node) jsonSchema {
+	list := node.Fields()[0]
+	var items jsonSchema
+	if list.Leaf() || len(list.Fields()) != 1 {
+		// Legacy two-level lists, where the repeated field is the element.
+		items = jsonSchemaOf(Required(list))
+	} else {
+		items = jsonSchemaOf(list.Fields()[0])
+	}
+	return jsonSchema{"type": "array", "items": items}
+}
+
+func jsonSchemaOfMap(node Node) jsonSchema {
+	keyValue := node.Fields()[0]
+	schema := jsonSchema{"type": "object"}
+	if value := fieldByName(keyValue, "value"); value != nil {
+		schema["additionalProperties"] = jsonSchemaOf(value)
+	}
+	return schema
+}
+
+func jsonSchemaNullable(schema jsonSchema) jsonSchema {
+	switch t := schema["type"].(type) {
+	case string:
+		schema["type"] = []string{t, "null"}
+	case nil:
+		// The schema already accepts any value.
+	default:
+		schema = jsonSchema{"anyOf": []jsonSchema{schema, {"type": "null"}}}
+	}
+	return schema
+}
+
+func jsonSchemaOfLeaf(t Type) jsonSchema {
+	if lt := t.LogicalType(); lt != nil {
+		switch {
+		case lt.UTF8 != nil, lt.Enum != nil:
+			return jsonSchema{"type": "string"}
+		case lt.UUID != nil:
+			return jsonSchema{"type": "string", "format": "uuid"}
+		case lt.Json != nil:
+			return jsonSchema{}
+		case lt.Bson != nil:
+			return jsonSchema{"type": "string", "contentEncoding": "base64", "contentMediaType": "application/bson"}
+		case lt.Date != nil:
+			return jsonSchema{"type": "string", "format": "date"}
+		case lt.Time != nil:
+			return jsonSchema{"type": "string", "format": "time"}
+		case lt.Timestamp != nil:
+			return jsonSchema{"type": "string", "format": "date-time"}
+		case lt.Decimal != nil:
+			return jsonSchema{"type": "number"}
+		case lt.Integer != nil:
+			return jsonSchemaOfInteger(lt.Integer)
+		case lt.Unknown != nil:
+			return jsonSchema{"type": "null"}
+		}
+	}
+
+	if ct := t.ConvertedType(); ct != nil {
+		switch *ct {
+		case deprecated.UTF8, deprecated.Enum:
+			return jsonSchema{"type": "string"}
+		}
+	}
+
+	switch t.Kind() {
+	case Boolean:
+		return jsonSchema{"type": "boolean"}
+	case Int32, Int64:
+		return jsonSchema{"type": "integer"}
+	case Int96:
+		return jsonSchema{"type": "string", "format": "date-time"}
+	case Float, Double:
+		return jsonSchema{"type": "number"}
+	default: // ByteArray, FixedLenByteArray
+		return jsonSchema{"type": "string", "contentEncoding": "base64"}
+	}
+}
+
+func jsonSchemaOfInteger(t *format.IntType) jsonSchema {
+	schema := jsonSchema{"type": "integer"}
+	switch {
+	case !t.IsSigned:
+		schema["minimum"] = 0
+		if t.BitWidth < 64 {
+			schema["maximum"] = uint64(1)<<uint(t.BitWidth) - 1
+		}
+	case t.BitWidth < 64:
+		schema["minimum"] = -(int64(1) << uint(t.BitWidth-1))
+		schema["maximum"] = int64(1)<<uint(t.BitWidth-1) - 1
+	default:
+		schema["minimum"] = int64(math.MinInt64)
+		schema["maximum"] = int64(math.MaxInt64)
+	}
+	return schema
+}
+
+// isListGroup returns true if node is a group annotated with the LIST logical
+// type. Column groups of files opened with OpenFile do not expose their logical
+// type through the Type method, so the schema element is inspected instead.
+func isListGroup(node Node) bool {
+	if !hasSingleRepeatedField(node) {
+		return false
+	}
+	if c, ok := node.(*Column); ok {
+		return (c.schema.LogicalType != nil && c.schema.LogicalType.List != nil) ||
+			(c.schema.ConvertedType != nil && *c.schema.ConvertedType == deprecated.List)
+	}
+	return isList(node)
+}
+
+// isMapGroup returns true if node is a group annotated with the MAP logical
+// type; see isListGroup.
+func isMapGroup(node Node) bool {
+	if !hasSingleRepeatedField(node) {
+		return false
+	}
+	if c, ok := node.(*Column); ok {
+		return (c.schema.LogicalType != nil && c.schema.LogicalType.Map != nil) ||
+			(c.schema.ConvertedType != nil && (*c.schema.ConvertedType == deprecated.Map || *c.schema.ConvertedType == deprecated.MapKeyValue))
+	}
+	return isMap(node)
+}
+
+func hasSingleRepeatedField(node Node) bool {
+	fields := node.Fields()
+	return len(fields) == 1 && fields[0].Repeated()
+}
@@ -0,0 +1,85 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestMarshalJSONSchema(t *testing.T) {
+	type Address struct {
+		City string  `parquet:"city"`
+		Zip  *string `parquet:"zip,optional"`
+	}
+
+	type Row struct {
+		ID        uint32           `parquet:"id"`
+		Name      string           `parquet:"name"`
+		Score     *float64         `parquet:"score,optional"`
+		Tags      []string         `parquet:"tags,list"`
+		Phones    []string         `parquet:"phones"`
+		Labels    map[string]int64 `parquet:"labels"`
+		Address   Address          `parquet:"address"`
+		CreatedAt time.Time        `parquet:"created_at,timestamp"`
+		Raw       []byte           `parquet:"raw"`
+	}
+
+	const want = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"address": {
+			"additionalProperties": false,
+			"properties": {
+				"city": {"type": "string"},
+				"zip": {"type": ["string", "null"]}
+			},
+			"required": ["city"],
+			"type": "object"
+		},
+		"created_at": {"format": "date-time", "type": "string"},
+		"id": {"maximum": 4294967295, "minimum": 0, "type": "integer"},
+		"labels": {"additionalProperties": {"maximum": 9223372036854775807, "minimum": -9223372036854775808, "type": "integer"}, "type": "object"},
+		"name": {"type": "string"},
+		"phones": {"items": {"type": "string"}, "type": "array"},
+		"raw": {"contentEncoding": "base64", "type": "string"},
+		"score": {"type": ["number", "null"]},
+		"tags": {"items": {"type": "string"}, "type": "array"}
+	},
+	"required": ["id", "name", "tags", "labels", "address", "created_at", "raw"],
+	"title": "Row",
+	"type": "object"
+}`
+
+	schema := parquet.SchemaOf(Row{})
+
+	f, err := createParquetFile(makeRows([]Row{{}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		scenario string
+		schema   *parquet.Schema
+	}{
+		{scenario: "go struct", schema: schema},
+		{scenario: "file", schema: f.Schema()},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			b, err := parquet.MarshalJSONSchema(test.schema.Name(), test.schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect := new(bytes.Buffer)
+			if err := json.Compact(expect, []byte(want)); err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != expect.String() {
+				t.Errorf("JSON schema mismatch:\nwant: %s\ngot:  %s", expect, b)
+			}
+		})
+	}
+}
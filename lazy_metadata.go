@@ -0,0 +1,282 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// lazyColumnChunk holds the thrift encoding of the metadata of a column chunk,
+// which is decoded when the column chunk is first used if the file was opened
+// with LazyColumnMetadata.
+type lazyColumnChunk struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// decodeFileMetaDataLazily decodes the file metadata from the thrift encoded
+// footer data, leaving the metadata of column chunks zero. The function returns
+// the thrift encoding of each column chunk in each row group, which are slices
+// of data.
+//
+// The footer is scanned once to locate the lists of column chunks of the row
+// groups, which are then replaced with empty lists so the rest of the metadata
+// can be decoded normally.
+func decodeFileMetaDataLazily(protocol thrift.Protocol, data []byte, metadata *format.FileMetaData) ([][][]byte, error) {
+	s := footerScanner{data: data}
+	s.input.Reset(data)
+	s.reader = protocol.NewReader(&s.input)
+
+	if err := s.scanFileMetaData(); err != nil {
+		return nil, err
+	}
+
+	// The compact protocol encodes an empty list of structs with a single
+	// byte holding the size in the high bits and the element type in the
+	// low bits.
+	emptyList := []byte{byte(thrift.STRUCT)}
+	footer := make([]byte, 0, len(data))
+	offset := 0
+	for _, section := range s.columnLists {
+		footer = append(footer, data[offset:section.offset]...)
+		footer = append(footer, emptyList...)
+		offset = section.offset + section.length
+	}
+	footer = append(footer, data[offset:]...)
+
+	if err := thrift.Unmarshal(protocol, footer, metadata); err != nil {
+		return nil, err
+	}
+	if len(metadata.RowGroups) != len(s.columnChunks) {
+		return nil, fmt.Errorf("decoded %d row groups but found %d lists of column chunks", len(metadata.RowGroups), len(s.columnChunks))
+	}
+	for i := range metadata.RowGroups {
+		metadata.RowGroups[i].Columns = make([]format.ColumnChunk, len(s.columnChunks[i]))
+	}
+	return s.columnChunks, nil
+}
+
+// footerScanner walks the thrift encoding of the file metadata to record the
+// location of column chunks, without decoding them.
+type footerScanner struct {
+	data   []byte
+	input  bytes.Reader
+	reader thrift.Reader
+	// Location of the list of column chunks of each row group.
+	columnLists []footerSection
+	// Thrift encoding of the column chunks of each row group.
+	columnChunks [][][]byte
+}
+
+type footerSection struct {
+	offset int
+	length int
+}
+
+func (s *footerScanner) offset() int { return len(s.data) - s.input.Len() }
+
+func (s *footerScanner) scanFileMetaData() error {
+	return s.scanStruct(func(id int16, typ thrift.Type) error {
+		if id != 4 || typ != thrift.LIST { // FileMetaData.RowGroups
+			return s.skip(typ, true)
+		}
+		l, err := s.reader.ReadList()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < int(l.Size); i++ {
+			if err := s.scanRowGroup(); err != nil {
+				return fmt.Errorf("scanning row group %d: %w", i, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *footerScanner) scanRowGroup() error {
+	var columns [][]byte
+	found := false
+
+	err := s.scanStruct(func(id int16, typ thrift.Type) error {
+		if id != 1 || typ != thrift.LIST { // RowGroup.Columns
+			return s.skip(typ, true)
+		}
+		start := s.offset()
+		l, err := s.reader.ReadList()
+		if err != nil {
+			return err
+		}
+		columns = make([][]byte, l.Size)
+		for i := range columns {
+			offset := s.offset()
+			if err := s.skip(thrift.STRUCT, false); err != nil {
+				return fmt.Errorf("scanning column chunk %d: %w", i, err)
+			}
+			columns[i] = s.data[offset:s.offset():s.offset()]
+		}
+		s.columnLists = append(s.columnLists, footerSection{
+			offset: start,
+			length: s.offset() - start,
+		})
+		found = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("missing list of column chunks")
+	}
+	s.columnChunks = append(s.columnChunks, columns)
+	return nil
+}
+
+func (s *footerScanner) scanStruct(do func(id int16, typ thrift.Type) error) error {
+	lastID := int16(0)
+	for {
+		f, err := s.reader.ReadField()
+		if err != nil {
+			return err
+		}
+		if f.Type == thrift.STOP {
+			return nil
+		}
+		if f.Delta {
+			f.ID += lastID
+		}
+		if err := do(f.ID, f.Type); err != nil {
+			return err
+		}
+		lastID = f.ID
+	}
+}
+
+// skip skips a value of the given type. In the compact protocol, boolean
+// fields of structs are encoded in the field type and have no value, while
+// booleans in collections are encoded in one byte.
+func (s *footerScanner) skip(typ thrift.Type, field bool) (err error) {
+	switch typ {
+	case thrift.TRUE, thrift.FALSE:
+		if !field {
+			_, err = s.reader.ReadBool()
+		}
+	case thrift.I8:
+		_, err = s.reader.ReadInt8()
+	case thrift.I16:
+		_, err = s.reader.ReadInt16()
+	case thrift.I32:
+		_, err = s.reader.ReadInt32()
+	case thrift.I64:
+		_, err = s.reader.ReadInt64()
+	case thrift.DOUBLE:
+		_, err = s.reader.ReadFloat64()
+	case thrift.BINARY:
+		var n int
+		if n, err = s.reader.ReadLength(); err == nil {
+			_, err = s.input.Seek(int64(n), io.SeekCurrent)
+		}
+	case thrift.LIST, thrift.SET:
+		var l thrift.List
+		if l, err = s.reader.ReadList(); err == nil {
+			for i := 0; i < int(l.Size) && err == nil; i++ {
+				err = s.skip(l.Type, false)
+			}
+		}
+	case thrift.MAP:
+		var m thrift.Map
+		if m, err = s.reader.ReadMap(); err == nil {
+			for i := 0; i < int(m.Size) && err == nil; i++ {
+				if err = s.skip(m.Key, false); err == nil {
+					err = s.skip(m.Value, false)
+				}
+			}
+		}
+	case thrift.STRUCT:
+		err = s.scanStruct(func(_ int16, typ thrift.Type) error { return s.skip(typ, true) })
+	default:
+		err = fmt.Errorf("skipping unsupported thrift type %d", typ)
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// load decodes the metadata of the column chunk if it was not decoded yet,
+// along with its page index and bloom filter. The method is a no-op if the
+// file was not opened with LazyColumnMetadata.
+func (c *fileColumnChunk) load() error {
+	if c.lazy == nil {
+		return nil
+	}
+	c.lazy.once.Do(func() { c.lazy.err = c.decodeLazy() })
+	return c.lazy.err
+}
+
+func (c *fileColumnChunk) decodeLazy() error {
+	f := c.file
+	if err := thrift.Unmarshal(&f.protocol, c.lazy.data, c.chunk); err != nil {
+		return fmt.Errorf("decoding metadata of column %q: %w", c.column.path, err)
+	}
+
+	if !f.config.SkipPageIndex {
+		if offset, length := c.chunk.ColumnIndexOffset, c.chunk.ColumnIndexLength; offset > 0 {
+			columnIndex := new(format.ColumnIndex)
+			if err := f.readIndex(offset, length, columnIndex); err != nil {
+				return fmt.Errorf("reading column index of column %q: %w", c.column.path, err)
+			}
+			c.columnIndex = columnIndex
+		}
+		if offset, length := c.chunk.OffsetIndexOffset, c.chunk.OffsetIndexLength; offset > 0 {
+			offsetIndex := new(format.OffsetIndex)
+			if err := f.readIndex(offset, length, offsetIndex); err != nil {
+				return fmt.Errorf("reading offset index of column %q: %w", c.column.path, err)
+			}
+			c.offsetIndex = offsetIndex
+		}
+	}
+
+	if !f.config.SkipBloomFilters && c.chunk.MetaData.BloomFilterOffset > 0 {
+		section := io.NewSectionReader(f.reader, 0, f.size)
+		rbuf, rbufpool := getBufioReader(section, f.config.ReadBufferSize)
+		defer putBufioReader(rbuf, rbufpool)
+		decoder := thrift.NewDecoder(f.protocol.NewReader(rbuf))
+		return c.readBloomFilter(section, rbuf, decoder)
+	}
+	return nil
+}
+
+func (f *File) readIndex(offset int64, length int32, index interface{}) error {
+	data := make([]byte, length)
+	if _, err := f.readAt(data, offset); err != nil {
+		return err
+	}
+	return thrift.Unmarshal(&f.protocol, data, index)
+}
+
+// loadColumnChunks decodes the metadata of all the column chunks of the file
+// which were not decoded yet, returning the first error that occurred.
+func (f *File) loadColumnChunks() error {
+	var firstErr error
+	for _, rowGroup := range f.rowGroups {
+		for _, columnChunk := range rowGroup.(*fileRowGroup).columns {
+			if err := columnChunk.(*fileColumnChunk).load(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// errorPages is an implementation of Pages which returns the same error on
+// every call, used when the pages of a column chunk cannot be read.
+type errorPages struct{ err error }
+
+func (p *errorPages) ReadPage() (Page, error) { return nil, p.err }
+func (p *errorPages) SeekToRow(int64) error   { return p.err }
+func (p *errorPages) Close() error            { return nil }
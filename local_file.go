@@ -0,0 +1,62 @@
+package parquet
+
+import (
+	"os"
+	"syscall"
+)
+
+// LocalFile is an io.ReaderAt reading parquet files from the local file system.
+//
+// Reads are positional (pread), they do not share a file offset, which allows
+// goroutines to read column chunks of the same file concurrently without being
+// serialized. On Linux, LocalFile also implements the read hint methods
+// documented on OpenFile, and advises the kernel to read ahead the sections of
+// the file which are about to be read (posix_fadvise with POSIX_FADV_WILLNEED),
+// overlapping the I/O of column chunks with the decoding of pages.
+//
+// LocalFile has a Size method, so it can be passed directly to the functions
+// constructing readers, for example:
+//
+//	f, err := parquet.OpenLocalFile("data.parquet")
+//	if err != nil {
+//		...
+//	}
+//	defer f.Close()
+//
+//	reader := parquet.NewGenericReader[RowType](f)
+type LocalFile struct {
+	file *os.File
+	conn syscall.RawConn
+	size int64
+}
+
+// OpenLocalFile opens the file at path for reading.
+func OpenLocalFile(path string) (*LocalFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	conn, err := file.SyscallConn()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &LocalFile{file: file, conn: conn, size: s.Size()}, nil
+}
+
+// ReadAt reads len(b) bytes from the file starting at offset off.
+func (f *LocalFile) ReadAt(b []byte, off int64) (int, error) { return f.file.ReadAt(b, off) }
+
+// Size returns the size of the file, as observed when it was opened.
+func (f *LocalFile) Size() int64 { return f.size }
+
+// Name returns the path of the file.
+func (f *LocalFile) Name() string { return f.file.Name() }
+
+// Close closes the file.
+func (f *LocalFile) Close() error { return f.file.Close() }
@@ -0,0 +1,18 @@
+package parquet
+
+import "golang.org/x/sys/unix"
+
+func (f *LocalFile) SetMagicFooterSection(offset, length int64) { f.willNeed(offset, length) }
+func (f *LocalFile) SetFooterSection(offset, length int64)      { f.willNeed(offset, length) }
+func (f *LocalFile) SetColumnIndexSection(offset, length int64) { f.willNeed(offset, length) }
+func (f *LocalFile) SetOffsetIndexSection(offset, length int64) { f.willNeed(offset, length) }
+func (f *LocalFile) SetBloomFilterSection(offset, length int64) { f.willNeed(offset, length) }
+func (f *LocalFile) SetColumnChunkSection(offset, length int64) { f.willNeed(offset, length) }
+
+// willNeed advises the kernel to read ahead the section of the file. The advice
+// is only a hint, errors are ignored since reads remain correct without it.
+func (f *LocalFile) willNeed(offset, length int64) {
+	f.conn.Control(func(fd uintptr) {
+		unix.Fadvise(int(fd), offset, length, unix.FADV_WILLNEED)
+	})
+}
@@ -0,0 +1,70 @@
+package parquet_test
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestLocalFile(t *testing.T) {
+	type Row struct {
+		A int64  `parquet:"a"`
+		B string `parquet:"b"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{A: int64(i), B: "b"}
+	}
+
+	path := filepath.Join(t.TempDir(), "file.parquet")
+	if err := parquet.WriteFile(path, rows, parquet.MaxRowsPerRowGroup(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenLocalFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	file, err := parquet.OpenFile(f, f.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rowGroups := file.RowGroups()
+	if len(rowGroups) != 10 {
+		t.Fatalf("want=10 got=%d", len(rowGroups))
+	}
+
+	// Row groups are read concurrently from the same file.
+	got := make([][]Row, len(rowGroups))
+	errs := make([]error, len(rowGroups))
+	wg := sync.WaitGroup{}
+	for i, rowGroup := range rowGroups {
+		wg.Add(1)
+		go func(i int, rowGroup parquet.RowGroup) {
+			defer wg.Done()
+			reader := parquet.NewGenericRowGroupReader[Row](rowGroup)
+			defer reader.Close()
+			got[i] = make([]Row, rowGroup.NumRows())
+			_, errs[i] = reader.Read(got[i])
+		}(i, rowGroup)
+	}
+	wg.Wait()
+
+	for i := range rowGroups {
+		if errs[i] != nil && !errors.Is(errs[i], io.EOF) {
+			t.Fatalf("row group %d: %v", i, errs[i])
+		}
+		if want := rows[i*10 : (i+1)*10]; !reflect.DeepEqual(want, got[i]) {
+			t.Errorf("row group %d: want=%+v got=%+v", i, want, got[i])
+		}
+	}
+}
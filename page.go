@@ -100,6 +100,10 @@ type PageWriter interface {
 
 // Pages is an interface implemented by page readers returned by calling the
 // Pages method of ColumnChunk instances.
+//
+// Pages are read in a deterministic order. Calling SeekToRow(0) rewinds the
+// reader to the first page, which allows programs to make multiple passes over
+// the pages of a column chunk without having to call Pages again.
 type Pages interface {
 	PageReader
 	RowSeeker
@@ -585,6 +589,10 @@ func (page *booleanPage) Bounds() (min, max Value, ok bool) {
 }
 
 func (page *booleanPage) Slice(i, j int64) Page {
+	// The bits of the page may start at an offset within the first byte when
+	// the page was already sliced.
+	i += int64(page.offset)
+	j += int64(page.offset)
 	off := i / 8
 	end := j / 8
 
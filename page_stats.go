@@ -0,0 +1,163 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// PageStats carries the statistics of a data page in a column chunk.
+type PageStats struct {
+	// Index of the first row of the page in its row group, or -1 if it is not
+	// known.
+	FirstRowIndex int64
+	// Number of values in the page, including nulls, or -1 if it is not known.
+	NumValues int64
+	// Number of null values in the page.
+	NullCount int64
+	// Minimum and maximum values of the page. Both are null values when the
+	// statistics are not available or the page only contains nulls.
+	MinValue Value
+	MaxValue Value
+}
+
+// ReadPageStats returns the statistics of each data page of the column chunk
+// passed as argument, allowing programs to skip pages which cannot contain the
+// values they are looking for.
+//
+// The statistics are taken from the column index of the chunk when it exists.
+// Otherwise, when the column chunk was read from a file, the page headers are
+// read to collect the statistics that they embed (see DataPageStatistics),
+// without reading the content of the pages. For other column chunks, the
+// statistics are computed by reading the pages.
+func ReadPageStats(columnChunk ColumnChunk) ([]PageStats, error) {
+	if columnIndex := columnChunk.ColumnIndex(); columnIndex != nil {
+		return columnIndexPageStats(columnIndex, columnChunk.OffsetIndex()), nil
+	}
+	if c, ok := columnChunk.(*fileColumnChunk); ok {
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+		pages := new(filePages)
+		pages.init(c)
+		defer pages.Close()
+		return pages.readPageStats()
+	}
+	return readPageStats(columnChunk.Pages())
+}
+
+func columnIndexPageStats(columnIndex ColumnIndex, offsetIndex OffsetIndex) []PageStats {
+	stats := make([]PageStats, columnIndex.NumPages())
+	for i := range stats {
+		stats[i] = PageStats{
+			FirstRowIndex: -1,
+			NumValues:     -1,
+			NullCount:     columnIndex.NullCount(i),
+			MinValue:      columnIndex.MinValue(i),
+			MaxValue:      columnIndex.MaxValue(i),
+		}
+		if offsetIndex != nil && i < offsetIndex.NumPages() {
+			stats[i].FirstRowIndex = offsetIndex.FirstRowIndex(i)
+		}
+	}
+	return stats
+}
+
+func readPageStats(pages Pages) ([]PageStats, error) {
+	defer pages.Close()
+	var stats []PageStats
+	var rowIndex int64
+
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				return stats, nil
+			}
+			return stats, err
+		}
+		s := PageStats{
+			FirstRowIndex: rowIndex,
+			NumValues:     p.NumValues(),
+			NullCount:     p.NumNulls(),
+		}
+		if minValue, maxValue, ok := p.Bounds(); ok {
+			s.MinValue, s.MaxValue = minValue.Clone(), maxValue.Clone()
+		}
+		rowIndex += p.NumRows()
+		stats = append(stats, s)
+		Release(p)
+	}
+}
+
+// readPageStats reads the statistics embedded in the page headers of the
+// column chunk, skipping the content of the pages.
+func (f *filePages) readPageStats() ([]PageStats, error) {
+	var stats []PageStats
+	var rowIndex int64
+	kind := f.chunk.column.Type().Kind()
+	repeated := f.chunk.column.MaxRepetitionLevel() > 0
+
+	for {
+		header := new(format.PageHeader)
+		if err := f.decodeHeader(header); err != nil {
+			if err == io.EOF {
+				return stats, nil
+			}
+			return stats, fmt.Errorf("reading page header %d of column %q: %w", len(stats), f.columnPath(), err)
+		}
+		if _, err := f.rbuf.Discard(int(header.CompressedPageSize)); err != nil {
+			return stats, fmt.Errorf("skipping page %d of column %q: %w", len(stats), f.columnPath(), err)
+		}
+
+		var s PageStats
+		var statistics *format.Statistics
+		var numRows int64
+
+		switch header.Type {
+		case format.DataPage:
+			h := header.DataPageHeader
+			if h == nil {
+				return stats, ErrMissingPageHeader
+			}
+			s.NumValues = int64(h.NumValues)
+			statistics = &h.Statistics
+			// Data pages in version 1 do not record the number of rows, which
+			// is only known for columns which are not repeated.
+			numRows = -1
+			if !repeated {
+				numRows = s.NumValues
+			}
+		case format.DataPageV2:
+			h := header.DataPageHeaderV2
+			if h == nil {
+				return stats, ErrMissingPageHeader
+			}
+			s.NumValues = int64(h.NumValues)
+			s.NullCount = int64(h.NumNulls)
+			statistics = &h.Statistics
+			numRows = int64(h.NumRows)
+		default:
+			continue
+		}
+
+		s.FirstRowIndex = rowIndex
+		if s.NullCount == 0 {
+			s.NullCount = statistics.NullCount
+		}
+		// The deprecated min and max fields are ignored since they may not be
+		// ordered according to the logical type of the column.
+		if statistics.MinValue != nil && statistics.MaxValue != nil {
+			s.MinValue = kind.Value(statistics.MinValue)
+			s.MaxValue = kind.Value(statistics.MaxValue)
+		}
+		stats = append(stats, s)
+
+		if rowIndex >= 0 && numRows >= 0 {
+			rowIndex += numRows
+		} else {
+			rowIndex = -1
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package parquet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReadPageStats(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Name *string `parquet:"name,optional"`
+	}
+
+	names := []string{"alpha", "bravo", "charlie"}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		if i%3 != 0 {
+			rows[i].Name = &names[i%3]
+		}
+	}
+
+	for _, dataPageVersion := range []int{1, 2} {
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewGenericWriter[Row](buffer,
+			parquet.PageBufferSize(1024),
+			parquet.DataPageStatistics(true),
+			parquet.DataPageVersion(dataPageVersion),
+		)
+		if _, err := writer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, skipPageIndex := range []bool{false, true} {
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.SkipPageIndex(skipPageIndex))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			id := f.RowGroups()[0].ColumnChunks()[0]
+			stats, err := parquet.ReadPageStats(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stats) < 2 {
+				t.Fatalf("expected multiple pages: got=%d", len(stats))
+			}
+
+			firstRowIndex := int64(0)
+			for i, s := range stats {
+				if s.FirstRowIndex != firstRowIndex {
+					t.Errorf("page %d: first row index mismatch: want=%d got=%d", i, firstRowIndex, s.FirstRowIndex)
+				}
+				if s.MinValue.Int64() != firstRowIndex {
+					t.Errorf("page %d: min value mismatch: want=%d got=%v", i, firstRowIndex, s.MinValue)
+				}
+				if i+1 < len(stats) {
+					firstRowIndex = stats[i+1].FirstRowIndex
+				} else {
+					firstRowIndex = int64(len(rows))
+				}
+				if s.MaxValue.Int64() != firstRowIndex-1 {
+					t.Errorf("page %d: max value mismatch: want=%d got=%v", i, firstRowIndex-1, s.MaxValue)
+				}
+			}
+
+			name := f.RowGroups()[0].ColumnChunks()[1]
+			stats, err = parquet.ReadPageStats(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			numNulls := int64(0)
+			for i, s := range stats {
+				numNulls += s.NullCount
+				if string(s.MinValue.ByteArray()) != "bravo" || string(s.MaxValue.ByteArray()) != "charlie" {
+					t.Errorf("page %d: bounds mismatch: want=[bravo,charlie] got=[%v,%v]", i, s.MinValue, s.MaxValue)
+				}
+			}
+			if numNulls != 334 {
+				t.Errorf("null count mismatch: want=334 got=%d", numNulls)
+			}
+		}
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	buffer.Write(rows)
+	stats, err := parquet.ReadPageStats(buffer.ColumnChunks()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0].FirstRowIndex != 0 || stats[0].MinValue.Int64() != 0 || stats[0].MaxValue.Int64() != int64(len(rows)-1) {
+		t.Errorf("buffer page stats mismatch: %+v", stats)
+	}
+}
@@ -497,3 +497,31 @@ func TestRepeatedPageTrailingNulls(t *testing.T) {
 		t.Errorf("wrong number of rows read: got=%d want=%d", n, len(records))
 	}
 }
+
+func TestBooleanPageSliceOfSlice(t *testing.T) {
+	values := make([]bool, 100)
+	for i := range values {
+		values[i] = i%7 == 0
+	}
+
+	typ := parquet.BooleanType
+	buf := typ.NewColumnBuffer(0, len(values))
+	if _, err := buf.(parquet.BooleanWriter).WriteBooleans(values); err != nil {
+		t.Fatal(err)
+	}
+
+	page := buf.Page().Slice(3, 90).Slice(5, 60)
+	got := make([]parquet.Value, page.NumValues())
+	n, err := page.Values().ReadValues(got)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if n != len(got) {
+		t.Fatalf("wrong number of values: want=%d got=%d", len(got), n)
+	}
+	for i, v := range got {
+		if want := values[8+i]; v.Boolean() != want {
+			t.Fatalf("wrong value at index %d: want=%t got=%t", i, want, v.Boolean())
+		}
+	}
+}
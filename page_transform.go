@@ -0,0 +1,70 @@
+package parquet
+
+// PageTransform is an interface implemented by types which transform the
+// content of the pages of a column, for example to encrypt or tokenize data
+// with mechanisms that the parquet format does not support natively.
+//
+// When writing, transforms are applied to the content of pages after it was
+// compressed; when reading, transforms are reversed before the content of pages
+// is decompressed. The sizes and checksums recorded in page headers are those
+// of the transformed content.
+//
+// Writers do not record the min and max values of transformed columns in the
+// statistics of pages and column chunks, and do not write their column index,
+// since those would expose the values of the columns. The page headers, offset
+// index, and bloom filters are written as usual; programs which need to protect
+// the values of columns should not enable bloom filters on transformed columns.
+type PageTransform interface {
+	// Returns the path of the column that the transform applies to.
+	Path() []string
+
+	// Appends the transformed content of the page src to dst, returning the
+	// resulting slice.
+	Encode(dst, src []byte) ([]byte, error)
+
+	// Reverses Encode, appending the original content of the page src to dst
+	// and returning the resulting slice.
+	Decode(dst, src []byte) ([]byte, error)
+}
+
+// PageTransforms is a writer and file configuration option which sets the
+// transforms applied to the pages of columns.
+//
+// Files written with page transforms can only be read when opened with the
+// same transforms, for example:
+//
+//	transforms := parquet.PageTransforms{encryptColumn("ssn")}
+//	writer := parquet.NewGenericWriter[Row](output, transforms)
+//	...
+//	f, err := parquet.OpenFile(input, size, transforms)
+//
+// Defaults to no transforms.
+type PageTransforms []PageTransform
+
+// ConfigureWriter satisfies the WriterOption interface.
+func (t PageTransforms) ConfigureWriter(config *WriterConfig) { config.PageTransforms = t }
+
+// ConfigureFile satisfies the FileOption interface.
+func (t PageTransforms) ConfigureFile(config *FileConfig) { config.PageTransforms = t }
+
+func searchPageTransform(transforms []PageTransform, path columnPath) PageTransform {
+	for _, t := range transforms {
+		if path.equal(t.Path()) {
+			return t
+		}
+	}
+	return nil
+}
+
+// decodePage reverses the transform applied to the content of a page, returning
+// a new buffer holding the original content.
+func decodePage(pool *bufferPool, transform PageTransform, page *buffer) (*buffer, error) {
+	decoded := pool.get(len(page.data))
+	data, err := transform.Decode(decoded.data[:0], page.data)
+	if err != nil {
+		decoded.unref()
+		return nil, err
+	}
+	decoded.data = data
+	return decoded, nil
+}
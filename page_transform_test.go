@@ -0,0 +1,120 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// xorTransform is a page transform which prefixes pages with a marker and
+// inverts their bytes, changing both their size and content.
+type xorTransform []string
+
+func (t xorTransform) Path() []string { return t }
+
+func (t xorTransform) Encode(dst, src []byte) ([]byte, error) {
+	dst = append(dst, 0xA5)
+	for _, b := range src {
+		dst = append(dst, ^b)
+	}
+	return dst, nil
+}
+
+func (t xorTransform) Decode(dst, src []byte) ([]byte, error) {
+	if len(src) == 0 || src[0] != 0xA5 {
+		return dst, fmt.Errorf("missing page transform marker")
+	}
+	for _, b := range src[1:] {
+		dst = append(dst, ^b)
+	}
+	return dst, nil
+}
+
+func TestPageTransforms(t *testing.T) {
+	type Row struct {
+		ID     int64    `parquet:"id"`
+		Secret string   `parquet:"secret,dict,zstd"`
+		Tags   []string `parquet:"tags,list"`
+		Note   *string  `parquet:"note,optional"`
+	}
+
+	note := "transparent-note"
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			ID:     int64(i),
+			Secret: fmt.Sprintf("confidential-%d", i%10),
+			Tags:   []string{"hidden-tag", "another-tag"}[:i%3],
+		}
+		if i%2 == 0 {
+			rows[i].Note = &note
+		}
+	}
+
+	transforms := parquet.PageTransforms{
+		xorTransform{"secret"},
+		xorTransform{"tags", "list", "element"},
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			writer := parquet.NewGenericWriter[Row](buffer,
+				transforms,
+				parquet.DataPageVersion(version),
+				parquet.PageBufferSize(256),
+				parquet.BloomFilters(parquet.SplitBlockFilter(10, "secret")),
+			)
+			if _, err := writer.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			data := buffer.Bytes()
+			for _, secret := range []string{"confidential-", "hidden-tag"} {
+				if bytes.Contains(data, []byte(secret)) {
+					t.Errorf("transformed value %q found in the file", secret)
+				}
+			}
+			if !bytes.Contains(data, []byte(note)) {
+				t.Errorf("value %q of a column without transform not found in the file", note)
+			}
+
+			if _, err := parquet.Read[Row](bytes.NewReader(data), int64(len(data))); err == nil {
+				t.Error("reading transformed pages without the transforms must fail")
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), transforms)
+			if err != nil {
+				t.Fatal(err)
+			}
+			secret := f.Metadata().RowGroups[0].Columns[1]
+			if secret.ColumnIndexOffset != 0 || secret.MetaData.Statistics.MaxValue != nil {
+				t.Error("bounds of a transformed column were written to the file")
+			}
+
+			reader := parquet.NewGenericReader[Row](f)
+			defer reader.Close()
+
+			values := make([]Row, len(rows))
+			if n, err := reader.Read(values); n != len(rows) {
+				t.Fatalf("reading rows: n=%d err=%v", n, err)
+			}
+			for i := range rows {
+				if !reflect.DeepEqual(values[i], rows[i]) {
+					t.Fatalf("row %d mismatch: want=%+v got=%+v", i, rows[i], values[i])
+				}
+			}
+
+			bloomFilter := f.RowGroups()[0].ColumnChunks()[1].BloomFilter()
+			if ok, err := bloomFilter.Check(parquet.ValueOf("confidential-3")); err != nil || !ok {
+				t.Errorf("bloom filter check failed: ok=%t err=%v", ok, err)
+			}
+		})
+	}
+}
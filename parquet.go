@@ -5,6 +5,20 @@
 // https://blog.twitter.com/engineering/en_us/a/2013/dremel-made-simple-with-parquet
 //
 // Or see the Parquet documentation: https://parquet.apache.org/docs/
+//
+// This package is the high level interface of the library. Programs building
+// custom readers or writers may use the lower level packages that it is built
+// upon directly:
+//
+//   - format declares the thrift structures of file metadata and page headers,
+//     which can be decoded with github.com/segmentio/encoding/thrift.
+//   - encoding and its sub-packages implement the encodings of values and
+//     levels (PLAIN, RLE, DELTA_*, BYTE_STREAM_SPLIT, ...).
+//   - compress and its sub-packages implement the compression codecs.
+//   - bloom implements the split block bloom filters of column chunks.
+//
+// Schemas are represented by the Node, Type, and Schema types of this package,
+// since the values, pages, and buffers of columns depend on them.
 package parquet
 
 import (
@@ -68,11 +82,10 @@ func ReadFile[T any](path string, options ...ReaderOption) (rows []T, err error)
 // This function is provided for convenience to facilitate the creation of
 // parquet files.
 func Write[T any](w io.Writer, rows []T, options ...WriterOption) error {
-	config, err := NewWriterConfig(options...)
+	writer, err := newGenericWriter[T](w, options...)
 	if err != nil {
 		return err
 	}
-	writer := NewGenericWriter[T](w, config)
 	if _, err := writer.Write(rows); err != nil {
 		return err
 	}
@@ -0,0 +1,134 @@
+package parquet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Project returns a conversion of rows of schema to a flat schema where leaf
+// columns nested in groups are projected as top-level columns, for example to
+// let consumers which do not support nested data read files that have some:
+//
+//	conv, err := parquet.Project(file.Schema(), map[string]string{
+//		"id":  "id",
+//		"zip": "user.address.zip",
+//	})
+//	...
+//	rows := parquet.ConvertRowReader(file.RowGroups()[0].Rows(), conv)
+//
+// The keys of the columns map are the names of the top-level columns of the
+// projection, and the values are the dot-separated paths of the leaf columns
+// of schema that they read from. Like in all groups, the columns are ordered
+// by name in the schema of the projection.
+//
+// The definition levels of values are re-based on the projected columns: a
+// column is optional when the leaf column or any of its parent groups is
+// optional, in which case its values are null when the leaf column or any of
+// its parents is null, and required otherwise. Leaf columns nested in repeated
+// fields cannot be projected since their values cannot be represented as a
+// single value per row.
+//
+// The conversion only applies to rows, which makes the projection read-only;
+// column chunks of row groups converted with ConvertRowGroup still hold the
+// levels of the original schema.
+func Project(schema *Schema, columns map[string]string) (Conversion, error) {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	group := make(Group, len(columns))
+	projected := make([]projectedColumn, len(names))
+	numSourceColumns := int(numLeafColumnsOf(schema))
+
+	for i, name := range names {
+		path := columns[name]
+		leaf, ok := schema.Lookup(strings.Split(path, ".")...)
+		if !ok {
+			return nil, fmt.Errorf("cannot project column %q which is not a leaf column of the schema", path)
+		}
+		if leaf.MaxRepetitionLevel > 0 {
+			return nil, fmt.Errorf("cannot project column %q which is nested in a repeated field", path)
+		}
+		if leaf.MaxDefinitionLevel > 0 {
+			group[name] = Optional(leaf.Node)
+		} else {
+			group[name] = Required(leaf.Node)
+		}
+		projected[i] = projectedColumn{
+			sourceIndex:        leaf.ColumnIndex,
+			maxDefinitionLevel: byte(leaf.MaxDefinitionLevel),
+		}
+	}
+
+	return &projection{
+		schema:           NewSchema(schema.Name(), group),
+		columns:          projected,
+		numSourceColumns: numSourceColumns,
+	}, nil
+}
+
+type projection struct {
+	schema           *Schema
+	columns          []projectedColumn
+	numSourceColumns int
+	buffers          sync.Pool // *projectionBuffer
+}
+
+type projectedColumn struct {
+	sourceIndex        int
+	maxDefinitionLevel byte
+}
+
+type projectionBuffer struct {
+	// Index of the value of each source column in the row being converted,
+	// or -1 if the row has no value for the column.
+	indexes []int
+	values  []Value
+}
+
+func (p *projection) Convert(rows []Row) (int, error) {
+	b, _ := p.buffers.Get().(*projectionBuffer)
+	if b == nil {
+		b = &projectionBuffer{indexes: make([]int, p.numSourceColumns)}
+	}
+	defer p.buffers.Put(b)
+
+	for n, row := range rows {
+		for i := range b.indexes {
+			b.indexes[i] = -1
+		}
+		for i, v := range row {
+			if columnIndex := v.Column(); columnIndex >= 0 && columnIndex < len(b.indexes) {
+				b.indexes[columnIndex] = i
+			}
+		}
+
+		b.values = b.values[:0]
+		for columnIndex, c := range p.columns {
+			v := Value{}
+			if i := b.indexes[c.sourceIndex]; i >= 0 {
+				v = row[i]
+			}
+			definitionLevel := 0
+			if v.definitionLevel != c.maxDefinitionLevel || v.IsNull() {
+				// The leaf column or one of its parent groups is null.
+				v = Value{}
+			} else if c.maxDefinitionLevel > 0 {
+				definitionLevel = 1
+			}
+			b.values = append(b.values, v.Level(0, definitionLevel, columnIndex))
+		}
+
+		rows[n] = append(row[:0], b.values...)
+	}
+
+	return len(rows), nil
+}
+
+func (p *projection) Column(i int) int { return p.columns[i].sourceIndex }
+
+func (p *projection) Schema() *Schema { return p.schema }
@@ -0,0 +1,92 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestProject(t *testing.T) {
+	type Address struct {
+		City string  `parquet:"city"`
+		Zip  *string `parquet:"zip,optional"`
+	}
+	type User struct {
+		Name    string   `parquet:"name"`
+		Address *Address `parquet:"address,optional"`
+	}
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		User User     `parquet:"user"`
+		Tags []string `parquet:"tags,list"`
+	}
+	type Flat struct {
+		City *string `parquet:"city,optional"`
+		ID   int64   `parquet:"id"`
+		Name string  `parquet:"name"`
+		Zip  *string `parquet:"zip,optional"`
+	}
+
+	zip := "94110"
+	rows := []Row{
+		{ID: 1, User: User{Name: "a", Address: &Address{City: "SF", Zip: &zip}}, Tags: []string{"x"}},
+		{ID: 2, User: User{Name: "b", Address: &Address{City: "LA"}}},
+		{ID: 3, User: User{Name: "c"}, Tags: []string{"y", "z"}},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conv, err := parquet.Project(f.Schema(), map[string]string{
+		"id":   "id",
+		"name": "user.name",
+		"city": "user.address.city",
+		"zip":  "user.address.zip",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantFields, gotFields := parquet.SchemaOf(Flat{}).Fields(), conv.Schema().Fields()
+	if len(wantFields) != len(gotFields) {
+		t.Fatalf("number of fields mismatch: want=%d got=%d", len(wantFields), len(gotFields))
+	}
+	for i, want := range wantFields {
+		got := gotFields[i]
+		if want.Name() != got.Name() || want.Optional() != got.Optional() || want.Type().Kind() != got.Type().Kind() {
+			t.Errorf("field %d mismatch:\nwant = %s %s optional=%t\ngot  = %s %s optional=%t", i,
+				want.Name(), want.Type(), want.Optional(), got.Name(), got.Type(), got.Optional())
+		}
+	}
+
+	reader := parquet.NewGenericRowGroupReader[Flat](parquet.ConvertRowGroup(f.RowGroups()[0], conv))
+	values := make([]Flat, len(rows))
+	n, err := reader.Read(values)
+	if n != len(rows) {
+		t.Fatalf("number of rows mismatch: want=%d got=%d (%v)", len(rows), n, err)
+	}
+
+	city := func(s string) *string { return &s }
+	expected := []Flat{
+		{ID: 1, Name: "a", City: city("SF"), Zip: &zip},
+		{ID: 2, Name: "b", City: city("LA")},
+		{ID: 3, Name: "c"},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", expected, values)
+	}
+
+	for _, path := range []string{"tags.list.element", "user", "user.phone"} {
+		if _, err := parquet.Project(f.Schema(), map[string]string{"x": path}); err == nil {
+			t.Errorf("expected an error when projecting column %q", path)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+// Package protobuf implements an adapter to hydrate Protocol Buffers messages
+// from parquet rows.
+//
+// Fields of the messages are matched with the columns of the parquet schema by
+// name: a parquet field is assigned to the protobuf field of the same name, or
+// the same JSON name. Programs can override the name of the parquet field that
+// a protobuf field is read from with the MapField option. Fields that exist in
+// only one of the protobuf message or parquet schema are ignored.
+//
+// Repeated protobuf fields may be read from repeated parquet fields or from
+// columns annotated with the LIST logical type, and map fields from columns
+// annotated with the MAP logical type. Integer columns annotated with the
+// TIMESTAMP logical type can be read into google.protobuf.Timestamp fields.
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	defaultRowBufferSize = 42
+
+	timestampFullName protoreflect.FullName = "google.protobuf.Timestamp"
+)
+
+// Option is an interface implemented by types that carry configuration
+// options for decoders and readers.
+type Option interface {
+	configure(*Decoder)
+}
+
+type option func(*Decoder)
+
+func (opt option) configure(d *Decoder) { opt(d) }
+
+// MapField configures the decoder to read the protobuf field with the given
+// full name (e.g. "example.User.user_id") from the parquet field named column
+// in the group that corresponds to the field's message.
+func MapField(field protoreflect.FullName, column string) Option {
+	return option(func(d *Decoder) { d.mapping[field] = column })
+}
+
+// Decoder hydrates Protocol Buffers messages from rows of a parquet schema.
+//
+// Decoder values are safe to use concurrently from multiple goroutines.
+type Decoder struct {
+	schema  *parquet.Schema
+	mapping map[protoreflect.FullName]string
+}
+
+// NewDecoder constructs a decoder of rows of the given schema.
+func NewDecoder(schema *parquet.Schema, options ...Option) *Decoder {
+	d := &Decoder{
+		schema:  schema,
+		mapping: make(map[protoreflect.FullName]string),
+	}
+	for _, opt := range options {
+		opt.configure(d)
+	}
+	return d
+}
+
+// Schema returns the parquet schema of rows decoded by d.
+func (d *Decoder) Schema() *parquet.Schema { return d.schema }
+
+// Decode sets the fields of msg to the values of the given row.
+//
+// The message is not reset prior to decoding the row; programs that reuse
+// messages should call proto.Reset first.
+func (d *Decoder) Decode(row parquet.Row, msg proto.Message) error {
+	var value any
+	if err := d.schema.Reconstruct(&value, row); err != nil {
+		return err
+	}
+	group, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("cannot decode parquet row of type %T into protobuf message", value)
+	}
+	return d.decodeMessage(msg.ProtoReflect(), d.schema, group)
+}
+
+func (d *Decoder) decodeMessage(m protoreflect.Message, node parquet.Node, values map[string]any) error {
+	fields := m.Descriptor().Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		column := d.lookupField(node, field)
+		if column == nil {
+			continue
+		}
+		value := values[column.Name()]
+		if value == nil {
+			continue
+		}
+		if err := d.decodeField(m, field, column, value); err != nil {
+			return fmt.Errorf("%s: %w", field.FullName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Decoder) lookupField(node parquet.Node, field protoreflect.FieldDescriptor) parquet.Field {
+	if name, ok := d.mapping[field.FullName()]; ok {
+		return fieldByName(node, name)
+	}
+	if f := fieldByName(node, string(field.Name())); f != nil {
+		return f
+	}
+	return fieldByName(node, field.JSONName())
+}
+
+func (d *Decoder) decodeField(m protoreflect.Message, field protoreflect.FieldDescriptor, column parquet.Node, value any) error {
+	switch {
+	case field.IsMap():
+		return d.decodeMap(m.Mutable(field).Map(), field, column, value)
+	case field.IsList():
+		return d.decodeList(m.Mutable(field).List(), field, column, value)
+	default:
+		if column.Repeated() {
+			return errors.New("cannot decode repeated parquet field into singular protobuf field")
+		}
+		v, err := d.decodeValue(m.NewField(field), field, column, value)
+		if err != nil {
+			return err
+		}
+		m.Set(field, v)
+		return nil
+	}
+}
+
+func (d *Decoder) decodeList(list protoreflect.List, field protoreflect.FieldDescriptor, column parquet.Node, value any) error {
+	elements, element, err := listElements(column, value)
+	if err != nil {
+		return err
+	}
+	for _, elem := range elements {
+		if elem == nil {
+			return errors.New("cannot decode null parquet list element into protobuf list")
+		}
+		v, err := d.decodeValue(list.NewElement(), field, element, elem)
+		if err != nil {
+			return err
+		}
+		list.Append(v)
+	}
+	return nil
+}
+
+func (d *Decoder) decodeMap(dict protoreflect.Map, field protoreflect.FieldDescriptor, column parquet.Node, value any) error {
+	entries, key, val, err := mapEntries(column, value)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		k, err := d.decodeValue(protoreflect.Value{}, field.MapKey(), key, entry[0])
+		if err != nil {
+			return fmt.Errorf("map key: %w", err)
+		}
+		if entry[1] == nil {
+			continue
+		}
+		v, err := d.decodeValue(dict.NewValue(), field.MapValue(), val, entry[1])
+		if err != nil {
+			return fmt.Errorf("map value: %w", err)
+		}
+		dict.Set(k.MapKey(), v)
+	}
+	return nil
+}
+
+// decodeValue converts a parquet value to a protobuf value of the given field
+// kind. For message fields, zero must be a new mutable message value that the
+// parquet value is decoded into.
+func (d *Decoder) decodeValue(zero protoreflect.Value, field protoreflect.FieldDescriptor, column parquet.Node, value any) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := zero.Message()
+		if m.Descriptor().FullName() == timestampFullName {
+			return zero, decodeTimestamp(m, column, value)
+		}
+		group, ok := value.(map[string]any)
+		if !ok {
+			return zero, fmt.Errorf("cannot decode parquet value of type %T into protobuf message", value)
+		}
+		return zero, d.decodeMessage(m, column, group)
+
+	case protoreflect.BoolKind:
+		if b, ok := value.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if i, ok := toInt64(value); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return protoreflect.ValueOfInt32(int32(i)), nil
+		}
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if i, ok := toInt64(value); ok {
+			return protoreflect.ValueOfInt64(i), nil
+		}
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if u, ok := toUint64(value); ok && u <= math.MaxUint32 {
+			return protoreflect.ValueOfUint32(uint32(u)), nil
+		}
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if u, ok := toUint64(value); ok {
+			return protoreflect.ValueOfUint64(u), nil
+		}
+
+	case protoreflect.FloatKind:
+		if f, ok := toFloat64(value); ok {
+			return protoreflect.ValueOfFloat32(float32(f)), nil
+		}
+
+	case protoreflect.DoubleKind:
+		if f, ok := toFloat64(value); ok {
+			return protoreflect.ValueOfFloat64(f), nil
+		}
+
+	case protoreflect.StringKind:
+		if b, ok := toBytes(value); ok {
+			return protoreflect.ValueOfString(string(b)), nil
+		}
+
+	case protoreflect.BytesKind:
+		if b, ok := toBytes(value); ok {
+			return protoreflect.ValueOfBytes(append([]byte{}, b...)), nil
+		}
+
+	case protoreflect.EnumKind:
+		if b, ok := toBytes(value); ok {
+			if v := field.Enum().Values().ByName(protoreflect.Name(b)); v != nil {
+				return protoreflect.ValueOfEnum(v.Number()), nil
+			}
+			return zero, fmt.Errorf("%q is not a value of protobuf enum %s", b, field.Enum().FullName())
+		}
+		if i, ok := toInt64(value); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), nil
+		}
+	}
+
+	return zero, fmt.Errorf("cannot decode parquet value of type %T into protobuf field of kind %s", value, field.Kind())
+}
+
+func decodeTimestamp(m protoreflect.Message, column parquet.Node, value any) error {
+	var t time.Time
+
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	default:
+		i, ok := toInt64(value)
+		if !ok {
+			return fmt.Errorf("cannot decode parquet value of type %T into %s", value, timestampFullName)
+		}
+		logicalType := column.Type().LogicalType()
+		if logicalType == nil || logicalType.Timestamp == nil {
+			return fmt.Errorf("cannot decode parquet column of type %s into %s", column.Type(), timestampFullName)
+		}
+		switch unit := logicalType.Timestamp.Unit; {
+		case unit.Millis != nil:
+			t = time.UnixMilli(i)
+		case unit.Micros != nil:
+			t = time.UnixMicro(i)
+		default:
+			t = time.Unix(0, i)
+		}
+	}
+
+	fields := m.Descriptor().Fields()
+	m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
+	m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+	return nil
+}
+
+// listElements returns the elements of a parquet list value, and the node
+// describing them. Lists may be represented either by repeated fields, or by
+// groups holding a single repeated field, which is the structure of columns
+// annotated with the LIST logical type. The node of the repeated field is used
+// as list element unless it is a group of a single field, as in the three-level
+// structure described by the parquet format specification.
+//
+// The structure of the columns is inspected instead of their logical types
+// since columns of files opened with parquet.OpenFile do not expose the logical
+// types of groups.
+func listElements(column parquet.Node, value any) ([]any, parquet.Node, error) {
+	if column.Repeated() {
+		values, ok := value.([]any)
+		if !ok {
+			return nil, nil, fmt.Errorf("cannot decode parquet value of type %T into protobuf list", value)
+		}
+		return values, parquet.Required(column), nil
+	}
+
+	if column.Leaf() {
+		return []any{value}, column, nil
+	}
+
+	fields := column.Fields()
+	if len(fields) != 1 || !fields[0].Repeated() {
+		return []any{value}, column, nil
+	}
+
+	group, ok := value.(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("malformed parquet list of type %T", value)
+	}
+	list := fields[0]
+	values, _ := group[list.Name()].([]any)
+
+	if list.Leaf() || len(list.Fields()) != 1 {
+		return values, parquet.Required(list), nil
+	}
+
+	element := list.Fields()[0]
+	elements := make([]any, len(values))
+	for i, v := range values {
+		if g, ok := v.(map[string]any); ok {
+			elements[i] = g[element.Name()]
+		}
+	}
+	return elements, element, nil
+}
+
+// mapEntries returns the key/value pairs of a parquet map value, and the nodes
+// describing the keys and values.
+func mapEntries(column parquet.Node, value any) ([][2]any, parquet.Node, parquet.Node, error) {
+	group, ok := value.(map[string]any)
+	if !ok || len(column.Fields()) != 1 {
+		return nil, nil, nil, fmt.Errorf("cannot decode parquet value of type %T into protobuf map", value)
+	}
+	keyValue := column.Fields()[0]
+	if !keyValue.Repeated() || len(keyValue.Fields()) != 2 {
+		return nil, nil, nil, fmt.Errorf("malformed parquet map %q", keyValue.Name())
+	}
+	key := fieldByName(keyValue, "key")
+	val := fieldByName(keyValue, "value")
+	if key == nil || val == nil {
+		return nil, nil, nil, fmt.Errorf("malformed parquet map %q", keyValue.Name())
+	}
+	values, _ := group[keyValue.Name()].([]any)
+	entries := make([][2]any, 0, len(values))
+	for _, v := range values {
+		if g, ok := v.(map[string]any); ok {
+			entries = append(entries, [2]any{g[key.Name()], g[val.Name()]})
+		}
+	}
+	return entries, key, val, nil
+}
+
+func fieldByName(node parquet.Node, name string) parquet.Field {
+	for _, f := range node.Fields() {
+		if f.Name() == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func toInt64(value any) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+	}
+	return 0, false
+}
+
+func toUint64(value any) (uint64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := v.Int(); i >= 0 {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
+
+func toFloat64(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	}
+	return 0, false
+}
+
+func toBytes(value any) ([]byte, bool) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return b, true
+	}
+	return nil, false
+}
+
+// Reader reads rows from a parquet.RowReader and decodes them into protobuf
+// messages.
+type Reader struct {
+	rows    parquet.RowReader
+	decoder *Decoder
+	buffer  []parquet.Row
+}
+
+// NewReader constructs a reader of protobuf messages decoded from rows of the
+// given schema read from rows.
+func NewReader(rows parquet.RowReader, schema *parquet.Schema, options ...Option) *Reader {
+	return &Reader{
+		rows:    rows,
+		decoder: NewDecoder(schema, options...),
+	}
+}
+
+// Read decodes rows into the messages passed as argument, returning the number
+// of messages that were decoded. The messages must be non-nil, they are reset
+// before being decoded into. When all rows have been read, the method returns
+// io.EOF.
+func (r *Reader) Read(msgs []proto.Message) (int, error) {
+	if cap(r.buffer) == 0 {
+		r.buffer = make([]parquet.Row, defaultRowBufferSize)
+	}
+
+	n := 0
+	for n < len(msgs) {
+		buffer := r.buffer
+		if remain := len(msgs) - n; remain < len(buffer) {
+			buffer = buffer[:remain]
+		}
+
+		numRows, err := r.rows.ReadRows(buffer)
+
+		for _, row := range buffer[:numRows] {
+			proto.Reset(msgs[n])
+			if err := r.decoder.Decode(row, msgs[n]); err != nil {
+				return n, err
+			}
+			n++
+		}
+
+		if err != nil {
+			return n, err
+		}
+		if numRows == 0 {
+			return n, io.ErrNoProgress
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,209 @@
+package protobuf_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/protobuf"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Address struct {
+	City string `parquet:"city"`
+	Zip  string `parquet:"zip_code"`
+}
+
+type User struct {
+	ID        int64            `parquet:"id"`
+	Name      string           `parquet:"name"`
+	Age       *int32           `parquet:"age,optional"`
+	Score     float64          `parquet:"score"`
+	Active    bool             `parquet:"active"`
+	Role      string           `parquet:"role,enum"`
+	Tags      []string         `parquet:"tags,list"`
+	Phones    []string         `parquet:"phones"`
+	Labels    map[string]int64 `parquet:"labels"`
+	Address   Address          `parquet:"address"`
+	CreatedAt time.Time        `parquet:"created_at,timestamp(millisecond)"`
+}
+
+func userDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Type:   typ.Enum(),
+			Label:  label.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	)
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("example/user.proto"),
+		Package:    proto.String("example"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Role"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("ADMIN"), Number: proto.Int32(1)},
+				{Name: proto.String("MEMBER"), Number: proto.Int32(2)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Address"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("city", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					field("zip", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				},
+			},
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+					field("name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					field("age", 3, descriptorpb.FieldDescriptorProto_TYPE_UINT32, optional, ""),
+					field("score", 4, descriptorpb.FieldDescriptorProto_TYPE_FLOAT, optional, ""),
+					field("active", 5, descriptorpb.FieldDescriptorProto_TYPE_BOOL, optional, ""),
+					field("role", 6, descriptorpb.FieldDescriptorProto_TYPE_ENUM, optional, ".example.Role"),
+					field("tags", 7, descriptorpb.FieldDescriptorProto_TYPE_STRING, repeated, ""),
+					field("phones", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING, repeated, ""),
+					field("labels", 9, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated, ".example.User.LabelsEntry"),
+					field("address", 10, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, ".example.Address"),
+					field("createdAt", 11, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, ".google.protobuf.Timestamp"),
+					field("missing", 12, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("LabelsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+						field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+		},
+	}
+	// The created_at column is matched with the JSON name of the createdAt
+	// field, which is created_at since the field name has no underscores.
+	file.MessageType[1].Field[10].JsonName = proto.String("created_at")
+
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+			file,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := files.FindDescriptorByName("example.User")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return desc.(protoreflect.MessageDescriptor)
+}
+
+func TestReader(t *testing.T) {
+	age := int32(42)
+	createdAt := time.Date(2023, 6, 1, 12, 30, 0, 123e6, time.UTC)
+
+	users := []User{
+		{
+			ID:        1,
+			Name:      "Luke",
+			Age:       &age,
+			Score:     0.5,
+			Active:    true,
+			Role:      "ADMIN",
+			Tags:      []string{"a", "b"},
+			Phones:    []string{"555-0100"},
+			Labels:    map[string]int64{"x": 1, "y": 2},
+			Address:   Address{City: "Paris", Zip: "75001"},
+			CreatedAt: createdAt,
+		},
+		{
+			ID:        2,
+			Name:      "Leia",
+			Role:      "MEMBER",
+			CreatedAt: createdAt.Add(time.Hour),
+		},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, users); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desc := userDescriptor(t)
+	rows := parquet.NewReader(f)
+	defer rows.Close()
+
+	reader := protobuf.NewReader(rows, f.Schema(),
+		protobuf.MapField("example.Address.zip", "zip_code"),
+	)
+
+	msgs := []proto.Message{
+		dynamicpb.NewMessage(desc),
+		dynamicpb.NewMessage(desc),
+		dynamicpb.NewMessage(desc),
+	}
+	n, err := reader.Read(msgs)
+	if err != io.EOF {
+		t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+	}
+	if n != len(users) {
+		t.Fatalf("number of messages mismatch: want=%d got=%d", len(users), n)
+	}
+
+	for i, want := range []string{
+		`id:1 name:"Luke" age:42 score:0.5 active:true role:ADMIN tags:"a" tags:"b" phones:"555-0100" ` +
+			`labels:{key:"x" value:1} labels:{key:"y" value:2} address:{city:"Paris" zip:"75001"} ` +
+			`createdAt:{seconds:1685622600 nanos:123000000}`,
+		`id:2 name:"Leia" role:MEMBER address:{} createdAt:{seconds:1685626200 nanos:123000000}`,
+	} {
+		expect := dynamicpb.NewMessage(desc)
+		if err := prototext.Unmarshal([]byte(want), expect); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(expect, msgs[i]) {
+			t.Errorf("message %d mismatch:\nwant: %v\ngot:  %v", i, expect, msgs[i])
+		}
+	}
+}
+
+func TestDecoderError(t *testing.T) {
+	type Row struct {
+		ID string `parquet:"id"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	row := schema.Deconstruct(nil, &Row{ID: "not-a-number"})
+
+	msg := dynamicpb.NewMessage(userDescriptor(t))
+	if err := protobuf.NewDecoder(schema).Decode(row, msg); err == nil {
+		t.Error("expected an error decoding a string into an integer field")
+	}
+}
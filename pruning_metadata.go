@@ -0,0 +1,181 @@
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/parquet-go/parquet-go/bloom/xxhash"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// pruningMetadataVersion is the version of the serialization format of
+// PruningMetadata, incremented when the format changes in incompatible ways.
+const pruningMetadataVersion = 1
+
+// PruningMetadata carries the metadata of a parquet file which query planners
+// need to decide which row groups of the file to read: the schema fingerprint,
+// and for each column chunk its statistics and the location of its bloom
+// filter.
+//
+// The metadata can be serialized with MarshalBinary into a compact format, so
+// query services may cache planning information about files in an external
+// catalog instead of reopening the files.
+type PruningMetadata struct {
+	// Fingerprint of the file schema, see SchemaFingerprint. Thrift has no
+	// unsigned integers, the fingerprint is serialized in the envelope.
+	SchemaFingerprint uint64
+	// Total number of rows in the file.
+	NumRows int64 `thrift:"2"`
+	// Leaf columns of the file schema.
+	Columns []PruningColumn `thrift:"3"`
+	// Row groups of the file.
+	RowGroups []PruningRowGroup `thrift:"4"`
+}
+
+// PruningColumn describes a leaf column of the file schema in PruningMetadata.
+type PruningColumn struct {
+	// Path of the column in the schema.
+	Path []string `thrift:"1"`
+	// Physical type of the column, which determines how the min and max values
+	// of column chunks are interpreted.
+	Type format.Type `thrift:"2"`
+}
+
+// PruningRowGroup describes a row group in PruningMetadata.
+type PruningRowGroup struct {
+	// Number of rows in the row group.
+	NumRows int64 `thrift:"1"`
+	// Column chunks of the row group, in the order of PruningMetadata.Columns.
+	Columns []PruningColumnChunk `thrift:"2"`
+}
+
+// PruningColumnChunk describes a column chunk in PruningMetadata.
+type PruningColumnChunk struct {
+	// Offset and compressed size of the column chunk in the file.
+	Offset         int64 `thrift:"1"`
+	CompressedSize int64 `thrift:"2"`
+	// Number of values and nulls in the column chunk.
+	NumValues int64 `thrift:"3"`
+	NullCount int64 `thrift:"4"`
+	// Reports whether the min and max values of the column chunk are known.
+	HasBounds bool `thrift:"5"`
+	// Plain encoded min and max values of the column chunk.
+	MinValue []byte `thrift:"6"`
+	MaxValue []byte `thrift:"7"`
+	// Offset of the bloom filter header in the file and size of the bloom
+	// filter bitset, both zero if the column chunk has no bloom filter.
+	BloomFilterOffset int64 `thrift:"8"`
+	BloomFilterSize   int64 `thrift:"9"`
+}
+
+// pruningMetadataEnvelope is the serialized form of PruningMetadata, which is
+// prefixed with the version of the format.
+type pruningMetadataEnvelope struct {
+	Version           int32           `thrift:"1,required"`
+	SchemaFingerprint int64           `thrift:"2,required"`
+	Metadata          PruningMetadata `thrift:"3,required"`
+}
+
+// SchemaFingerprint returns a fingerprint of the given schema, which changes
+// when the names, types, or repetition of its columns change. Query services
+// may compare fingerprints to detect that files of a dataset do not all have
+// the same schema.
+func SchemaFingerprint(node Node) uint64 {
+	return xxhash.Sum64([]byte(sprint("", node)))
+}
+
+// PruningMetadata returns the pruning metadata of f.
+//
+// Bloom filters are only reported when they were loaded when opening the file,
+// see SkipBloomFilters.
+func (f *File) PruningMetadata() *PruningMetadata {
+	m := &PruningMetadata{
+		SchemaFingerprint: SchemaFingerprint(f.schema),
+		NumRows:           f.NumRows(),
+		RowGroups:         make([]PruningRowGroup, len(f.rowGroups)),
+	}
+
+	f.root.forEachLeaf(func(c *Column) {
+		m.Columns = append(m.Columns, PruningColumn{
+			Path: c.Path(),
+			Type: format.Type(c.Type().Kind()),
+		})
+	})
+
+	for i, rowGroup := range f.rowGroups {
+		columnChunks := rowGroup.ColumnChunks()
+		g := PruningRowGroup{
+			NumRows: rowGroup.NumRows(),
+			Columns: make([]PruningColumnChunk, len(columnChunks)),
+		}
+
+		for j, columnChunk := range columnChunks {
+			c := columnChunk.(*fileColumnChunk)
+			if c.load() != nil {
+				continue
+			}
+			metadata := &c.chunk.MetaData
+			chunk := PruningColumnChunk{
+				Offset:         metadata.DataPageOffset,
+				CompressedSize: metadata.TotalCompressedSize,
+				NumValues:      metadata.NumValues,
+				NullCount:      metadata.Statistics.NullCount,
+			}
+			if metadata.DictionaryPageOffset != 0 {
+				chunk.Offset = metadata.DictionaryPageOffset
+			}
+			// Empty byte arrays are decoded as nil slices, a byte array column
+			// chunk with a max value and no min value has an empty min value.
+			kind := Kind(metadata.Type)
+			if stats := &metadata.Statistics; stats.MaxValue != nil && (stats.MinValue != nil || kind == ByteArray) {
+				chunk.HasBounds = true
+				chunk.MinValue = stats.MinValue
+				chunk.MaxValue = stats.MaxValue
+			}
+			if c.bloomFilter != nil {
+				chunk.BloomFilterOffset = metadata.BloomFilterOffset
+				chunk.BloomFilterSize = c.bloomFilter.Size()
+			}
+			g.Columns[j] = chunk
+		}
+
+		m.RowGroups[i] = g
+	}
+
+	return m
+}
+
+// Bounds returns the min and max values of the column chunk at the given
+// indexes of row group and column. The ok boolean is false if the column chunk
+// has no statistics.
+func (m *PruningMetadata) Bounds(rowGroup, column int) (min, max Value, ok bool) {
+	chunk := &m.RowGroups[rowGroup].Columns[column]
+	if !chunk.HasBounds {
+		return min, max, false
+	}
+	kind := Kind(m.Columns[column].Type)
+	return kind.Value(chunk.MinValue), kind.Value(chunk.MaxValue), true
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+func (m *PruningMetadata) MarshalBinary() ([]byte, error) {
+	return thrift.Marshal(new(thrift.CompactProtocol), &pruningMetadataEnvelope{
+		Version:           pruningMetadataVersion,
+		SchemaFingerprint: int64(m.SchemaFingerprint),
+		Metadata:          *m,
+	})
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (m *PruningMetadata) UnmarshalBinary(b []byte) error {
+	envelope := pruningMetadataEnvelope{}
+	if err := thrift.Unmarshal(new(thrift.CompactProtocol), b, &envelope); err != nil {
+		return fmt.Errorf("decoding pruning metadata: %w", err)
+	}
+	if envelope.Version != pruningMetadataVersion {
+		return fmt.Errorf("decoding pruning metadata: unsupported version %d", envelope.Version)
+	}
+	*m = envelope.Metadata
+	m.SchemaFingerprint = uint64(envelope.SchemaFingerprint)
+	return nil
+}
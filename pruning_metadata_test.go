@@ -0,0 +1,96 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestPruningMetadata(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := []Row{
+		{ID: 1, Name: ""}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"},
+		{ID: 4, Name: "d"}, {ID: 5, Name: "e"},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer,
+		parquet.MaxRowsPerRowGroup(3),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+	)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := f.PruningMetadata()
+	if metadata.SchemaFingerprint != parquet.SchemaFingerprint(f.Schema()) {
+		t.Errorf("schema fingerprint mismatch")
+	}
+	if metadata.SchemaFingerprint == parquet.SchemaFingerprint(parquet.SchemaOf(struct{ ID int64 }{})) {
+		t.Errorf("different schemas have the same fingerprint")
+	}
+
+	b, err := metadata.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(parquet.PruningMetadata)
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(metadata, decoded) {
+		t.Errorf("pruning metadata mismatch after serialization:\nwant=%+v\ngot= %+v", metadata, decoded)
+	}
+
+	if decoded.NumRows != 5 {
+		t.Errorf("number of rows mismatch: want=%d got=%d", 5, decoded.NumRows)
+	}
+	if len(decoded.RowGroups) != 2 {
+		t.Fatalf("number of row groups mismatch: want=%d got=%d", 2, len(decoded.RowGroups))
+	}
+
+	for i, want := range []struct{ minID, maxID int64 }{{1, 3}, {4, 5}} {
+		minValue, maxValue, ok := decoded.Bounds(i, 0)
+		if !ok {
+			t.Fatalf("row group %d: missing bounds", i)
+		}
+		if minValue.Int64() != want.minID || maxValue.Int64() != want.maxID {
+			t.Errorf("row group %d: bounds mismatch: want=[%d,%d] got=[%d,%d]", i, want.minID, want.maxID, minValue.Int64(), maxValue.Int64())
+		}
+	}
+
+	minName, maxName, ok := decoded.Bounds(0, 1)
+	if !ok {
+		t.Fatal("missing bounds of empty string values")
+	}
+	if minName.String() != "" || maxName.String() != "c" {
+		t.Errorf("bounds mismatch: want=[%q,%q] got=[%q,%q]", "", "c", minName, maxName)
+	}
+
+	for i, rowGroup := range decoded.RowGroups {
+		if chunk := rowGroup.Columns[0]; chunk.BloomFilterOffset != 0 {
+			t.Errorf("row group %d: unexpected bloom filter on id column", i)
+		}
+		if chunk := rowGroup.Columns[1]; chunk.BloomFilterOffset == 0 || chunk.BloomFilterSize == 0 {
+			t.Errorf("row group %d: missing bloom filter on name column", i)
+		}
+	}
+
+	if err := decoded.UnmarshalBinary(b[:len(b)/2]); err == nil {
+		t.Error("expected an error decoding truncated pruning metadata")
+	}
+}
@@ -0,0 +1,580 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Query is a builder of queries reading rows from a parquet file. It combines
+// the projection of columns, the pruning of row groups with the statistics of
+// column chunks, the filtering of rows, and limits on the number of rows, which
+// programs would otherwise assemble with lower level APIs.
+//
+// Queries are created by calling File.Query, for example:
+//
+//	rows, errs := file.Query().
+//		Select("id", "name").
+//		Where(parquet.Gt("ts", t0)).
+//		Limit(1000).
+//		Rows(ctx)
+//
+// The methods of Query modify the query and return it, so calls can be
+// chained. Query values are not safe to use concurrently from multiple
+// goroutines while they are being built.
+type Query struct {
+	file       *File
+	columns    []string
+	predicates []Predicate
+	limit      int64
+}
+
+// Query returns a new query reading all the rows of f.
+func (f *File) Query() *Query { return &Query{file: f} }
+
+// Select restricts the columns read by the query to the top-level fields of
+// the file schema with the given names. Columns of the file are read in the
+// order of the file schema, regardless of the order of the names.
+//
+// When Select is not called, all the columns of the file are read.
+func (q *Query) Select(columns ...string) *Query {
+	q.columns = append(q.columns, columns...)
+	return q
+}
+
+// Where adds predicates that rows must satisfy to be returned by the query.
+// Rows are returned only if they satisfy all the predicates.
+//
+// The columns of predicates do not need to be selected, they are read to
+// evaluate the predicates and removed from the rows returned by the query.
+func (q *Query) Where(predicates ...Predicate) *Query {
+	q.predicates = append(q.predicates, predicates...)
+	return q
+}
+
+// Limit sets the maximum number of rows returned by the query. A zero or
+// negative limit removes the limit.
+func (q *Query) Limit(limit int64) *Query {
+	q.limit = limit
+	return q
+}
+
+// Schema returns the schema of rows returned by the query, or an error if
+// the query is invalid, for example because it refers to columns that do not
+// exist in the file.
+func (q *Query) Schema() (*Schema, error) {
+	plan, err := q.plan()
+	if err != nil {
+		return nil, err
+	}
+	return plan.schema, nil
+}
+
+// Rows runs the query and returns a channel producing its rows, and a channel
+// receiving the error that interrupted the query, if any.
+//
+// Row groups where the statistics of column chunks show that no rows can
+// satisfy the predicates are skipped without being read, as well as row groups
+// where the bloom filters of column chunks show that no values are equal to
+// those of Eq predicates.
+//
+// The channels follow the same semantics as those returned by File.RowsChan;
+// they are closed once the query completed, after an error occurred, or after
+// ctx was canceled. Errors caused by invalid queries are sent to the error
+// channel as well.
+func (q *Query) Rows(ctx context.Context) (<-chan Row, <-chan error) {
+	rows := make(chan Row)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(rows)
+
+		if err := q.sendRows(ctx, rows); err != nil {
+			errs <- err
+		}
+	}()
+
+	return rows, errs
+}
+
+func (q *Query) sendRows(ctx context.Context, ch chan<- Row) error {
+	plan, err := q.plan()
+	if err != nil {
+		return err
+	}
+
+	var metadata *PruningMetadata
+	if len(plan.filters) != 0 {
+		metadata = q.file.PruningMetadata()
+	}
+
+	buf := make([]Row, defaultRowBufferSize)
+	count := int64(0)
+
+	for i, rowGroup := range q.file.rowGroups {
+		if metadata != nil {
+			if plan.skip(metadata, i) {
+				continue
+			}
+			if skip, err := plan.skipBloomFilters(rowGroup); err != nil {
+				return err
+			} else if skip {
+				continue
+			}
+			if rowGroup = plan.skipPages(rowGroup, q.file.config.ReadMode); rowGroup == nil {
+				continue
+			}
+		}
+		if plan.read != nil {
+			rowGroup = ConvertRowGroup(rowGroup, plan.read)
+		}
+
+		if err := func() error {
+			rows := rowGroup.Rows()
+			defer rows.Close()
+
+			var reader RowReader = rows
+			if len(plan.filters) != 0 {
+				reader = FilterRowReader(reader, plan.match)
+			}
+			if plan.project != nil {
+				reader = ConvertRowReader(reader, plan.project)
+			}
+
+			for {
+				n, err := reader.ReadRows(buf)
+
+				for _, row := range buf[:n] {
+					select {
+					case ch <- row.Clone():
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+					if count++; count == q.limit {
+						return io.EOF
+					}
+				}
+
+				if err != nil {
+					return err
+				}
+			}
+		}(); err != nil {
+			if err == io.EOF {
+				if count == q.limit {
+					return nil
+				}
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FilterRowGroups returns the row groups of f where the statistics of column
+// chunks do not show that no rows can satisfy all the predicates, in the order
+// of the file. Programs can iterate over the row groups and their column
+// chunks to only read those that may contain matching rows.
+//
+// Eq predicates are also checked against the bloom filters of column chunks,
+// which makes point lookups skip row groups where the value is absent even
+// when it is within the bounds of the column chunk statistics; see also
+// MightContain.
+//
+// The predicates follow the same rules as those of Query.Where; an error is
+// returned if they refer to columns which are not leaf columns of the file or
+// to values which cannot be compared to the values of their columns. All the
+// row groups are returned when no predicates are passed.
+func (f *File) FilterRowGroups(predicates ...Predicate) ([]RowGroup, error) {
+	if len(predicates) == 0 {
+		return f.rowGroups, nil
+	}
+	scan, err := f.filterRowGroups(predicates, false)
+	if err != nil {
+		return nil, err
+	}
+	return readRowGroups(scan), nil
+}
+
+// filterRowGroups is like FilterRowGroups, and when skipPages is true, also
+// uses the page index of the file to skip the pages of row groups where no
+// rows can satisfy the predicates, see queryPlan.skipPages.
+//
+// The function returns all the row groups of the file, those which are skipped
+// have the reason why they are skipped.
+func (f *File) filterRowGroups(predicates []Predicate, skipPages bool) ([]scannedRowGroup, error) {
+	scan := make([]scannedRowGroup, len(f.rowGroups))
+	for i, rowGroup := range f.rowGroups {
+		scan[i] = scannedRowGroup{index: i, rowGroup: rowGroup}
+	}
+	if len(predicates) == 0 {
+		return scan, nil
+	}
+	plan, err := (&Query{file: f, predicates: predicates}).plan()
+	if err != nil {
+		return nil, err
+	}
+	metadata := f.PruningMetadata()
+	for i := range scan {
+		s := &scan[i]
+		if plan.skip(metadata, i) {
+			s.pruneReason = pruneStatistics
+			continue
+		}
+		if skip, err := plan.skipBloomFilters(s.rowGroup); err != nil {
+			return nil, err
+		} else if skip {
+			s.pruneReason = pruneBloomFilter
+			continue
+		}
+		if skipPages {
+			if rowGroup := plan.skipPages(s.rowGroup, f.config.ReadMode); rowGroup != nil {
+				s.rowGroup = rowGroup
+			} else {
+				s.pruneReason = prunePageIndex
+			}
+		}
+	}
+	return scan, nil
+}
+
+// queryPlan is the compiled form of a query.
+type queryPlan struct {
+	// Schema of rows returned by the query.
+	schema *Schema
+	// Conversion of row groups of the file to the columns read by the query,
+	// nil if all the columns are read.
+	read Conversion
+	// Conversion of the rows read to the schema of the query, nil if no
+	// columns were read only to evaluate predicates.
+	project Conversion
+	filters []queryFilter
+}
+
+func (q *Query) plan() (*queryPlan, error) {
+	fileSchema := q.file.schema
+	selected := make(map[string]bool, len(q.columns))
+
+	for _, name := range q.columns {
+		if fieldByName(fileSchema, name) == nil {
+			return nil, fmt.Errorf("query selects column %q which does not exist in the parquet file", name)
+		}
+		selected[name] = true
+	}
+
+	read := make(map[string]bool, len(selected))
+	for name := range selected {
+		read[name] = true
+	}
+	for _, p := range q.predicates {
+		read[strings.Split(p.column, ".")[0]] = true
+	}
+
+	plan := &queryPlan{schema: fileSchema}
+	readSchema := fileSchema
+
+	if len(q.columns) != 0 && len(read) != len(fileSchema.Fields()) {
+		readSchema = projectSchema(fileSchema, read)
+		conv, err := Convert(readSchema, fileSchema)
+		if err != nil {
+			return nil, err
+		}
+		plan.read = conv
+	}
+	plan.schema = readSchema
+
+	for _, p := range q.predicates {
+		path := strings.Split(p.column, ".")
+		leaf, ok := readSchema.Lookup(path...)
+		if !ok {
+			return nil, fmt.Errorf("query predicate refers to column %q which is not a leaf column of the parquet file", p.column)
+		}
+		fileLeaf, _ := fileSchema.Lookup(path...)
+		typ := leaf.Node.Type()
+		value, err := queryValueOf(p.value, typ)
+		if err != nil {
+			return nil, fmt.Errorf("query predicate on column %q: %w", p.column, err)
+		}
+		plan.filters = append(plan.filters, queryFilter{
+			columnIndex:     leaf.ColumnIndex,
+			fileColumnIndex: fileLeaf.ColumnIndex,
+			compare:         typ.Compare,
+			op:              p.op,
+			value:           value,
+		})
+	}
+
+	if len(q.columns) != 0 && len(read) != len(selected) {
+		plan.schema = projectSchema(fileSchema, selected)
+		conv, err := Convert(plan.schema, readSchema)
+		if err != nil {
+			return nil, err
+		}
+		plan.project = conv
+	}
+
+	return plan, nil
+}
+
+// projectSchema returns a schema with the top-level fields of schema which
+// have their names in the columns set.
+func projectSchema(schema *Schema, columns map[string]bool) *Schema {
+	group := make(Group, len(columns))
+	for _, field := range schema.Fields() {
+		if columns[field.Name()] {
+			group[field.Name()] = field
+		}
+	}
+	return NewSchema(schema.Name(), group)
+}
+
+// skip returns true if the statistics of the row group at the given index show
+// that none of its rows satisfy the predicates of the plan.
+func (p *queryPlan) skip(metadata *PruningMetadata, rowGroup int) bool {
+	for i := range p.filters {
+		f := &p.filters[i]
+		chunk := &metadata.RowGroups[rowGroup].Columns[f.fileColumnIndex]
+
+		if chunk.HasBounds {
+			min, max, _ := metadata.Bounds(rowGroup, f.fileColumnIndex)
+			if !f.overlaps(min, max) {
+				return true
+			}
+		} else if chunk.NumValues > 0 && chunk.NumValues <= chunk.NullCount {
+			// Null values never satisfy predicates.
+			return true
+		}
+	}
+	return false
+}
+
+// skipBloomFilters returns true if the bloom filters of the column chunks of
+// rowGroup show that none of its rows satisfy the equality predicates of the
+// plan. Column chunks without bloom filters never cause row groups to be
+// skipped.
+func (p *queryPlan) skipBloomFilters(rowGroup RowGroup) (bool, error) {
+	chunks := rowGroup.ColumnChunks()
+	for i := range p.filters {
+		f := &p.filters[i]
+		if f.op != predicateEq {
+			continue
+		}
+		if filter := chunks[f.fileColumnIndex].BloomFilter(); filter != nil {
+			ok, err := filter.Check(f.value)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// skipPages returns a row group exposing the rows of rowGroup which are in
+// pages where the column indexes of the filtered columns show that rows may
+// satisfy the predicates of the plan; the other pages are skipped without
+// being read. The method returns nil if no pages match, and rowGroup if all
+// pages may match or if the column chunks have no page index.
+func (p *queryPlan) skipPages(rowGroup RowGroup, readMode ReadMode) RowGroup {
+	numRows := rowGroup.NumRows()
+	all := []rowRange{{0, numRows}}
+	ranges := all
+	chunks := rowGroup.ColumnChunks()
+
+	for i := range p.filters {
+		f := &p.filters[i]
+		if ranges = intersectRowRanges(ranges, f.pageRowRanges(chunks[f.fileColumnIndex], numRows)); len(ranges) == 0 {
+			return nil
+		}
+	}
+
+	if len(ranges) == 1 && ranges[0] == all[0] {
+		return rowGroup
+	}
+	return newRangedRowGroup(rowGroup, ranges, readMode)
+}
+
+func (p *queryPlan) match(row Row) bool {
+	for i := range p.filters {
+		if !p.filters[i].matchRow(row) {
+			return false
+		}
+	}
+	return true
+}
+
+// Predicate is a condition on the values of a column that rows returned by a
+// Query must satisfy, see Query.Where.
+//
+// Predicates are constructed by calling Eq, Lt, Le, Gt, or Ge. The column is
+// the dot-separated path of a leaf column of the file, and the value is
+// compared to the values of the column according to the ordering of its type.
+// Values may be Go values (e.g. int64, string, or time.Time), which are
+// converted to the type of the column, or parquet Value instances.
+//
+// Null values never satisfy predicates. Rows of repeated columns satisfy a
+// predicate if any of their values does.
+type Predicate struct {
+	column string
+	op     predicateOp
+	value  interface{}
+}
+
+type predicateOp int
+
+const (
+	predicateEq predicateOp = iota
+	predicateLt
+	predicateLe
+	predicateGt
+	predicateGe
+)
+
+// Eq constructs a predicate satisfied by values of the column equal to value.
+func Eq(column string, value interface{}) Predicate {
+	return Predicate{column: column, op: predicateEq, value: value}
+}
+
+// Lt constructs a predicate satisfied by values of the column less than value.
+func Lt(column string, value interface{}) Predicate {
+	return Predicate{column: column, op: predicateLt, value: value}
+}
+
+// Le constructs a predicate satisfied by values of the column less than or
+// equal to value.
+func Le(column string, value interface{}) Predicate {
+	return Predicate{column: column, op: predicateLe, value: value}
+}
+
+// Gt constructs a predicate satisfied by values of the column greater than
+// value.
+func Gt(column string, value interface{}) Predicate {
+	return Predicate{column: column, op: predicateGt, value: value}
+}
+
+// Ge constructs a predicate satisfied by values of the column greater than or
+// equal to value.
+func Ge(column string, value interface{}) Predicate {
+	return Predicate{column: column, op: predicateGe, value: value}
+}
+
+// queryValueOf converts the value of a predicate to the given column type.
+func queryValueOf(value interface{}, typ Type) (Value, error) {
+	var v Value
+	switch value := value.(type) {
+	case nil:
+	case Value:
+		v = value
+	case time.Time:
+		// Convert to the unit of timestamp columns.
+		return makeValue(typ.Kind(), typ.LogicalType(), reflect.ValueOf(value)), nil
+	default:
+		if !isValueOfSupported(reflect.TypeOf(value)) {
+			return v, fmt.Errorf("cannot compare values of type %T", value)
+		}
+		v = ValueOf(value)
+	}
+	if v.IsNull() {
+		return v, fmt.Errorf("cannot compare values to null")
+	}
+	return typ.ConvertValue(v, kindType(v.Kind()))
+}
+
+// isValueOfSupported returns true if ValueOf can convert Go values of type t.
+func isValueOfSupported(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// queryFilter is the compiled form of a predicate.
+type queryFilter struct {
+	// Index of the column in the rows read by the query.
+	columnIndex int
+	// Index of the column in the file schema.
+	fileColumnIndex int
+	compare         func(Value, Value) int
+	op              predicateOp
+	value           Value
+}
+
+func (f *queryFilter) match(v Value) bool {
+	c := f.compare(v, f.value)
+	switch f.op {
+	case predicateLt:
+		return c < 0
+	case predicateLe:
+		return c <= 0
+	case predicateGt:
+		return c > 0
+	case predicateGe:
+		return c >= 0
+	default:
+		return c == 0
+	}
+}
+
+func (f *queryFilter) matchRow(row Row) bool {
+	for _, v := range row {
+		if v.Column() == f.columnIndex && !v.IsNull() && f.match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// pageRowRanges returns the ranges of rows of the pages of chunk where values
+// may satisfy the filter, or all the rows if the chunk has no page index.
+func (f *queryFilter) pageRowRanges(chunk ColumnChunk, numRows int64) []rowRange {
+	columnIndex, offsetIndex := chunk.ColumnIndex(), chunk.OffsetIndex()
+	if columnIndex == nil || offsetIndex == nil || columnIndex.NumPages() != offsetIndex.NumPages() {
+		return []rowRange{{0, numRows}}
+	}
+
+	var ranges []rowRange
+	for i, n := 0, offsetIndex.NumPages(); i < n; i++ {
+		// Null values never satisfy predicates.
+		if columnIndex.NullPage(i) || !f.overlaps(columnIndex.MinValue(i), columnIndex.MaxValue(i)) {
+			continue
+		}
+		end := numRows
+		if i+1 < n {
+			end = offsetIndex.FirstRowIndex(i + 1)
+		}
+		ranges = appendRowRange(ranges, rowRange{offsetIndex.FirstRowIndex(i), end})
+	}
+	return ranges
+}
+
+// overlaps returns true if some values between min and max (inclusive) may
+// satisfy the filter.
+func (f *queryFilter) overlaps(min, max Value) bool {
+	switch f.op {
+	case predicateLt:
+		return f.compare(min, f.value) < 0
+	case predicateLe:
+		return f.compare(min, f.value) <= 0
+	case predicateGt:
+		return f.compare(max, f.value) > 0
+	case predicateGe:
+		return f.compare(max, f.value) >= 0
+	default:
+		return f.compare(min, f.value) <= 0 && f.compare(f.value, max) <= 0
+	}
+}
@@ -0,0 +1,179 @@
+package parquet_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestQuery(t *testing.T) {
+	type Row struct {
+		ID   int64     `parquet:"id"`
+		Name string    `parquet:"name"`
+		TS   time.Time `parquet:"ts,timestamp(millisecond)"`
+	}
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Name: string(rune('a' + i)),
+			TS:   t0.Add(time.Duration(i) * time.Hour),
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.MaxRowsPerRowGroup(3)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.CollectReadStats(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collect := func(q *parquet.Query) ([]parquet.Row, error) {
+		t.Helper()
+		var values []parquet.Row
+		ch, errs := q.Rows(context.Background())
+		for row := range ch {
+			values = append(values, row)
+		}
+		return values, <-errs
+	}
+
+	query := f.Query().
+		Select("id", "name").
+		Where(parquet.Gt("ts", t0.Add(6*time.Hour)), parquet.Le("id", 8)).
+		Limit(10)
+
+	schema, err := query.Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if columns, want := schema.Columns(), [][]string{{"id"}, {"name"}}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns mismatch: want=%q got=%q", want, columns)
+	}
+
+	values, err := collect(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []parquet.Row{
+		{parquet.Int64Value(7).Level(0, 0, 0), parquet.ByteArrayValue([]byte("h")).Level(0, 0, 1)},
+		{parquet.Int64Value(8).Level(0, 0, 0), parquet.ByteArrayValue([]byte("i")).Level(0, 0, 1)},
+	}
+	if len(values) != len(want) {
+		t.Fatalf("number of rows mismatch: want=%d got=%d", len(want), len(values))
+	}
+	for i := range want {
+		if !values[i].Equal(want[i]) {
+			t.Errorf("row %d mismatch: want=%v got=%v", i, want[i], values[i])
+		}
+	}
+
+	// The first two row groups only have timestamps before t0+6h and must
+	// not be read.
+	if pages := f.Stats().Pages; pages != 2*3 {
+		t.Errorf("number of pages read mismatch: want=%d got=%d", 2*3, pages)
+	}
+
+	values, err = collect(f.Query().Where(parquet.Ge("name", "c")).Limit(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 4 {
+		t.Fatalf("number of rows mismatch: want=%d got=%d", 4, len(values))
+	}
+	if id := values[0][0].Int64(); id != 2 {
+		t.Errorf("first row mismatch: want=%d got=%d", 2, id)
+	}
+
+	if _, err := collect(f.Query().Select("nope")); err == nil {
+		t.Error("expected an error when selecting a column which does not exist")
+	}
+	if _, err := collect(f.Query().Where(parquet.Eq("nope", 1))); err == nil {
+		t.Error("expected an error when filtering on a column which does not exist")
+	}
+}
+
+func TestFileFilterRowGroups(t *testing.T) {
+	type Row struct {
+		ID     int64     `parquet:"id"`
+		Tenant string    `parquet:"tenant"`
+		TS     time.Time `parquet:"ts,timestamp(millisecond)"`
+	}
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]Row, 12)
+	for i := range rows {
+		rows[i] = Row{
+			ID:     int64(i),
+			Tenant: fmt.Sprintf("tenant-%d", i/6),
+			TS:     t0.Add(time.Duration(i) * time.Hour),
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.MaxRowsPerRowGroup(3)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numRowsOf := func(rowGroups []parquet.RowGroup) (numRows []int64) {
+		for _, rowGroup := range rowGroups {
+			numRows = append(numRows, rowGroup.NumRows())
+		}
+		return numRows
+	}
+
+	for _, test := range []struct {
+		scenario   string
+		predicates []parquet.Predicate
+		rowGroups  []int
+	}{
+		{scenario: "no predicates", rowGroups: []int{0, 1, 2, 3}},
+		{scenario: "timestamp", predicates: []parquet.Predicate{parquet.Ge("ts", t0.Add(4*time.Hour))}, rowGroups: []int{1, 2, 3}},
+		{scenario: "string", predicates: []parquet.Predicate{parquet.Eq("tenant", "tenant-1")}, rowGroups: []int{2, 3}},
+		{
+			scenario:   "conjunction",
+			predicates: []parquet.Predicate{parquet.Ge("ts", t0.Add(4*time.Hour)), parquet.Eq("tenant", "tenant-0")},
+			rowGroups:  []int{1},
+		},
+		{scenario: "no matches", predicates: []parquet.Predicate{parquet.Gt("id", 100)}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			rowGroups, err := f.FilterRowGroups(test.predicates...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want []parquet.RowGroup
+			for _, i := range test.rowGroups {
+				want = append(want, f.RowGroups()[i])
+			}
+			if len(rowGroups) != len(want) {
+				t.Fatalf("number of row groups mismatch: want=%v got=%v", numRowsOf(want), numRowsOf(rowGroups))
+			}
+			for i := range want {
+				if rowGroups[i] != want[i] {
+					t.Errorf("row group %d mismatch", i)
+				}
+			}
+		})
+	}
+
+	if _, err := f.FilterRowGroups(parquet.Eq("nope", 1)); err == nil {
+		t.Error("expected an error when filtering on a column which does not exist")
+	}
+	if _, err := f.FilterRowGroups(parquet.Eq("id", struct{}{})); err == nil {
+		t.Error("expected an error when filtering on a value which cannot be compared")
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 )
 
 // GenericReader is similar to a Reader but uses a type parameter to define the
@@ -14,6 +17,10 @@ import (
 type GenericReader[T any] struct {
 	base Reader
 	read readFunc[T]
+	// When the reader is configured with a timestamp location, this function
+	// localizes the time values of rows read from columns with local semantics.
+	// It is nil if the rows do not contain such values.
+	localizeTimestamps localizeTimestampsFunc
 }
 
 // NewGenericReader is like NewReader but returns GenericReader[T] suited to write
@@ -37,30 +44,53 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 		panic(err)
 	}
 
-	rowGroup := fileRowGroupOf(f)
+	scan, err := f.filterRowGroups(c.Filter, true)
+	if err != nil {
+		panic(err)
+	}
+	rowGroup := fileRowGroupOf(f, readRowGroups(scan))
 
 	t := typeOf[T]()
 	if c.Schema == nil {
 		if t == nil {
 			c.Schema = rowGroup.Schema()
+			if c.Int96Timestamps {
+				c.Schema = int96TimestampsSchema(c.Schema)
+			}
 		} else {
 			c.Schema = schemaOf(dereference(t))
 		}
 	}
 
+	if c.Schema, err = c.readSchema(c.Schema, f.schema); err != nil {
+		panic(err)
+	}
+
 	r := &GenericReader[T]{
 		base: Reader{
 			file: reader{
 				schema:   c.Schema,
 				rowGroup: rowGroup,
 			},
+			owned: ownedFile(input, f),
+			scan:  scan,
 		},
 	}
 
+	if c.TimestampLocation != nil && t != nil {
+		r.localizeTimestamps = localizeTimestampsFuncOf(t, c.Schema, f.schema, c.TimestampLocation)
+	}
+
 	if !nodesAreEqual(c.Schema, f.schema) {
 		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c.Schema)
 	}
 
+	if !c.ReadDeleted {
+		r.base.file.rowGroup = skipDeletedRows(r.base.file.rowGroup, rowGroup)
+	}
+
+	r.base.file.setColumnMasks(c.ColumnMasks)
+	r.base.read.columnMasks = c.ColumnMasks
 	r.base.read.init(r.base.file.schema, r.base.file.rowGroup)
 	r.read = readFuncOf[T](t, r.base.file.schema)
 	return r
@@ -76,24 +106,42 @@ func NewGenericRowGroupReader[T any](rowGroup RowGroup, options ...ReaderOption)
 	if c.Schema == nil {
 		if t == nil {
 			c.Schema = rowGroup.Schema()
+			if c.Int96Timestamps {
+				c.Schema = int96TimestampsSchema(c.Schema)
+			}
 		} else {
 			c.Schema = schemaOf(dereference(t))
 		}
 	}
 
+	if c.Schema, err = c.readSchema(c.Schema, rowGroup.Schema()); err != nil {
+		panic(err)
+	}
+
 	r := &GenericReader[T]{
 		base: Reader{
 			file: reader{
 				schema:   c.Schema,
 				rowGroup: rowGroup,
 			},
+			scan: []scannedRowGroup{{rowGroup: rowGroup}},
 		},
 	}
 
+	if c.TimestampLocation != nil && t != nil {
+		r.localizeTimestamps = localizeTimestampsFuncOf(t, c.Schema, rowGroup.Schema(), c.TimestampLocation)
+	}
+
 	if !nodesAreEqual(c.Schema, rowGroup.Schema()) {
 		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c.Schema)
 	}
 
+	if !c.ReadDeleted {
+		r.base.file.rowGroup = skipDeletedRows(r.base.file.rowGroup, rowGroup)
+	}
+
+	r.base.file.setColumnMasks(c.ColumnMasks)
+	r.base.read.columnMasks = c.ColumnMasks
 	r.base.read.init(r.base.file.schema, r.base.file.rowGroup)
 	r.read = readFuncOf[T](t, r.base.file.schema)
 	return r
@@ -105,11 +153,27 @@ func (r *GenericReader[T]) Reset() {
 
 // Read reads the next rows from the reader into the given rows slice up to len(rows).
 //
-// The returned values are safe to reuse across Read calls and do not share
-// memory with the reader's underlying page buffers.
+// Read follows the contract of io.Reader: the caller allocates the rows slice
+// and may reuse it across calls to read rows in batches, for example:
+//
+//	rows := make([]RowType, 1000)
+//	for {
+//		n, err := reader.Read(rows)
+//		process(rows[:n])
+//		if err != nil {
+//			...
+//		}
+//	}
+//
+// Each row read overwrites the fields of the element of the slice that it is
+// read into, nulls reset the fields to their zero value. The returned values
+// are safe to retain across Read calls and do not share memory with the
+// reader's underlying page buffers.
 //
 // The method returns the number of rows read and io.EOF when no more rows
-// can be read from the reader.
+// can be read from the reader. Callers should always process the n rows
+// returned before considering the error, since the last rows of the file may
+// be returned along with io.EOF.
 func (r *GenericReader[T]) Read(rows []T) (int, error) {
 	return r.read(r, rows)
 }
@@ -122,6 +186,10 @@ func (r *GenericReader[T]) Schema() *Schema {
 	return r.base.Schema()
 }
 
+func (r *GenericReader[T]) Explain() ScanPlan {
+	return r.base.Explain()
+}
+
 func (r *GenericReader[T]) NumRows() int64 {
 	return r.base.NumRows()
 }
@@ -165,6 +233,9 @@ func (r *GenericReader[T]) readRows(rows []T) (int, error) {
 				if err2 := schema.Reconstruct(&rows[nTotal+i], row); err2 != nil {
 					return nTotal + i, err2
 				}
+				if r.localizeTimestamps != nil {
+					r.localizeTimestamps(reflect.ValueOf(&rows[nTotal+i]).Elem())
+				}
 			}
 		}
 		nTotal += n
@@ -237,6 +308,24 @@ type Reader struct {
 	read     reader
 	rowIndex int64
 	rowbuf   []Row
+	owned    *File
+	// Row groups of the file that the reader considers, before conversion to
+	// the read schema, used to explain the scan.
+	scan []scannedRowGroup
+	// Row group converted to the schema of the reader before deleted rows are
+	// skipped, and the row group that it was converted from, which Read uses
+	// to derive the row groups of the Go values that it reads into.
+	rowGroup RowGroup
+	source   RowGroup
+	config   *ReaderConfig
+
+	// Configuration and cache of the function localizing timestamps of the
+	// last type of Go values read when the reader is configured with a
+	// timestamp location.
+	fileSchema         *Schema
+	timestampLocation  *time.Location
+	timestampsType     reflect.Type
+	localizeTimestamps localizeTimestampsFunc
 }
 
 // NewReader constructs a parquet reader reading rows from the given
@@ -272,22 +361,160 @@ func NewReader(input io.ReaderAt, options ...ReaderOption) *Reader {
 		panic(err)
 	}
 
+	scan, err := f.filterRowGroups(c.Filter, true)
+	if err != nil {
+		panic(err)
+	}
+	rowGroup := fileRowGroupOf(f, readRowGroups(scan))
+
 	r := &Reader{
 		file: reader{
 			schema:   f.schema,
-			rowGroup: fileRowGroupOf(f),
+			rowGroup: rowGroup,
 		},
+		owned:             ownedFile(input, f),
+		scan:              scan,
+		source:            rowGroup,
+		config:            c,
+		fileSchema:        f.schema,
+		timestampLocation: c.TimestampLocation,
+	}
+
+	if c.Schema == nil && c.Int96Timestamps {
+		c.Schema = int96TimestampsSchema(f.schema)
 	}
 
 	if c.Schema != nil {
+		if c.Schema, err = c.readSchema(c.Schema, f.schema); err != nil {
+			panic(err)
+		}
 		r.file.schema = c.Schema
 		r.file.rowGroup = convertRowGroupTo(r.file.rowGroup, c.Schema)
 	}
 
+	r.rowGroup = r.file.rowGroup
+	if !c.ReadDeleted {
+		r.file.rowGroup = skipDeletedRows(r.file.rowGroup, rowGroup)
+	}
+
+	r.file.setColumnMasks(c.ColumnMasks)
+	r.read.columnMasks = c.ColumnMasks
 	r.read.init(r.file.schema, r.file.rowGroup)
 	return r
 }
 
+// readSchema returns the schema that rows are read with from row groups of the
+// file schema when reading rows of the given schema, after applying the options
+// of c which match its columns with those of the file and validate it against
+// the file schema.
+func (c *ReaderConfig) readSchema(schema, file *Schema) (*Schema, error) {
+	if c.MatchColumnNames != nil || len(c.ColumnAliases) > 0 || c.MatchFieldIDs {
+		schema = matchColumnNames(schema, file, c.MatchColumnNames, c.ColumnAliases, c.MatchFieldIDs)
+	}
+	if c.StrictSchema {
+		if err := checkSchemaMatch(schema, file, c.StrictSchemaIgnore); err != nil {
+			return nil, err
+		}
+	}
+	if c.DisallowNarrowing {
+		if err := checkNarrowing(schema, file); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+// checkSchemaMatch returns an error wrapping ErrSchemaMismatch if the leaf
+// columns of the read schema differ from those of the file schema, ignoring
+// the columns nested under one of the dot-separated paths in ignore.
+func checkSchemaMatch(read, file Node, ignore []string) error {
+	ignored := func(path columnPath) bool {
+		for _, prefix := range ignore {
+			p := strings.Split(prefix, ".")
+			if len(p) <= len(path) && path[:len(p)].equal(p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	readColumns := make(map[string]columnPath)
+	forEachLeafColumnOf(read, func(leaf leafColumn) {
+		readColumns[leaf.path.String()] = leaf.path
+	})
+
+	var missingFromRead, missingFromFile []string
+	forEachLeafColumnOf(file, func(leaf leafColumn) {
+		path := leaf.path.String()
+		if _, ok := readColumns[path]; ok {
+			delete(readColumns, path)
+		} else if !ignored(leaf.path) {
+			missingFromRead = append(missingFromRead, path)
+		}
+	})
+	for path, columnPath := range readColumns {
+		if !ignored(columnPath) {
+			missingFromFile = append(missingFromFile, path)
+		}
+	}
+
+	if len(missingFromRead) == 0 && len(missingFromFile) == 0 {
+		return nil
+	}
+
+	sort.Strings(missingFromFile)
+	var reasons []string
+	if len(missingFromRead) > 0 {
+		reasons = append(reasons, "file columns not read: "+strings.Join(missingFromRead, ", "))
+	}
+	if len(missingFromFile) > 0 {
+		reasons = append(reasons, "columns missing from the file: "+strings.Join(missingFromFile, ", "))
+	}
+	return fmt.Errorf("%w: %s", ErrSchemaMismatch, strings.Join(reasons, "; "))
+}
+
+// checkNarrowing returns an error wrapping ErrSchemaMismatch if reading the
+// leaf columns of the file schema into the columns of the read schema with the
+// same paths would require narrowing conversions of their values.
+func checkNarrowing(read, file Node) error {
+	fileColumns := make(map[string]Type)
+	forEachLeafColumnOf(file, func(leaf leafColumn) {
+		fileColumns[leaf.path.String()] = leaf.node.Type()
+	})
+
+	var narrowed []string
+	forEachLeafColumnOf(read, func(leaf leafColumn) {
+		path := leaf.path.String()
+		if fileType, ok := fileColumns[path]; ok && isNarrowing(leaf.node.Type(), fileType) {
+			narrowed = append(narrowed, fmt.Sprintf("%s (%s to %s)", path, fileType, leaf.node.Type()))
+		}
+	})
+
+	if len(narrowed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: columns narrowed: %s", ErrSchemaMismatch, strings.Join(narrowed, ", "))
+}
+
+// isNarrowing reports whether converting values of the source type to the
+// target type may lose information.
+func isNarrowing(target, source Type) bool {
+	targetKind, sourceKind := target.Kind(), source.Kind()
+	if targetKind == sourceKind {
+		return targetKind == FixedLenByteArray && target.Length() < source.Length()
+	}
+	switch sourceKind {
+	case Boolean:
+		return false
+	case Int32:
+		return targetKind != Int64 && targetKind != Double && targetKind != ByteArray
+	case Float:
+		return targetKind != Double && targetKind != ByteArray
+	default:
+		return targetKind != ByteArray
+	}
+}
+
 func openFile(input io.ReaderAt) (*File, error) {
 	f, _ := input.(*File)
 	if f != nil {
@@ -300,8 +527,17 @@ func openFile(input io.ReaderAt) (*File, error) {
 	return OpenFile(input, n)
 }
 
-func fileRowGroupOf(f *File) RowGroup {
-	switch rowGroups := f.RowGroups(); len(rowGroups) {
+// ownedFile returns f if it was opened on behalf of the reader from the input,
+// in which case closing the reader must also close the file.
+func ownedFile(input io.ReaderAt, f *File) *File {
+	if input == io.ReaderAt(f) {
+		return nil
+	}
+	return f
+}
+
+func fileRowGroupOf(f *File, rowGroups []RowGroup) RowGroup {
+	switch len(rowGroups) {
 	case 0:
 		return newEmptyRowGroup(f.Schema())
 	case 1:
@@ -321,7 +557,14 @@ func NewRowGroupReader(rowGroup RowGroup, options ...ReaderOption) *Reader {
 		panic(err)
 	}
 
+	source := rowGroup
+	if c.Schema == nil && c.Int96Timestamps {
+		c.Schema = int96TimestampsSchema(rowGroup.Schema())
+	}
 	if c.Schema != nil {
+		if c.Schema, err = c.readSchema(c.Schema, rowGroup.Schema()); err != nil {
+			panic(err)
+		}
 		rowGroup = convertRowGroupTo(rowGroup, c.Schema)
 	}
 
@@ -330,8 +573,20 @@ func NewRowGroupReader(rowGroup RowGroup, options ...ReaderOption) *Reader {
 			schema:   rowGroup.Schema(),
 			rowGroup: rowGroup,
 		},
+		scan:              []scannedRowGroup{{rowGroup: source}},
+		rowGroup:          rowGroup,
+		source:            source,
+		config:            c,
+		fileSchema:        source.Schema(),
+		timestampLocation: c.TimestampLocation,
+	}
+
+	if !c.ReadDeleted {
+		r.file.rowGroup = skipDeletedRows(rowGroup, source)
 	}
 
+	r.file.setColumnMasks(c.ColumnMasks)
+	r.read.columnMasks = c.ColumnMasks
 	r.read.init(r.file.schema, r.file.rowGroup)
 	return r
 }
@@ -409,23 +664,49 @@ func (r *Reader) Read(row interface{}) error {
 		return err
 	}
 
-	r.rowIndex++
-	return r.read.schema.Reconstruct(row, r.rowbuf[0])
+	r.rowIndex = r.read.rowIndex
+	if err := r.read.schema.Reconstruct(row, r.rowbuf[0]); err != nil {
+		return err
+	}
+	if r.timestampLocation != nil {
+		r.localizeRowTimestamps(row)
+	}
+	return nil
+}
+
+func (r *Reader) localizeRowTimestamps(row interface{}) {
+	v := reflect.ValueOf(row)
+	if t := v.Type(); t != r.timestampsType {
+		r.timestampsType = t
+		r.localizeTimestamps = localizeTimestampsFuncOf(t, r.read.schema, r.fileSchema, r.timestampLocation)
+	}
+	if r.localizeTimestamps != nil {
+		r.localizeTimestamps(v)
+	}
 }
 
 func (r *Reader) updateReadSchema(rowType reflect.Type) error {
-	schema := schemaOf(rowType)
+	schema, err := r.config.readSchema(schemaOf(rowType), r.file.schema)
+	if err != nil {
+		return err
+	}
+	rowGroup := r.rowGroup
 
-	if nodesAreEqual(schema, r.file.schema) {
-		r.read.init(schema, r.file.rowGroup)
-	} else {
+	if !nodesAreEqual(schema, r.file.schema) {
 		conv, err := Convert(schema, r.file.schema)
 		if err != nil {
 			return err
 		}
-		r.read.init(schema, ConvertRowGroup(r.file.rowGroup, conv))
+		rowGroup = ConvertRowGroup(rowGroup, conv)
 	}
 
+	// Converted row groups read the column chunks of the row group they are
+	// converted from, so deleted rows must be skipped after the conversion.
+	if !r.config.ReadDeleted {
+		rowGroup = skipDeletedRows(rowGroup, r.source)
+	}
+
+	r.read.init(schema, rowGroup)
 	r.seen = rowType
 	return nil
 }
@@ -441,13 +722,22 @@ func (r *Reader) ReadRows(rows []Row) (int, error) {
 		return 0, err
 	}
 	n, err := r.file.ReadRows(rows)
-	r.rowIndex += int64(n)
+	r.rowIndex = r.file.rowIndex
 	return n, err
 }
 
 // Schema returns the schema of rows read by r.
 func (r *Reader) Schema() *Schema { return r.file.schema }
 
+// Explain returns the plan of the scan performed by r, describing the row
+// groups and columns that it reads, and the estimated number of bytes read.
+//
+// Row groups are identified by their index in the file. Those that cannot match
+// the Filter option of r are listed with the reason why they are skipped, the
+// column chunks pruned from the other row groups are those of columns which are
+// not part of the schema of rows read by r.
+func (r *Reader) Explain() ScanPlan { return explainScan(r.file.schema, r.scan) }
+
 // NumRows returns the number of rows that can be read from r.
 func (r *Reader) NumRows() int64 { return r.file.rowGroup.NumRows() }
 
@@ -461,6 +751,13 @@ func (r *Reader) SeekToRow(rowIndex int64) error {
 }
 
 // Close closes the reader, preventing more rows from being read.
+//
+// Page buffers held by the reader are returned to their pools and background
+// goroutines prefetching pages are stopped. When the reader was constructed
+// from an io.ReaderAt which was not a *File, the file opened by the reader is
+// also closed.
+//
+// Calling Close multiple times is safe.
 func (r *Reader) Close() error {
 	if err := r.read.Close(); err != nil {
 		return err
@@ -468,6 +765,11 @@ func (r *Reader) Close() error {
 	if err := r.file.Close(); err != nil {
 		return err
 	}
+	if r.owned != nil {
+		f := r.owned
+		r.owned = nil
+		return f.Close()
+	}
 	return nil
 }
 
@@ -481,14 +783,24 @@ type reader struct {
 	rowGroup RowGroup
 	rows     Rows
 	rowIndex int64
+	// Masks configured on the reader, keyed by column path, and the masks
+	// applied to the columns of the schema, indexed by column index.
+	columnMasks map[string]ColumnMask
+	masks       []ColumnMask
 }
 
 func (r *reader) init(schema *Schema, rowGroup RowGroup) {
 	r.schema = schema
 	r.rowGroup = rowGroup
+	r.masks = columnMasksOf(schema, r.columnMasks)
 	r.Reset()
 }
 
+func (r *reader) setColumnMasks(masks map[string]ColumnMask) {
+	r.columnMasks = masks
+	r.masks = columnMasksOf(r.schema, masks)
+}
+
 func (r *reader) Reset() {
 	r.rowIndex = 0
 
@@ -524,7 +836,16 @@ func (r *reader) ReadRows(rows []Row) (int, error) {
 		}
 	}
 	n, err := r.rows.ReadRows(rows)
-	r.rowIndex += int64(n)
+	if r.masks != nil {
+		maskRows(rows[:n], r.masks)
+	}
+	if live, ok := r.rows.(*liveRows); ok {
+		// Row indexes are those of the underlying row group, they count the
+		// deleted rows skipped while reading.
+		r.rowIndex = live.rowIndex
+	} else {
+		r.rowIndex += int64(n)
+	}
 	return n, err
 }
 
@@ -547,6 +868,7 @@ func (r *reader) Close() (err error) {
 	r.rowGroup = nil
 	if r.rows != nil {
 		err = r.rows.Close()
+		r.rows = nil
 	}
 	return err
 }
@@ -9,9 +9,12 @@ import (
 	"math/rand"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/internal/quick"
 )
 
@@ -610,6 +613,685 @@ func TestReaderReadSubset(t *testing.T) {
 	}
 }
 
+func TestGenericReaderStrictSchema(t *testing.T) {
+	type Point3D struct {
+		X, Y, Z int64
+		Meta    struct{ A, B string }
+	}
+	type Point2D struct{ X, Y int64 }
+	type Point4D struct {
+		X, Y, Z, W int64
+		Meta       struct{ A, B string }
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []Point3D{{X: 1, Y: 2, Z: 3}}); err != nil {
+		t.Fatal(err)
+	}
+
+	newReader := func(t *testing.T, read func(), want string) {
+		t.Helper()
+		defer func() {
+			r := recover()
+			if want == "" {
+				if r != nil {
+					t.Fatalf("unexpected panic: %v", r)
+				}
+				return
+			}
+			err, _ := r.(error)
+			if !errors.Is(err, parquet.ErrSchemaMismatch) {
+				t.Fatalf("panic mismatch: want=%v got=%v", parquet.ErrSchemaMismatch, r)
+			}
+			if got := err.Error(); got != parquet.ErrSchemaMismatch.Error()+": "+want {
+				t.Fatalf("error mismatch: want=%q got=%q", want, got)
+			}
+		}()
+		read()
+	}
+
+	file := bytes.NewReader(buf.Bytes())
+
+	t.Run("match", func(t *testing.T) {
+		newReader(t, func() { parquet.NewGenericReader[Point3D](file, parquet.StrictSchema()) }, "")
+	})
+
+	t.Run("columns not read", func(t *testing.T) {
+		newReader(t, func() { parquet.NewGenericReader[Point2D](file, parquet.StrictSchema()) },
+			"file columns not read: Z, Meta.A, Meta.B")
+	})
+
+	t.Run("columns missing from the file", func(t *testing.T) {
+		newReader(t, func() { parquet.NewGenericReader[Point4D](file, parquet.StrictSchema()) },
+			"columns missing from the file: W")
+	})
+
+	t.Run("ignored columns", func(t *testing.T) {
+		newReader(t, func() { parquet.NewGenericReader[Point2D](file, parquet.StrictSchema("Z", "Meta")) }, "")
+		newReader(t, func() {
+			parquet.NewGenericReader[Point2D](file, parquet.StrictSchema("Z"), parquet.StrictSchema("Meta.A"))
+		},
+			"file columns not read: Meta.B")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		newReader(t, func() { parquet.NewGenericReader[Point2D](file) }, "")
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		newReader(t, func() { parquet.NewReader(file, parquet.SchemaOf(Point4D{}), parquet.StrictSchema()) },
+			"columns missing from the file: W")
+	})
+
+	t.Run("row group reader", func(t *testing.T) {
+		f, err := parquet.OpenFile(file, file.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		rowGroup := f.RowGroups()[0]
+		newReader(t, func() { parquet.NewRowGroupReader(rowGroup, parquet.SchemaOf(Point3D{}), parquet.StrictSchema()) }, "")
+		newReader(t, func() { parquet.NewRowGroupReader(rowGroup, parquet.SchemaOf(Point2D{}), parquet.StrictSchema()) },
+			"file columns not read: Z, Meta.A, Meta.B")
+	})
+
+	t.Run("reader read", func(t *testing.T) {
+		reader := parquet.NewReader(file, parquet.StrictSchema())
+		defer reader.Close()
+
+		err := reader.Read(&Point2D{})
+		if !errors.Is(err, parquet.ErrSchemaMismatch) {
+			t.Fatalf("error mismatch: want=%v got=%v", parquet.ErrSchemaMismatch, err)
+		}
+		row := Point3D{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if row.X != 1 || row.Y != 2 || row.Z != 3 {
+			t.Errorf("row mismatch: %+v", row)
+		}
+	})
+}
+
+func TestGenericReaderTypeWidening(t *testing.T) {
+	type Narrow struct {
+		I int32   `parquet:"i"`
+		F float32 `parquet:"f"`
+	}
+	type Wide struct {
+		I int64   `parquet:"i"`
+		F float64 `parquet:"f"`
+	}
+
+	t.Run("widening", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, []Narrow{{I: -1, F: 1.5}, {I: math.MaxInt32, F: -0.25}}); err != nil {
+			t.Fatal(err)
+		}
+
+		reader := parquet.NewGenericReader[Wide](bytes.NewReader(buf.Bytes()), parquet.DisallowNarrowing(true))
+		defer reader.Close()
+
+		rows := make([]Wide, 2)
+		if _, err := reader.Read(rows); err != nil && !errors.Is(err, io.EOF) {
+			t.Fatal(err)
+		}
+		want := []Wide{{I: -1, F: 1.5}, {I: math.MaxInt32, F: -0.25}}
+		if !reflect.DeepEqual(want, rows) {
+			t.Errorf("want=%+v got=%+v", want, rows)
+		}
+	})
+
+	t.Run("narrowing", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, []Wide{{I: 42, F: 0.5}}); err != nil {
+			t.Fatal(err)
+		}
+		file := bytes.NewReader(buf.Bytes())
+
+		rows := make([]Narrow, 1)
+		if _, err := parquet.NewGenericReader[Narrow](file).Read(rows); err != nil && !errors.Is(err, io.EOF) {
+			t.Fatal(err)
+		}
+		if want := (Narrow{I: 42, F: 0.5}); rows[0] != want {
+			t.Errorf("want=%+v got=%+v", want, rows[0])
+		}
+
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, parquet.ErrSchemaMismatch) {
+				t.Fatalf("want=%v got=%v", parquet.ErrSchemaMismatch, err)
+			}
+			if want := parquet.ErrSchemaMismatch.Error() + ": columns narrowed: i (INT(64,true) to INT(32,true)), f (DOUBLE to FLOAT)"; err.Error() != want {
+				t.Errorf("want=%q got=%q", want, err.Error())
+			}
+		}()
+		parquet.NewGenericReader[Narrow](file, parquet.DisallowNarrowing(true))
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, []Wide{{I: 42, F: 0.5}}); err != nil {
+			t.Fatal(err)
+		}
+		file := bytes.NewReader(buf.Bytes())
+
+		reader := parquet.NewReader(file, parquet.DisallowNarrowing(true))
+		defer reader.Close()
+		if err := reader.Read(&Narrow{}); !errors.Is(err, parquet.ErrSchemaMismatch) {
+			t.Errorf("want=%v got=%v", parquet.ErrSchemaMismatch, err)
+		}
+		row := Wide{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if want := (Wide{I: 42, F: 0.5}); row != want {
+			t.Errorf("want=%+v got=%+v", want, row)
+		}
+
+		f, err := parquet.OpenFile(file, file.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, parquet.ErrSchemaMismatch) {
+				t.Fatalf("want=%v got=%v", parquet.ErrSchemaMismatch, err)
+			}
+		}()
+		parquet.NewRowGroupReader(f.RowGroups()[0], parquet.SchemaOf(Narrow{}), parquet.DisallowNarrowing(true))
+	})
+}
+
+func TestGenericReaderMatchColumnNames(t *testing.T) {
+	type Address struct {
+		StreetName string `parquet:"street_name"`
+		ZipCode    string `parquet:"zip_code"`
+	}
+	type FileRow struct {
+		UserID    int64    `parquet:"user_id"`
+		FirstName string   `parquet:"first_name"`
+		Address   Address  `parquet:"home_address"`
+		Tags      []string `parquet:"TAGS,list"`
+	}
+	type ReadAddress struct {
+		StreetName string
+		ZipCode    string
+	}
+	type ReadRow struct {
+		UserID    int64
+		FirstName string
+		Address   ReadAddress `parquet:"homeAddress"`
+		Tags      []string    `parquet:",list"`
+	}
+
+	buf := new(bytes.Buffer)
+	fileRows := []FileRow{
+		{UserID: 1, FirstName: "Luke", Address: Address{"Main St", "12345"}, Tags: []string{"a", "b"}},
+		{UserID: 2, FirstName: "Leia", Address: Address{"Side St", "67890"}},
+	}
+	if err := parquet.Write(buf, fileRows); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(t *testing.T, options ...parquet.ReaderOption) []ReadRow {
+		reader := parquet.NewGenericReader[ReadRow](bytes.NewReader(buf.Bytes()), options...)
+		defer reader.Close()
+		rows := make([]ReadRow, len(fileRows))
+		n, err := reader.Read(rows)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		return rows[:n]
+	}
+
+	t.Run("normalize", func(t *testing.T) {
+		want := []ReadRow{
+			{UserID: 1, FirstName: "Luke", Address: ReadAddress{"Main St", "12345"}, Tags: []string{"a", "b"}},
+			{UserID: 2, FirstName: "Leia", Address: ReadAddress{"Side St", "67890"}, Tags: []string{}},
+		}
+		got := read(t, parquet.MatchColumnNames(parquet.NormalizeName), parquet.StrictSchema())
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+
+	t.Run("fold case", func(t *testing.T) {
+		want := []ReadRow{
+			{Tags: []string{"a", "b"}},
+			{Tags: []string{}},
+		}
+		got := read(t, parquet.MatchColumnNames(parquet.FoldCase))
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		want := []ReadRow{{Tags: []string{}}, {Tags: []string{}}}
+		got := read(t)
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+
+	readRows := func(t *testing.T, reader *parquet.Reader) []ReadRow {
+		defer reader.Close()
+		var rows []ReadRow
+		for {
+			row := ReadRow{}
+			if err := reader.Read(&row); err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				return rows
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	want := []ReadRow{
+		{UserID: 1, FirstName: "Luke", Address: ReadAddress{"Main St", "12345"}, Tags: []string{"a", "b"}},
+		{UserID: 2, FirstName: "Leia", Address: ReadAddress{"Side St", "67890"}, Tags: []string{}},
+	}
+
+	t.Run("reader", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(buf.Bytes()), parquet.MatchColumnNames(parquet.NormalizeName), parquet.StrictSchema())
+		if got := readRows(t, reader); !reflect.DeepEqual(want, got) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+
+	t.Run("row group reader", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader := parquet.NewRowGroupReader(f.RowGroups()[0],
+			parquet.SchemaOf(ReadRow{}),
+			parquet.MatchColumnNames(parquet.NormalizeName),
+			parquet.StrictSchema(),
+		)
+		if got := readRows(t, reader); !reflect.DeepEqual(want, got) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+}
+
+func TestGenericReaderColumnAliases(t *testing.T) {
+	type AddressV1 struct {
+		Zip string `parquet:"zip"`
+	}
+	type RowV1 struct {
+		UID     int64     `parquet:"uid"`
+		Name    string    `parquet:"name"`
+		Address AddressV1 `parquet:"addr"`
+	}
+	type Address struct {
+		ZipCode string `parquet:"zip_code"`
+	}
+	type Row struct {
+		UserID  int64   `parquet:"user_id"`
+		Name    string  `parquet:"name"`
+		Address Address `parquet:"address"`
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []RowV1{{UID: 1, Name: "Luke", Address: AddressV1{Zip: "12345"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	options := []parquet.ReaderOption{
+		parquet.ColumnAliases(map[string]string{"uid": "user_id"}),
+		parquet.ColumnAliases(map[string]string{"addr": "address", "addr.zip": "zip_code"}),
+		parquet.StrictSchema(),
+	}
+	reader := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()), options...)
+	defer reader.Close()
+
+	rows := make([]Row, 2)
+	n, err := reader.Read(rows)
+	if err != io.EOF {
+		t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+	}
+	want := Row{UserID: 1, Name: "Luke", Address: Address{ZipCode: "12345"}}
+	if n != 1 || rows[0] != want {
+		t.Errorf("rows mismatch: want=%+v got=%+v", want, rows[:n])
+	}
+
+	t.Run("reader", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(buf.Bytes()), options...)
+		defer reader.Close()
+
+		row := Row{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if row != want {
+			t.Errorf("rows mismatch: want=%+v got=%+v", want, row)
+		}
+	})
+}
+
+func TestGenericReaderMatchFieldIDs(t *testing.T) {
+	type AddressV1 struct {
+		Zip string `parquet:"zip,id(5)"`
+	}
+	type RowV1 struct {
+		UID     int64     `parquet:"uid,id(1)"`
+		Name    string    `parquet:"name,id(2)"`
+		Email   string    `parquet:"email,id(3)"`
+		Address AddressV1 `parquet:"addr,id(4)"`
+	}
+	type Address struct {
+		ZipCode string `parquet:"zip_code,id(5)"`
+	}
+	type Row struct {
+		UserID   int64   `parquet:"user_id,id(1)"`
+		FullName string  `parquet:"full_name,id(2)"`
+		Address  Address `parquet:"address,id(4)"`
+		// The column was added after the name column was renamed, it must
+		// not be read from the column of the file with the same name.
+		Name string `parquet:"name,id(6)"`
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []RowV1{{UID: 1, Name: "Luke", Email: "luke@example.com", Address: AddressV1{Zip: "12345"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()), parquet.MatchFieldIDs(true))
+	defer reader.Close()
+
+	rows := make([]Row, 2)
+	n, err := reader.Read(rows)
+	if err != io.EOF {
+		t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+	}
+	want := Row{UserID: 1, FullName: "Luke", Address: Address{ZipCode: "12345"}}
+	if n != 1 || rows[0] != want {
+		t.Errorf("rows mismatch: want=%+v got=%+v", want, rows[:n])
+	}
+
+	t.Run("reader", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(buf.Bytes()), parquet.MatchFieldIDs(true))
+		defer reader.Close()
+
+		row := Row{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if row != want {
+			t.Errorf("rows mismatch: want=%+v got=%+v", want, row)
+		}
+	})
+}
+
+func TestGenericReaderDefaultValues(t *testing.T) {
+	type AddressV1 struct {
+		City string `parquet:"city"`
+	}
+	type RowV1 struct {
+		ID      int64      `parquet:"id"`
+		Address *AddressV1 `parquet:"address,optional"`
+	}
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip,default(00000)"`
+	}
+	type Row struct {
+		ID      int64     `parquet:"id,default(-1)"`
+		Retries *int32    `parquet:"retries,optional,default(3)"`
+		Enabled bool      `parquet:"enabled,default(true)"`
+		Ratio   float64   `parquet:"ratio,default(0.5)"`
+		Region  string    `parquet:"region,default(us-east-1)"`
+		Created time.Time `parquet:"created,timestamp(millisecond),default(2023-01-02T03:04:05Z)"`
+		Address *Address  `parquet:"address,optional"`
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []RowV1{
+		{ID: 1, Address: &AddressV1{City: "Paris"}},
+		{ID: 2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retries := int32(3)
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := []Row{
+		{ID: 1, Retries: &retries, Enabled: true, Ratio: 0.5, Region: "us-east-1", Created: created, Address: &Address{City: "Paris", Zip: "00000"}},
+		{ID: 2, Retries: &retries, Enabled: true, Ratio: 0.5, Region: "us-east-1", Created: created},
+	}
+	for i := range want {
+		if !want[i].Created.Equal(rows[i].Created) {
+			t.Errorf("created mismatch at row %d: want=%v got=%v", i, want[i].Created, rows[i].Created)
+		}
+		rows[i].Created = want[i].Created
+	}
+	if !reflect.DeepEqual(want, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+	}
+}
+
+func TestGenericReaderReuseRows(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Name  *string  `parquet:"name,optional"`
+		Data  []byte   `parquet:"data,optional"`
+		Score int32    `parquet:"score,optional"`
+		Tags  []string `parquet:"tags,list"`
+	}
+
+	name := "name"
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		rows[i].Tags = []string{}
+		if i%3 == 0 {
+			rows[i].Name = &name
+			rows[i].Data = []byte{byte(i)}
+			rows[i].Score = int32(i + 1)
+			rows[i].Tags = []string{"a", "b"}
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[Row](bytes.NewReader(buffer.Bytes()))
+	defer reader.Close()
+
+	var got []Row
+	batch := make([]Row, 4)
+	for {
+		n, err := reader.Read(batch)
+		got = append(got, batch[:n]...)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	if len(got) != len(rows) {
+		t.Fatalf("number of rows mismatch: want=%d got=%d", len(rows), len(got))
+	}
+	for i := range rows {
+		if !reflect.DeepEqual(rows[i], got[i]) {
+			t.Errorf("row %d mismatch:\nwant=%+v\ngot= %+v", i, rows[i], got[i])
+		}
+	}
+}
+
+func TestGenericReaderExplain(t *testing.T) {
+	type RowV1 struct {
+		ID    int64  `parquet:"id"`
+		Name  string `parquet:"name"`
+		Email string `parquet:"email"`
+	}
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	buf := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[RowV1](buf, parquet.MaxRowsPerRowGroup(2))
+	if _, err := writer.Write([]RowV1{{1, "a", "a@x"}, {2, "b", "b@x"}, {3, "c", "c@x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	plan := reader.Explain()
+	if plan.NumRows != 3 {
+		t.Errorf("number of rows mismatch: want=%d got=%d", 3, plan.NumRows)
+	}
+	if len(plan.RowGroups) != 2 {
+		t.Fatalf("number of row groups mismatch: want=%d got=%d", 2, len(plan.RowGroups))
+	}
+
+	var estimatedBytes int64
+	for i, rowGroup := range plan.RowGroups {
+		if rowGroup.Index != i {
+			t.Errorf("row group index mismatch: want=%d got=%d", i, rowGroup.Index)
+		}
+		for _, column := range rowGroup.Columns {
+			read := column.Path[0] != "email"
+			if column.Read != read {
+				t.Errorf("column %q read mismatch: want=%t got=%t", column.Path, read, column.Read)
+			}
+			if !read && column.PruneReason == "" {
+				t.Errorf("column %q is pruned without a reason", column.Path)
+			}
+			if column.NumPages != 1 {
+				t.Errorf("column %q number of pages mismatch: want=%d got=%d", column.Path, 1, column.NumPages)
+			}
+			if column.CompressedBytes <= 0 {
+				t.Errorf("column %q has no compressed size", column.Path)
+			}
+			if read {
+				estimatedBytes += column.CompressedBytes
+			}
+		}
+	}
+	if plan.EstimatedBytes != estimatedBytes {
+		t.Errorf("estimated bytes mismatch: want=%d got=%d", estimatedBytes, plan.EstimatedBytes)
+	}
+	if s := plan.String(); !strings.Contains(s, "email") || !strings.Contains(s, "pruned") {
+		t.Errorf("plan does not mention the pruned column:\n%s", s)
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		reader := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()), parquet.Filter(parquet.Eq("id", 3)))
+		defer reader.Close()
+
+		plan := reader.Explain()
+		if plan.NumRows != 1 {
+			t.Errorf("number of rows mismatch: want=%d got=%d", 1, plan.NumRows)
+		}
+		if len(plan.RowGroups) != 2 {
+			t.Fatalf("number of row groups mismatch: want=%d got=%d", 2, len(plan.RowGroups))
+		}
+		skipped, read := plan.RowGroups[0], plan.RowGroups[1]
+		if skipped.Index != 0 || skipped.Read || skipped.PruneReason == "" || skipped.Columns != nil || skipped.EstimatedBytes != 0 {
+			t.Errorf("row group 0 is not pruned: %+v", skipped)
+		}
+		if read.Index != 1 || !read.Read || read.PruneReason != "" || len(read.Columns) != 3 {
+			t.Errorf("row group 1 is not read: %+v", read)
+		}
+		if plan.EstimatedBytes != read.EstimatedBytes {
+			t.Errorf("estimated bytes mismatch: want=%d got=%d", read.EstimatedBytes, plan.EstimatedBytes)
+		}
+		if s := plan.String(); !strings.Contains(s, "row group 0: 2 rows, pruned ("+skipped.PruneReason+")") {
+			t.Errorf("plan does not mention the pruned row group:\n%s", s)
+		}
+	})
+}
+
+func TestReaderExplainPruneReasons(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+		N  int64 `parquet:"n"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(2 * i), N: int64(2 * i)}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows,
+		parquet.MaxRowsPerRowGroup(500),
+		parquet.PageBufferSize(256),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "id")),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		scenario string
+		filter   []parquet.Predicate
+		reasons  []string
+	}{
+		{
+			scenario: "statistics",
+			filter:   []parquet.Predicate{parquet.Eq("id", 4000)},
+			reasons:  []string{"statistics do not match the filter", "statistics do not match the filter"},
+		},
+		{
+			scenario: "bloom filter",
+			filter:   []parquet.Predicate{parquet.Eq("id", 1)},
+			reasons:  []string{"bloom filters do not match the filter", "statistics do not match the filter"},
+		},
+		{
+			scenario: "page index",
+			filter:   []parquet.Predicate{parquet.Eq("n", 10), parquet.Eq("id", 900)},
+			reasons:  []string{"page index does not match the filter", "statistics do not match the filter"},
+		},
+		{
+			scenario: "read",
+			filter:   []parquet.Predicate{parquet.Eq("id", 1200)},
+			reasons:  []string{"statistics do not match the filter", ""},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()), parquet.Filter(test.filter...))
+			defer reader.Close()
+
+			plan := reader.Explain()
+			if len(plan.RowGroups) != len(test.reasons) {
+				t.Fatalf("number of row groups mismatch: want=%d got=%d", len(test.reasons), len(plan.RowGroups))
+			}
+			for i, rowGroup := range plan.RowGroups {
+				if rowGroup.Index != i {
+					t.Errorf("row group index mismatch: want=%d got=%d", i, rowGroup.Index)
+				}
+				if rowGroup.PruneReason != test.reasons[i] {
+					t.Errorf("row group %d prune reason mismatch: want=%q got=%q", i, test.reasons[i], rowGroup.PruneReason)
+				}
+				if rowGroup.Read != (test.reasons[i] == "") {
+					t.Errorf("row group %d read mismatch: want=%t got=%t", i, test.reasons[i] == "", rowGroup.Read)
+				}
+			}
+			// The plan is returned by value, fmt must use its String method.
+			if s := fmt.Sprint(reader.Explain()); s != plan.String() || !strings.HasPrefix(s, "scan: 2 row groups") {
+				t.Errorf("plan is not formatted with its String method:\n%s", s)
+			}
+		})
+	}
+}
+
 func TestReaderSeekToRow(t *testing.T) {
 	type rowType struct {
 		Name utf8string `parquet:",dict"`
@@ -786,3 +1468,242 @@ func TestSeekToRowDictReadMultiplePages(t *testing.T) {
 		t.Fatalf("read != write")
 	}
 }
+
+func TestReaderFilter(t *testing.T) {
+	type Row struct {
+		ID     int64     `parquet:"id"`
+		Tenant string    `parquet:"tenant"`
+		TS     time.Time `parquet:"ts,timestamp(millisecond)"`
+	}
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]Row, 12)
+	for i := range rows {
+		rows[i] = Row{
+			ID:     int64(i),
+			Tenant: fmt.Sprintf("tenant-%d", i/6),
+			TS:     t0.Add(time.Duration(i) * time.Hour),
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.MaxRowsPerRowGroup(3)); err != nil {
+		t.Fatal(err)
+	}
+	openFile := func(t *testing.T) *parquet.File {
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.CollectReadStats(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	// Only the second row group may have rows of tenant-0 after t0+4h, it is
+	// read entirely, including the row at t0+3h.
+	filter := parquet.Filter(
+		parquet.Ge("ts", t0.Add(4*time.Hour)),
+		parquet.Eq("tenant", "tenant-0"),
+	)
+
+	t.Run("generic", func(t *testing.T) {
+		f := openFile(t)
+		reader := parquet.NewGenericReader[Row](f, filter)
+		defer reader.Close()
+
+		if numRows := reader.NumRows(); numRows != 3 {
+			t.Errorf("number of rows mismatch: want=3 got=%d", numRows)
+		}
+		got := make([]Row, 10)
+		n, err := reader.Read(got)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got[:n], rows[3:6]) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows[3:6], got[:n])
+		}
+
+		// The pages read must be those of the second row group only.
+		rowGroupFile := openFile(t)
+		rowGroupReader := parquet.NewGenericRowGroupReader[Row](rowGroupFile.RowGroups()[1])
+		if _, err := rowGroupReader.Read(got); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		if want, pages := rowGroupFile.Stats().Pages, f.Stats().Pages; pages != want {
+			t.Errorf("number of pages read mismatch: want=%d got=%d", want, pages)
+		}
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		reader := parquet.NewReader(openFile(t), filter, parquet.Filter(parquet.Lt("id", 3)))
+		defer reader.Close()
+
+		if numRows := reader.NumRows(); numRows != 0 {
+			t.Errorf("number of rows mismatch: want=0 got=%d", numRows)
+		}
+		if _, err := reader.ReadRows(make([]parquet.Row, 1)); err != io.EOF {
+			t.Errorf("reading rows when all row groups were skipped must return io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic when filtering on a column which does not exist")
+			}
+		}()
+		parquet.NewReader(openFile(t), parquet.Filter(parquet.Eq("nope", 1)))
+	})
+}
+
+func TestReaderFilterPages(t *testing.T) {
+	type Row struct {
+		parquet.SoftDelete
+		ID     int64    `parquet:"id"`
+		Tenant string   `parquet:"tenant"`
+		Tags   []string `parquet:"tags,list"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			SoftDelete: parquet.SoftDelete{Deleted: i%7 == 0},
+			ID:         int64(i),
+			Tenant:     fmt.Sprintf("tenant-%d", i%3),
+			Tags:       []string{"a", "b", "c"}[:i%4],
+		}
+	}
+
+	// Small pages so the pages of the columns have different row boundaries,
+	// the pages of other columns which overlap the matching pages of the id
+	// column are partially read.
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	openFile := func(t *testing.T) *parquet.File {
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.CollectReadStats(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	readAll := func(t *testing.T, reader *parquet.GenericReader[Row]) []Row {
+		values := make([]Row, 0, len(rows))
+		buf := make([]Row, 64)
+		for {
+			n, err := reader.Read(buf)
+			values = append(values, buf[:n]...)
+			if err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				return values
+			}
+		}
+	}
+
+	full := openFile(t)
+	fullReader := parquet.NewGenericReader[Row](full)
+	defer fullReader.Close()
+	readAll(t, fullReader)
+
+	f := openFile(t)
+	reader := parquet.NewGenericReader[Row](f, parquet.Filter(parquet.Ge("id", 500), parquet.Lt("id", 600)))
+	defer reader.Close()
+	values := readAll(t, reader)
+
+	if len(values) == 0 || len(values) >= len(rows)/2 {
+		t.Fatalf("rows of non-matching pages must be skipped: got %d rows", len(values))
+	}
+	first := values[0].ID
+	for i, value := range values {
+		if value.Deleted {
+			t.Fatalf("deleted row %d was returned", value.ID)
+		}
+		if !reflect.DeepEqual(value, rows[value.ID]) {
+			t.Fatalf("row mismatch:\nwant: %+v\ngot:  %+v", rows[value.ID], value)
+		}
+		if i > 0 && value.ID <= values[i-1].ID {
+			t.Fatalf("rows out of order: %d after %d", value.ID, values[i-1].ID)
+		}
+	}
+	// All the live rows between the first and last rows returned are read.
+	last := values[len(values)-1].ID
+	if first > 500 || last < 599 {
+		t.Errorf("matching rows were skipped: first=%d last=%d", first, last)
+	}
+	numLive := 0
+	for _, row := range rows[first : last+1] {
+		if !row.Deleted {
+			numLive++
+		}
+	}
+	if len(values) != numLive {
+		t.Errorf("number of rows mismatch: want=%d got=%d", numLive, len(values))
+	}
+
+	if pages, fullPages := f.Stats().Pages, full.Stats().Pages; pages >= fullPages/2 {
+		t.Errorf("pages of non-matching rows must not be read: pages=%d full=%d", pages, fullPages)
+	}
+	if numRows := reader.NumRows(); numRows != int64(len(rows)) {
+		t.Errorf("number of rows mismatch: want=%d got=%d", len(rows), numRows)
+	}
+
+	// Row indexes include the rows of skipped pages; seeking before the
+	// matching pages resumes reading at the first matching page.
+	for _, seek := range []struct{ rowIndex, firstID int64 }{{553, 554}, {0, first}} {
+		if err := reader.SeekToRow(seek.rowIndex); err != nil {
+			t.Fatal(err)
+		}
+		if values := readAll(t, reader); len(values) == 0 || values[0].ID != seek.firstID {
+			t.Errorf("first row mismatch after seeking to row %d: want=%d got=%+v", seek.rowIndex, seek.firstID, values[:1])
+		}
+	}
+}
+
+func TestReaderInt96Timestamps(t *testing.T) {
+	type Legacy struct {
+		Time deprecated.Int96 `parquet:"time"`
+	}
+	type Event struct {
+		Time time.Time `parquet:"time,timestamp(nanosecond)"`
+	}
+
+	times := []time.Time{
+		time.Date(2009, 11, 10, 23, 0, 0, 123456789, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+	}
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, []Legacy{
+		{Time: deprecated.TimeToInt96(times[0])},
+		{Time: deprecated.TimeToInt96(times[1])},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	input := bytes.NewReader(buffer.Bytes())
+
+	events := make([]Event, len(times))
+	if n, err := parquet.NewGenericReader[Event](input).Read(events); n != len(times) {
+		t.Fatalf("wrong number of rows: want=%d got=%d (%v)", len(times), n, err)
+	}
+	for i, event := range events {
+		if !event.Time.Equal(times[i]) {
+			t.Errorf("wrong time at index %d: want=%v got=%v", i, times[i], event.Time)
+		}
+	}
+
+	reader := parquet.NewReader(input, parquet.Int96Timestamps(true))
+	column, _ := reader.Schema().Lookup("time")
+	if lt := column.Node.Type().LogicalType(); lt == nil || lt.Timestamp == nil {
+		t.Fatalf("INT96 column not exposed as a timestamp: %s", column.Node.Type())
+	}
+	rows := make([]parquet.Row, len(times))
+	if n, err := reader.ReadRows(rows); n != len(times) {
+		t.Fatalf("wrong number of rows: want=%d got=%d (%v)", len(times), n, err)
+	}
+	for i, row := range rows {
+		if got := row[0].Int64(); got != times[i].UnixNano() {
+			t.Errorf("wrong value at index %d: want=%d got=%d", i, times[i].UnixNano(), got)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package parquet
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// Recompress returns a reader producing a copy of the parquet file f where the
+// pages of all column chunks are compressed with codec, for example to serve
+// files compressed with ZSTD to consumers which only support SNAPPY:
+//
+//	f, err := parquet.OpenFile(input, size)
+//	...
+//	r := parquet.Recompress(f, &parquet.Snappy)
+//	defer r.Close()
+//	_, err = io.Copy(output, r)
+//
+// The pages are transcoded one at a time while the copy is read, their values
+// are not decoded and the file is never fully held in memory. The encodings,
+// statistics, bloom filters, and page index of the file are preserved, and the
+// offsets recorded in the metadata are updated to the location of the pages in
+// the copy. Pages of files opened with page transforms are transformed again
+// after being recompressed.
+//
+// Closing the reader before reaching the end of the copy stops the transcoding
+// of pages.
+func Recompress(f *File, codec compress.Codec) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(recompress(w, f, codec))
+	}()
+	return r
+}
+
+func recompress(output io.Writer, f *File, codec compress.Codec) error {
+	buffer := bufio.NewWriterSize(output, DefaultWriteBufferSize)
+	r := &recompressor{
+		file:  f,
+		codec: codec,
+		input: bufio.NewReaderSize(nil, f.config.ReadBufferSize),
+	}
+	r.writer.Reset(buffer)
+	r.decoder.Reset(r.protocol.NewReader(r.input))
+	r.encoder.Reset(r.protocol.NewWriter(&r.writer))
+
+	if err := r.writeFile(); err != nil {
+		return err
+	}
+	return buffer.Flush()
+}
+
+type recompressor struct {
+	file   *File
+	codec  compress.Codec
+	writer offsetTrackingWriter
+	input  *bufio.Reader
+
+	protocol thrift.CompactProtocol
+	decoder  thrift.Decoder
+	encoder  thrift.Encoder
+
+	// Offset indexes of the column chunks of each row group, with the page
+	// locations of the copy, nil for column chunks without offset index.
+	offsetIndexes [][]*format.OffsetIndex
+
+	// Scratch buffers holding the content of the page being transcoded.
+	page       []byte
+	scratch    []byte
+	compressed []byte
+}
+
+func (r *recompressor) writeFile() error {
+	metadata := *r.file.Metadata()
+	metadata.RowGroups = make([]format.RowGroup, len(r.file.metadata.RowGroups))
+	copy(metadata.RowGroups, r.file.metadata.RowGroups)
+
+	if _, err := r.writer.WriteString("PAR1"); err != nil {
+		return err
+	}
+
+	for i := range metadata.RowGroups {
+		if err := r.writeRowGroup(&metadata.RowGroups[i], r.file.rowGroups[i]); err != nil {
+			return fmt.Errorf("recompressing row group %d: %w", i, err)
+		}
+	}
+
+	if err := r.writePageIndex(metadata.RowGroups); err != nil {
+		return fmt.Errorf("copying page index: %w", err)
+	}
+
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		return err
+	}
+	length := len(footer)
+	footer = append(footer, 0, 0, 0, 0)
+	footer = append(footer, "PAR1"...)
+	binary.LittleEndian.PutUint32(footer[length:], uint32(length))
+	_, err = r.writer.Write(footer)
+	return err
+}
+
+func (r *recompressor) writeRowGroup(rowGroup *format.RowGroup, fileRowGroup RowGroup) error {
+	chunks := fileRowGroup.ColumnChunks()
+	rowGroup.Columns = make([]format.ColumnChunk, len(chunks))
+
+	for j, c := range chunks {
+		chunk := c.(*fileColumnChunk)
+		if err := chunk.load(); err != nil {
+			return err
+		}
+		if err := chunk.checkAccess(); err != nil {
+			return err
+		}
+		rowGroup.Columns[j] = *chunk.chunk
+		rowGroup.Columns[j].FileOffset = 0
+	}
+
+	// Like the writer, bloom filters are written before the pages of the row
+	// group.
+	for j := range rowGroup.Columns {
+		if err := r.writeBloomFilter(&rowGroup.Columns[j].MetaData); err != nil {
+			return fmt.Errorf("copying bloom filter of column %q: %w", columnPath(rowGroup.Columns[j].MetaData.PathInSchema), err)
+		}
+	}
+
+	rowGroup.FileOffset = r.writer.offset
+	rowGroup.TotalByteSize = 0
+	rowGroup.TotalCompressedSize = 0
+	offsetIndexes := make([]*format.OffsetIndex, len(chunks))
+
+	for j := range rowGroup.Columns {
+		column := &rowGroup.Columns[j]
+		offsetIndex, err := r.writeColumnChunk(column, chunks[j].(*fileColumnChunk))
+		if err != nil {
+			return fmt.Errorf("recompressing column %q: %w", columnPath(column.MetaData.PathInSchema), err)
+		}
+		offsetIndexes[j] = offsetIndex
+		rowGroup.TotalByteSize += column.MetaData.TotalUncompressedSize
+		rowGroup.TotalCompressedSize += column.MetaData.TotalCompressedSize
+	}
+
+	r.offsetIndexes = append(r.offsetIndexes, offsetIndexes)
+	return nil
+}
+
+// writeBloomFilter copies the header and bitset of the bloom filter of the
+// column chunk, and updates its offset in the metadata.
+func (r *recompressor) writeBloomFilter(metadata *format.ColumnMetaData) error {
+	offset := metadata.BloomFilterOffset
+	if offset <= 0 {
+		return nil
+	}
+
+	length := int64(metadata.BloomFilterLength)
+	if length <= 0 {
+		// The length of the bloom filter was not recorded, it is determined
+		// by decoding its header like when the file is opened.
+		section := io.NewSectionReader(r.file, offset, r.file.size-offset)
+		r.input.Reset(section)
+		header := format.BloomFilterHeader{}
+		if err := r.decoder.Decode(&header); err != nil {
+			return fmt.Errorf("decoding bloom filter header: %w", err)
+		}
+		headerSize, _ := section.Seek(0, io.SeekCurrent)
+		length = headerSize - int64(r.input.Buffered()) + int64(header.NumBytes)
+	}
+
+	metadata.BloomFilterOffset = r.writer.offset
+	_, err := io.Copy(&r.writer, io.NewSectionReader(r.file, offset, length))
+	return err
+}
+
+// writeColumnChunk transcodes the pages of the column chunk, and updates the
+// offsets, sizes, and codec of the column chunk metadata. The method returns
+// the offset index of the column chunk updated with the locations of the pages
+// in the copy, or nil if the column chunk had no offset index.
+func (r *recompressor) writeColumnChunk(column *format.ColumnChunk, chunk *fileColumnChunk) (*format.OffsetIndex, error) {
+	metadata := &column.MetaData
+	baseOffset := metadata.DataPageOffset
+	if metadata.DictionaryPageOffset != 0 {
+		baseOffset = metadata.DictionaryPageOffset
+	}
+
+	transform := searchPageTransform(r.file.config.PageTransforms, chunk.column.Path())
+	source := LookupCompressionCodec(metadata.Codec)
+	if codec := chunk.column.Compression(); codec.CompressionCodec() == metadata.Codec {
+		// The codec of the column carries the zstd dictionary of the column
+		// when the file has one.
+		source = codec
+	}
+	passthrough := source.CompressionCodec() == r.codec.CompressionCodec()
+	r.input.Reset(io.NewSectionReader(r.file, baseOffset, metadata.TotalCompressedSize))
+
+	startOffset := r.writer.offset
+	dictionaryPageOffset := int64(0)
+	dataPageOffset := int64(-1)
+	uncompressedSize := int64(0)
+	pageLocations := []format.PageLocation(nil)
+
+	for pageIndex := 0; ; pageIndex++ {
+		header := new(format.PageHeader)
+		if err := r.decoder.Decode(header); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("decoding header of page %d: %w", pageIndex, err)
+		}
+
+		size := int(header.CompressedPageSize)
+		if cap(r.page) < size {
+			r.page = make([]byte, size)
+		}
+		r.page = r.page[:size]
+		if _, err := io.ReadFull(r.input, r.page); err != nil {
+			return nil, fmt.Errorf("reading page %d: %w", pageIndex, err)
+		}
+		if !passthrough {
+			if err := r.recompressPage(header, source, transform); err != nil {
+				return nil, fmt.Errorf("recompressing page %d: %w", pageIndex, err)
+			}
+		}
+
+		pageOffset := r.writer.offset
+		if err := r.encoder.Encode(header); err != nil {
+			return nil, err
+		}
+		headerSize := r.writer.offset - pageOffset
+		if _, err := r.writer.Write(r.page); err != nil {
+			return nil, err
+		}
+		uncompressedSize += headerSize + int64(header.UncompressedPageSize)
+
+		if header.Type == format.DictionaryPage {
+			dictionaryPageOffset = pageOffset
+			continue
+		}
+		if dataPageOffset < 0 {
+			dataPageOffset = pageOffset
+		}
+		pageLocations = append(pageLocations, format.PageLocation{
+			Offset:             pageOffset,
+			CompressedPageSize: int32(r.writer.offset - pageOffset),
+		})
+	}
+
+	// When the offset of the dictionary page was not recorded, the data page
+	// offset is the start of the column chunk like in the original file.
+	metadata.DataPageOffset = startOffset
+	if metadata.DictionaryPageOffset != 0 {
+		metadata.DictionaryPageOffset = startOffset
+		if dictionaryPageOffset != 0 {
+			metadata.DictionaryPageOffset = dictionaryPageOffset
+		}
+		if dataPageOffset >= 0 {
+			metadata.DataPageOffset = dataPageOffset
+		}
+	}
+	metadata.Codec = r.codec.CompressionCodec()
+	metadata.TotalCompressedSize = r.writer.offset - startOffset
+	metadata.TotalUncompressedSize = uncompressedSize
+
+	if column.OffsetIndexOffset <= 0 {
+		return nil, nil
+	}
+	offsetIndex := new(format.OffsetIndex)
+	if err := r.readIndex(column.OffsetIndexOffset, column.OffsetIndexLength, offsetIndex); err != nil {
+		return nil, fmt.Errorf("reading offset index: %w", err)
+	}
+	if len(offsetIndex.PageLocations) != len(pageLocations) {
+		return nil, fmt.Errorf("offset index has %d pages but the column chunk has %d data pages: %w", len(offsetIndex.PageLocations), len(pageLocations), ErrCorrupted)
+	}
+	for i := range pageLocations {
+		pageLocations[i].FirstRowIndex = offsetIndex.PageLocations[i].FirstRowIndex
+	}
+	offsetIndex.PageLocations = pageLocations
+	return offsetIndex, nil
+}
+
+// recompressPage decompresses the page held in r.page with the source codec and
+// compresses it with the codec of r, updating the page header accordingly.
+func (r *recompressor) recompressPage(header *format.PageHeader, source compress.Codec, transform PageTransform) (err error) {
+	if transform != nil {
+		if r.scratch, err = transform.Decode(r.scratch[:0], r.page); err != nil {
+			return fmt.Errorf("decoding transformed page: %w", err)
+		}
+		r.page, r.scratch = r.scratch, r.page
+	}
+
+	// The levels of data pages v2 are never compressed, and the values may
+	// also be left uncompressed.
+	levels, compressed := 0, true
+	if h := header.DataPageHeaderV2; header.Type == format.DataPageV2 && h != nil {
+		levels = int(h.RepetitionLevelsByteLength) + int(h.DefinitionLevelsByteLength)
+		compressed = h.IsCompressed == nil || *h.IsCompressed
+		if levels < 0 || levels > len(r.page) {
+			return fmt.Errorf("page of %d bytes cannot hold %d bytes of levels: %w", len(r.page), levels, ErrCorrupted)
+		}
+		if compressed {
+			isCompressed := isCompressed(r.codec)
+			h.IsCompressed = &isCompressed
+		}
+	}
+
+	if compressed {
+		if r.scratch, err = source.Decode(r.scratch[:0], r.page[levels:]); err != nil {
+			return fmt.Errorf("decompressing page with %s: %w", source, err)
+		}
+		if r.compressed, err = r.codec.Encode(r.compressed[:0], r.scratch); err != nil {
+			return fmt.Errorf("compressing page with %s: %w", r.codec, err)
+		}
+		r.page = append(r.page[:levels], r.compressed...)
+	}
+
+	if transform != nil {
+		if r.scratch, err = transform.Encode(r.scratch[:0], r.page); err != nil {
+			return fmt.Errorf("transforming page: %w", err)
+		}
+		r.page, r.scratch = r.scratch, r.page
+	}
+
+	header.CompressedPageSize = int32(len(r.page))
+	if header.CRC != 0 {
+		header.CRC = int32(crc32.ChecksumIEEE(r.page))
+	}
+	return nil
+}
+
+// writePageIndex copies the column indexes of the file, and writes the offset
+// indexes updated with the locations of pages in the copy, in the same layout
+// as the writer.
+func (r *recompressor) writePageIndex(rowGroups []format.RowGroup) error {
+	for i := range rowGroups {
+		for j := range rowGroups[i].Columns {
+			column := &rowGroups[i].Columns[j]
+			if column.ColumnIndexOffset <= 0 {
+				continue
+			}
+			offset := r.writer.offset
+			section := io.NewSectionReader(r.file, column.ColumnIndexOffset, int64(column.ColumnIndexLength))
+			if _, err := io.Copy(&r.writer, section); err != nil {
+				return err
+			}
+			column.ColumnIndexOffset = offset
+		}
+	}
+
+	for i := range rowGroups {
+		for j := range rowGroups[i].Columns {
+			column := &rowGroups[i].Columns[j]
+			offsetIndex := r.offsetIndexes[i][j]
+			if offsetIndex == nil {
+				continue
+			}
+			column.OffsetIndexOffset = r.writer.offset
+			if err := r.encoder.Encode(offsetIndex); err != nil {
+				return err
+			}
+			column.OffsetIndexLength = int32(r.writer.offset - column.OffsetIndexOffset)
+		}
+	}
+
+	return nil
+}
+
+func (r *recompressor) readIndex(offset int64, length int32, index interface{}) error {
+	data := make([]byte, length)
+	if _, err := r.file.ReadAt(data, offset); err != nil {
+		return err
+	}
+	return thrift.Unmarshal(&r.protocol, data, index)
+}
@@ -0,0 +1,173 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestRecompress(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Name  string   `parquet:"name"`
+		Role  string   `parquet:"role,dict"`
+		Score *float64 `parquet:"score,optional"`
+		Tags  []string `parquet:"tags,list"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Name: fmt.Sprintf("name-%d", i),
+			Role: fmt.Sprintf("role-%d", i%5),
+			Tags: []string{"a", "b", "c"}[:i%4],
+		}
+		if i%3 != 0 {
+			score := float64(i)
+			rows[i].Score = &score
+		}
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("data page v%d", version), func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			if err := parquet.Write(buffer, rows,
+				parquet.Compression(&parquet.Zstd),
+				parquet.DataPageVersion(version),
+				parquet.PageBufferSize(1024),
+				parquet.MaxRowsPerRowGroup(400),
+				parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			recompressed := recompressFile(t, f, &parquet.Snappy)
+
+			for _, rowGroup := range recompressed.Metadata().RowGroups {
+				for _, column := range rowGroup.Columns {
+					if codec := column.MetaData.Codec; codec != format.Snappy {
+						t.Errorf("column %q: want=%s got=%s", column.MetaData.PathInSchema, format.Snappy, codec)
+					}
+				}
+			}
+
+			values, err := parquet.Read[Row](recompressed, recompressed.Size())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(values, rows) {
+				t.Error("rows mismatch")
+			}
+
+			// The page index and bloom filters must locate the pages and
+			// filters of the recompressed file.
+			if len(recompressed.OffsetIndexes()) == 0 {
+				t.Fatal("recompressed file has no offset index")
+			}
+			for i, rowGroup := range recompressed.RowGroups() {
+				for j, chunk := range rowGroup.ColumnChunks() {
+					pages := chunk.Pages()
+					if err := pages.SeekToRow(rowGroup.NumRows() - 1); err != nil {
+						t.Fatal(err)
+					}
+					p, err := pages.ReadPage()
+					if err != nil {
+						t.Fatalf("row group %d, column %d: %v", i, j, err)
+					}
+					parquet.Release(p)
+					pages.Close()
+				}
+
+				name := rowGroup.ColumnChunks()[1].BloomFilter()
+				if name == nil {
+					t.Fatalf("row group %d: missing bloom filter", i)
+				}
+				v := parquet.ValueOf(rows[i*400].Name)
+				if ok, err := name.Check(v); err != nil {
+					t.Fatal(err)
+				} else if !ok {
+					t.Errorf("row group %d: bloom filter does not contain %v", i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestRecompressTestdata(t *testing.T) {
+	for _, path := range testdataFiles {
+		t.Run(path, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := readFileRows(f)
+			if err != nil {
+				t.Skip(err)
+			}
+
+			recompressed := recompressFile(t, f, &parquet.Gzip)
+			got, err := readFileRows(recompressed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(want) != len(got) {
+				t.Fatalf("number of rows mismatch: want=%d got=%d", len(want), len(got))
+			}
+			for i := range want {
+				if !want[i].Equal(got[i]) {
+					t.Fatalf("row %d mismatch:\nwant = %+v\ngot  = %+v", i, want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func recompressFile(t *testing.T, f *parquet.File, codec compress.Codec) *parquet.File {
+	t.Helper()
+	r := parquet.Recompress(f, codec)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recompressed, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return recompressed
+}
+
+func readFileRows(f *parquet.File) ([]parquet.Row, error) {
+	reader := parquet.NewReader(f)
+	defer reader.Close()
+	rows := make([]parquet.Row, 0, f.NumRows())
+	buffer := make([]parquet.Row, 10)
+	for {
+		n, err := reader.ReadRows(buffer)
+		for _, row := range buffer[:n] {
+			rows = append(rows, row.Clone())
+		}
+		if err != nil {
+			if err == io.EOF {
+				return rows, nil
+			}
+			return rows, err
+		}
+	}
+}
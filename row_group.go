@@ -77,6 +77,18 @@ type RowGroupWriter interface {
 	WriteRowGroup(RowGroup) (int64, error)
 }
 
+// RowGroupKeyValueMetadata is an interface implemented by row groups which
+// expose key/value metadata, like the row groups of files opened with OpenFile.
+//
+// The parquet format does not have key/value metadata on row groups, by
+// convention it is stored in the key/value metadata of the first column chunk
+// of the row group, see Writer.SetRowGroupKeyValueMetadata.
+type RowGroupKeyValueMetadata interface {
+	// Returns the value associated with the given key in the key/value metadata
+	// of the row group, and true if the key was found, false otherwise.
+	Lookup(key string) (value string, ok bool)
+}
+
 // SortingColumn represents a column by which a row group is sorted.
 type SortingColumn interface {
 	// Returns the path of the column in the row group schema, omitting the name
@@ -102,6 +114,11 @@ func Descending(path ...string) SortingColumn { return descending(path) }
 // the row group to place null values first in the column.
 func NullsFirst(sortingColumn SortingColumn) SortingColumn { return nullsFirst{sortingColumn} }
 
+// NullsLast wraps the SortingColumn passed as argument so that it instructs
+// the row group to place null values last in the column. This is the default
+// for sorting columns, the function exists to declare the ordering explicitly.
+func NullsLast(sortingColumn SortingColumn) SortingColumn { return nullsLast{sortingColumn} }
+
 type ascending []string
 
 func (asc ascending) String() string   { return fmt.Sprintf("ascending(%s)", columnPath(asc)) }
@@ -121,6 +138,11 @@ type nullsFirst struct{ SortingColumn }
 func (nf nullsFirst) String() string   { return fmt.Sprintf("nulls_first+%s", nf.SortingColumn) }
 func (nf nullsFirst) NullsFirst() bool { return true }
 
+type nullsLast struct{ SortingColumn }
+
+func (nl nullsLast) String() string   { return fmt.Sprintf("nulls_last+%s", nl.SortingColumn) }
+func (nl nullsLast) NullsFirst() bool { return false }
+
 func searchSortingColumn(sortingColumns []SortingColumn, path columnPath) int {
 	// There are usually a few sorting columns in a row group, so the linear
 	// scan is the fastest option and works whether the sorting column list
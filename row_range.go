@@ -0,0 +1,132 @@
+package parquet
+
+import (
+	"io"
+	"sort"
+)
+
+// rowRange is a range of rows [start:end) of a row group.
+type rowRange struct {
+	start, end int64
+}
+
+// intersectRowRanges returns the ranges of rows present in both a and b, which
+// must be sorted and not overlapping.
+func intersectRowRanges(a, b []rowRange) []rowRange {
+	ranges := make([]rowRange, 0, min(len(a), len(b)))
+	for len(a) > 0 && len(b) > 0 {
+		start, end := a[0].start, a[0].end
+		if b[0].start > start {
+			start = b[0].start
+		}
+		if b[0].end < end {
+			end = b[0].end
+		}
+		if start < end {
+			ranges = append(ranges, rowRange{start, end})
+		}
+		if a[0].end < b[0].end {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return ranges
+}
+
+// appendRowRange appends r to ranges, merging it with the last range when
+// they are contiguous.
+func appendRowRange(ranges []rowRange, r rowRange) []rowRange {
+	if n := len(ranges); n > 0 && ranges[n-1].end == r.start {
+		ranges[n-1].end = r.end
+		return ranges
+	}
+	return append(ranges, r)
+}
+
+// rangedRowGroup is a row group which only exposes the given ranges of rows of
+// the underlying row group from the pages of its column chunks, and therefore
+// from its Rows method. Pages of the column chunks which have no rows in the
+// ranges are skipped without being read, using the offset indexes of the
+// column chunks.
+//
+// The number of rows is the one of the underlying row group, and row indexes
+// passed to SeekToRow include the rows outside of the ranges.
+type rangedRowGroup struct {
+	RowGroup
+	columns  []ColumnChunk
+	readMode ReadMode
+}
+
+func newRangedRowGroup(rowGroup RowGroup, ranges []rowRange, readMode ReadMode) *rangedRowGroup {
+	chunks := rowGroup.ColumnChunks()
+	columns := make([]ColumnChunk, len(chunks))
+	for i, chunk := range chunks {
+		columns[i] = &rangedColumnChunk{ColumnChunk: chunk, ranges: ranges}
+	}
+	return &rangedRowGroup{RowGroup: rowGroup, columns: columns, readMode: readMode}
+}
+
+func (g *rangedRowGroup) ColumnChunks() []ColumnChunk { return g.columns }
+
+func (g *rangedRowGroup) Rows() Rows { return newRowGroupRows(g, g.readMode) }
+
+type rangedColumnChunk struct {
+	ColumnChunk
+	ranges []rowRange
+}
+
+func (c *rangedColumnChunk) Pages() Pages {
+	return &rangedPages{pages: c.ColumnChunk.Pages(), ranges: c.ranges}
+}
+
+type rangedPages struct {
+	pages  Pages
+	ranges []rowRange
+	// Index of the range that the next page is read from.
+	next int
+	// Index of the next row read from the underlying pages.
+	rowIndex int64
+}
+
+func (p *rangedPages) ReadPage() (Page, error) {
+	for p.next < len(p.ranges) {
+		r := p.ranges[p.next]
+		if p.rowIndex >= r.end {
+			p.next++
+			continue
+		}
+		if p.rowIndex < r.start {
+			if err := p.pages.SeekToRow(r.start); err != nil {
+				return nil, err
+			}
+			p.rowIndex = r.start
+		}
+
+		page, err := p.pages.ReadPage()
+		if err != nil {
+			return nil, err
+		}
+		start := p.rowIndex
+		p.rowIndex += page.NumRows()
+		if p.rowIndex <= r.end {
+			return page, nil
+		}
+		// The page extends past the end of the range, the position of the
+		// underlying pages is restored by seeking to the start of the next
+		// range on the next call.
+		head := page.Slice(0, r.end-start)
+		Release(page)
+		p.rowIndex = r.end
+		return head, nil
+	}
+	return nil, io.EOF
+}
+
+func (p *rangedPages) SeekToRow(rowIndex int64) error {
+	p.next = sort.Search(len(p.ranges), func(i int) bool { return p.ranges[i].end > rowIndex })
+	p.rowIndex = rowIndex
+	return p.pages.SeekToRow(rowIndex)
+}
+
+func (p *rangedPages) Close() error { return p.pages.Close() }
@@ -0,0 +1,246 @@
+package parquet
+
+import (
+	"io"
+)
+
+// RowStream reads rows of a row group incrementally: instead of materializing
+// all the values of a row like RowReader does, the values of each column of
+// the current row are read in chunks bounded by the size of the buffer passed
+// to ReadColumnValues. This bounds the memory needed to read rows which contain
+// very large repeated fields, for example lists of millions of elements.
+//
+// The columns of a row may be read in any order, and columns which are not
+// read are skipped when moving to the next row. A typical use looks like this:
+//
+//	stream := parquet.NewRowStream(rowGroup)
+//	defer stream.Close()
+//
+//	values := make([]parquet.Value, 1024)
+//	for stream.NextRow() == nil {
+//		for columnIndex := 0; columnIndex < numColumns; columnIndex++ {
+//			for {
+//				n, err := stream.ReadColumnValues(columnIndex, values)
+//				process(values[:n])
+//				if err != nil {
+//					break
+//				}
+//			}
+//		}
+//	}
+type RowStream struct {
+	rowGroup RowGroup
+	rowIndex int64
+	columns  []rowStreamColumn
+	closed   bool
+}
+
+type rowStreamColumn struct {
+	pages  Pages
+	page   Page
+	values ValueReader
+	buffer []Value
+	offset int
+	length int
+	// Set when the first value of the current row has not been read yet.
+	first bool
+	// Set when all the values of the current row have been read.
+	done bool
+	// Set when the column has no more pages.
+	eof bool
+}
+
+// NewRowStream constructs a RowStream reading rows from the given row group.
+func NewRowStream(rowGroup RowGroup) *RowStream {
+	columns := rowGroup.ColumnChunks()
+	s := &RowStream{
+		rowGroup: rowGroup,
+		rowIndex: -1,
+		columns:  make([]rowStreamColumn, len(columns)),
+	}
+	buffers := make([]Value, len(columns)*columnBufferSize)
+	for i, column := range columns {
+		j := (i + 0) * columnBufferSize
+		k := (i + 1) * columnBufferSize
+		s.columns[i] = rowStreamColumn{
+			pages:  column.Pages(),
+			buffer: buffers[j:k:k],
+			done:   true,
+		}
+	}
+	return s
+}
+
+// Schema returns the schema of rows read by s.
+func (s *RowStream) Schema() *Schema { return s.rowGroup.Schema() }
+
+// RowIndex returns the index of the current row in the row group, or -1 if
+// NextRow was not called yet.
+func (s *RowStream) RowIndex() int64 { return s.rowIndex }
+
+// NextRow moves the stream to the next row, skipping the values of the current
+// row which were not read. The method must be called before reading the first
+// row. It returns io.EOF when there are no more rows in the row group.
+//
+// Calling NextRow invalidates the values previously returned by
+// ReadColumnValues.
+func (s *RowStream) NextRow() error {
+	if s.closed {
+		return io.ErrClosedPipe
+	}
+	if s.rowIndex+1 >= s.rowGroup.NumRows() {
+		return io.EOF
+	}
+	for i := range s.columns {
+		c := &s.columns[i]
+		for !c.done {
+			if err := c.skipValues(); err != nil {
+				return err
+			}
+		}
+		c.first, c.done = true, false
+	}
+	s.rowIndex++
+	return nil
+}
+
+// ReadColumnValues reads values of the column at the given index in the
+// current row. The values are written to the buffer passed as argument, and
+// the method returns the number of values read. When all the values of the
+// column in the current row have been read, the method returns io.EOF.
+//
+// The values returned remain valid until the next call to ReadColumnValues for
+// the same column, or to NextRow. Programs which need to retain the values
+// longer must clone them.
+func (s *RowStream) ReadColumnValues(columnIndex int, values []Value) (int, error) {
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if columnIndex < 0 || columnIndex >= len(s.columns) {
+		return 0, errRowIndexOutOfBounds(int64(columnIndex), int64(len(s.columns)))
+	}
+	c := &s.columns[columnIndex]
+	if c.done {
+		return 0, io.EOF
+	}
+	n, err := c.readValues(values)
+	if err == nil && c.done && n == 0 {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Close closes the stream, releasing the pages that it was reading from.
+func (s *RowStream) Close() error {
+	var lastErr error
+	if !s.closed {
+		s.closed = true
+		for i := range s.columns {
+			c := &s.columns[i]
+			Release(c.page)
+			c.page, c.values = nil, nil
+			clearValues(c.buffer)
+			if err := c.pages.Close(); err != nil {
+				lastErr = err
+			}
+		}
+	}
+	return lastErr
+}
+
+// fill makes values of the column available in its buffer. When the current
+// page is exhausted, the next one is read, which invalidates the values that
+// were previously read from the column.
+func (c *rowStreamColumn) fill() error {
+	for c.offset == c.length {
+		if c.eof {
+			c.done = true
+			return nil
+		}
+
+		if c.values != nil {
+			n, err := c.values.ReadValues(c.buffer)
+			if n > 0 {
+				c.offset, c.length = 0, n
+				return nil
+			}
+			switch err {
+			case nil:
+				return io.ErrNoProgress
+			case io.EOF:
+			default:
+				return err
+			}
+		}
+
+		clearValues(c.buffer)
+		Release(c.page)
+		c.page, c.values = nil, nil
+		c.offset, c.length = 0, 0
+
+		page, err := c.pages.ReadPage()
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			c.eof, c.done = true, true
+			return nil
+		}
+		c.page, c.values = page, page.Values()
+	}
+	return nil
+}
+
+// span returns the number of values at the current offset of the buffer which
+// belong to the current row, and updates the state of the column when the end
+// of the row is found.
+func (c *rowStreamColumn) span(limit int) int {
+	i := c.offset
+	j := c.offset + limit
+	if j > c.length {
+		j = c.length
+	}
+	if c.first && i < j {
+		c.first = false
+		i++
+	}
+	for i < j && c.buffer[i].repetitionLevel != 0 {
+		i++
+	}
+	// A value with a zero repetition level is the first value of the next row.
+	if i < c.length && c.buffer[i].repetitionLevel == 0 {
+		c.done = true
+	}
+	return i - c.offset
+}
+
+func (c *rowStreamColumn) readValues(values []Value) (int, error) {
+	n := 0
+	for n < len(values) && !c.done {
+		// Stop when the buffer needs to be refilled, since reading the next
+		// page would invalidate the values already read.
+		if c.offset == c.length && n > 0 {
+			break
+		}
+		if err := c.fill(); err != nil {
+			return n, err
+		}
+		if c.done {
+			break
+		}
+		k := c.span(len(values) - n)
+		n += copy(values[n:], c.buffer[c.offset:c.offset+k])
+		c.offset += k
+	}
+	return n, nil
+}
+
+func (c *rowStreamColumn) skipValues() error {
+	if err := c.fill(); err != nil {
+		return err
+	}
+	if !c.done {
+		c.offset += c.span(c.length - c.offset)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestRowStream(t *testing.T) {
+	type Item struct {
+		Key   string `parquet:"key"`
+		Value int64  `parquet:"value"`
+	}
+	type Row struct {
+		ID    int64   `parquet:"id"`
+		Items []Item  `parquet:"items"`
+		Tags  []int32 `parquet:"tags"`
+		Name  *string `parquet:"name,optional"`
+	}
+
+	name := "hello"
+	rows := make([]Row, 20)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		if i%4 == 0 {
+			rows[i].Name = &name
+		}
+		// Some rows have large lists spanning multiple pages, others have
+		// empty lists.
+		numItems := (i % 3) * 1000
+		for j := 0; j < numItems; j++ {
+			rows[i].Items = append(rows[i].Items, Item{Key: "k", Value: int64(j)})
+		}
+		for j := 0; j < i%5; j++ {
+			rows[i].Tags = append(rows[i].Tags, int32(j))
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(512)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowGroup := f.RowGroups()[0]
+	numColumns := len(rowGroup.ColumnChunks())
+
+	want := make([]parquet.Row, len(rows))
+	reader := rowGroup.Rows()
+	defer reader.Close()
+	for n := 0; n < len(want); {
+		m, err := reader.ReadRows(want[n:])
+		for i := n; i < n+m; i++ {
+			want[i] = want[i].Clone()
+		}
+		n += m
+		if err != nil {
+			if err == io.EOF && n == len(want) {
+				break
+			}
+			t.Fatal(err)
+		}
+	}
+
+	for _, skip := range []int{-1, 1, 2} {
+		stream := parquet.NewRowStream(rowGroup)
+		values := make([]parquet.Value, 7)
+
+		for rowIndex := 0; ; rowIndex++ {
+			if err := stream.NextRow(); err != nil {
+				if err != io.EOF || rowIndex != len(rows) {
+					t.Fatalf("row %d: %v", rowIndex, err)
+				}
+				break
+			}
+			if got := stream.RowIndex(); got != int64(rowIndex) {
+				t.Fatalf("row index mismatch: want=%d got=%d", rowIndex, got)
+			}
+
+			var row parquet.Row
+			for columnIndex := 0; columnIndex < numColumns; columnIndex++ {
+				if columnIndex == skip {
+					// Read a single chunk of the column, the rest of the
+					// values must be skipped when moving to the next row.
+					stream.ReadColumnValues(columnIndex, values)
+					continue
+				}
+				for {
+					n, err := stream.ReadColumnValues(columnIndex, values)
+					for _, v := range values[:n] {
+						row = append(row, v.Clone())
+					}
+					if err != nil {
+						if err != io.EOF {
+							t.Fatal(err)
+						}
+						break
+					}
+				}
+			}
+
+			var expected parquet.Row
+			want[rowIndex].Range(func(columnIndex int, columnValues []parquet.Value) bool {
+				if columnIndex != skip {
+					expected = append(expected, columnValues...)
+				}
+				return true
+			})
+			if !row.Equal(expected) {
+				t.Fatalf("row %d mismatch (skip=%d): want %d values, got %d", rowIndex, skip, len(expected), len(row))
+			}
+		}
+
+		if err := stream.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -59,6 +59,7 @@ type Schema struct {
 //	timestamp | for int64 types use the TIMESTAMP logical type with, by default, millisecond precision
 //	split     | for float32/float64, use the BYTE_STREAM_SPLIT encoding
 //	id(n)     | where n is int denoting a column field id. Example id(2) for a column with field id of 2
+//	default(v)| where v is the value of the column when reading files where it is missing
 //
 // # The date logical type is an int32 value of the number of days since the unix epoch
 //
@@ -69,6 +70,17 @@ type Schema struct {
 //	  TimestrampMicros int64 `parquet:"timestamp_micros,timestamp(microsecond)"
 //	}
 //
+// The default tag declares the value of fields when reading files which do not
+// have the column, instead of the zero value, which helps read files written
+// before the field was added to the schema. The value is parsed according to
+// the Go type of the field; it cannot contain commas, and time.Time values are
+// formatted as RFC 3339 strings:
+//
+//	type Config struct {
+//		Retries *int32 `parquet:"retries,optional,default(3)"`
+//		Region  string `parquet:"region,default(us-east-1)"`
+//	}
+//
 // The decimal tag must be followed by two integer parameters, the first integer
 // representing the scale and the second the precision; for example:
 //
@@ -370,6 +382,7 @@ func structNodeOf(t reflect.Type) *structNode {
 			fields[i].Tag.Get("parquet-key"),
 			fields[i].Tag.Get("parquet-value"),
 		})
+		field.defaultValue = makeDefaultValue(fields[i], field.Node)
 		s.fields[i] = field
 	}
 
@@ -467,10 +480,24 @@ type structField struct {
 	Node
 	name  string
 	index []int
+	// The value declared with the "default" struct tag, or a null value if
+	// the field did not declare a default.
+	defaultValue Value
 }
 
 func (f *structField) Name() string { return f.name }
 
+func (f *structField) defaultValueOf() Value { return f.defaultValue }
+
+// defaultValueOf returns the default value declared on node, which is a null
+// value if the node has no default.
+func defaultValueOf(node Node) Value {
+	if f, ok := node.(interface{ defaultValueOf() Value }); ok {
+		return f.defaultValueOf()
+	}
+	return Value{}
+}
+
 func (f *structField) Value(base reflect.Value) reflect.Value {
 	switch base.Kind() {
 	case reflect.Map:
@@ -669,6 +696,85 @@ func parseDecimalArgs(args string) (scale, precision int, err error) {
 	return int(s), int(p), nil
 }
 
+// makeDefaultValue returns the value declared by the "default" option of the
+// struct field tag, or a null value if the field has no default.
+func makeDefaultValue(sf reflect.StructField, node Node) (value Value) {
+	forEachStructTagOption(sf, func(t reflect.Type, option, args string) {
+		if option != "default" {
+			return
+		}
+		if !value.IsNull() {
+			throwInvalidNode(t, "struct field has multiple declaration of the default tag", sf.Name, sf.Tag.Get("parquet"))
+		}
+		if !node.Leaf() {
+			throwInvalidTag(t, sf.Name, option)
+		}
+		v, err := parseDefaultArgs(t, args)
+		if err != nil {
+			throwInvalidTag(t, sf.Name, option+args)
+		}
+		nodeType := node.Type()
+		value = makeValue(nodeType.Kind(), nodeType.LogicalType(), v)
+	})
+	return value
+}
+
+func parseDefaultArgs(t reflect.Type, args string) (reflect.Value, error) {
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return reflect.Value{}, fmt.Errorf("malformed default args: %s", args)
+	}
+	args = strings.TrimPrefix(args, "(")
+	args = strings.TrimSuffix(args, ")")
+
+	v := reflect.New(t).Elem()
+
+	if t == reflect.TypeOf(time.Time{}) {
+		d, err := time.Parse(time.RFC3339Nano, args)
+		if err != nil {
+			return v, err
+		}
+		v.Set(reflect.ValueOf(d))
+		return v, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(args)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(args, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(args, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(args, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		v.SetString(args)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return v, fmt.Errorf("default values are not supported on fields of type %s", t)
+		}
+		v.SetBytes([]byte(args))
+	default:
+		return v, fmt.Errorf("default values are not supported on fields of type %s", t)
+	}
+	return v, nil
+}
+
 func parseIDArgs(args string) (int, error) {
 	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
 		return 0, fmt.Errorf("malformed id args: %s", args)
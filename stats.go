@@ -0,0 +1,206 @@
+package parquet
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// ReadStats carries the time spent in each stage of reading pages from a
+// parquet file.
+//
+// The durations are cumulative over all the pages read from the file, and may
+// add up to more than the wall clock time when pages are read concurrently.
+type ReadStats struct {
+	// Number of pages read, including dictionary pages.
+	Pages int64
+	// Time spent reading data from the underlying io.ReaderAt.
+	Seek time.Duration
+	// Time spent decoding page headers.
+	HeaderParse time.Duration
+	// Time spent decompressing pages.
+	Decompress time.Duration
+	// Time spent decoding repetition and definition levels.
+	LevelDecode time.Duration
+	// Time spent decoding values of data and dictionary pages.
+	ValueDecode time.Duration
+}
+
+// DictionaryStats carries statistics of the dictionary encoding of the pages
+// read from a column, see File.DictionaryStats. The statistics show whether
+// dictionary encoding pays off for the column: dictionaries with entries that
+// are referenced only once or not at all, or column chunks where the writer
+// fell back to another encoding, are signs that the column should not be
+// dictionary encoded.
+//
+// The counts are cumulative over all the column chunks read from the column.
+type DictionaryStats struct {
+	// Number of dictionary pages read, and total number of entries of the
+	// dictionaries.
+	DictionaryPages   int64
+	DictionaryEntries int64
+	// Number of data pages encoded with indexes into a dictionary, and number
+	// of non-null indexes that they held.
+	IndexedPages  int64
+	IndexedValues int64
+	// Number of distinct dictionary entries referenced by the indexes of the
+	// data pages, summed over the column chunks.
+	DistinctIndexes int64
+	// Number of data pages which were not dictionary encoded in column chunks
+	// with a dictionary, and number of non-null values that they held. Those
+	// pages are written after the writer fell back to another encoding, most
+	// often because the dictionary had grown too large.
+	FallbackPages  int64
+	FallbackValues int64
+}
+
+// HitRate returns the fraction of the non-null values read from column chunks
+// with a dictionary that were encoded as indexes into the dictionary, or zero
+// if no values were read from such column chunks.
+func (s DictionaryStats) HitRate() float64 {
+	if n := s.IndexedValues + s.FallbackValues; n != 0 {
+		return float64(s.IndexedValues) / float64(n)
+	}
+	return 0
+}
+
+// Reuse returns the average number of indexes referencing each dictionary
+// entry, or zero if no dictionaries were read. Dictionary encoding is usually
+// not worth its cost when entries are referenced less than a few times.
+func (s DictionaryStats) Reuse() float64 {
+	if s.DictionaryEntries != 0 {
+		return float64(s.IndexedValues) / float64(s.DictionaryEntries)
+	}
+	return 0
+}
+
+// Stats is an interface implemented by types which collect statistics on the
+// performance of reading parquet files, such as *File when opened with the
+// CollectReadStats option.
+type Stats interface {
+	// Returns a snapshot of the statistics collected so far.
+	Stats() ReadStats
+}
+
+type readStage int
+
+const (
+	readStageSeek readStage = iota
+	readStageHeaderParse
+	readStageDecompress
+	readStageLevelDecode
+	readStageValueDecode
+	numReadStages
+)
+
+// readStats collects the statistics of a file, the methods may be called on a
+// nil pointer when collection is disabled.
+//
+// The fields are updated with 64 bits atomic operations, which must be
+// aligned on 64 bits boundaries on 32 bits platforms; keep 64 bits fields at
+// the beginning of the struct and always allocate it separately.
+type readStats struct {
+	pages        int64
+	durations    [numReadStages]int64
+	dictionaries []dictionaryStats
+}
+
+// dictionaryStats holds the counters of DictionaryStats, in the same order.
+type dictionaryStats [7]int64
+
+const (
+	dictionaryPages = iota
+	dictionaryEntries
+	indexedPages
+	indexedValues
+	distinctIndexes
+	fallbackPages
+	fallbackValues
+)
+
+func (s *readStats) snapshot() ReadStats {
+	if s == nil {
+		return ReadStats{}
+	}
+	duration := func(stage readStage) time.Duration {
+		return time.Duration(atomic.LoadInt64(&s.durations[stage]))
+	}
+	return ReadStats{
+		Pages:       atomic.LoadInt64(&s.pages),
+		Seek:        duration(readStageSeek),
+		HeaderParse: duration(readStageHeaderParse),
+		Decompress:  duration(readStageDecompress),
+		LevelDecode: duration(readStageLevelDecode),
+		ValueDecode: duration(readStageValueDecode),
+	}
+}
+
+func (s *readStats) dictionarySnapshot() []DictionaryStats {
+	if s == nil {
+		return nil
+	}
+	stats := make([]DictionaryStats, len(s.dictionaries))
+	for i := range stats {
+		d := &s.dictionaries[i]
+		stats[i] = DictionaryStats{
+			DictionaryPages:   atomic.LoadInt64(&d[dictionaryPages]),
+			DictionaryEntries: atomic.LoadInt64(&d[dictionaryEntries]),
+			IndexedPages:      atomic.LoadInt64(&d[indexedPages]),
+			IndexedValues:     atomic.LoadInt64(&d[indexedValues]),
+			DistinctIndexes:   atomic.LoadInt64(&d[distinctIndexes]),
+			FallbackPages:     atomic.LoadInt64(&d[fallbackPages]),
+			FallbackValues:    atomic.LoadInt64(&d[fallbackValues]),
+		}
+	}
+	return stats
+}
+
+func (s *readStats) addPage() {
+	if s != nil {
+		atomic.AddInt64(&s.pages, 1)
+	}
+}
+
+// addDictionary records the counter of the dictionary statistics of a column.
+func (s *readStats) addDictionary(columnIndex, counter int, n int64) {
+	if s != nil && columnIndex < len(s.dictionaries) {
+		atomic.AddInt64(&s.dictionaries[columnIndex][counter], n)
+	}
+}
+
+func (s *readStats) add(stage readStage, d time.Duration) {
+	atomic.AddInt64(&s.durations[stage], int64(d))
+}
+
+// now returns the current time if s is not nil, which avoids the cost of
+// reading the clock when statistics are not collected.
+func (s *readStats) now() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// record adds the time elapsed since start to the given stage.
+func (s *readStats) record(stage readStage, start time.Time) {
+	if s != nil {
+		s.add(stage, time.Since(start))
+	}
+}
+
+// timedReader wraps the readers of column chunks to measure the time spent in
+// I/O operations.
+type timedReader struct {
+	reader  io.Reader
+	stats   *readStats
+	elapsed time.Duration
+}
+
+func (r *timedReader) Read(b []byte) (int, error) {
+	start := time.Now()
+	n, err := r.reader.Read(b)
+	elapsed := time.Since(start)
+	r.elapsed += elapsed
+	r.stats.add(readStageSeek, elapsed)
+	return n, err
+}
@@ -0,0 +1,55 @@
+package parquet
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDictionaryStatsFallback(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name,dict"`
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := Write(buffer, []Row{{"a"}, {"b"}, {"a"}, {"c"}}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), CollectReadStats(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages := f.RowGroups()[0].ColumnChunks()[0].Pages().(*filePages)
+	defer pages.Close()
+	p, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	Release(p)
+
+	// Writers which fall back to another encoding when dictionaries grow too
+	// large write plain pages after the dictionary encoded pages of column
+	// chunks, which this writer never does.
+	column := ByteArrayType.NewColumnBuffer(0, 2)
+	if _, err := column.WriteValues([]Value{ByteArrayValue([]byte("d")), ByteArrayValue([]byte("e"))}); err != nil {
+		t.Fatal(err)
+	}
+	pages.recordDictionaryStats(column.Page())
+
+	stats := f.DictionaryStats()[0]
+	want := DictionaryStats{
+		DictionaryPages:   1,
+		DictionaryEntries: 3,
+		IndexedPages:      1,
+		IndexedValues:     4,
+		DistinctIndexes:   3,
+		FallbackPages:     1,
+		FallbackValues:    2,
+	}
+	if stats != want {
+		t.Errorf("dictionary statistics mismatch:\nwant: %+v\ngot:  %+v", want, stats)
+	}
+	if hitRate := stats.HitRate(); hitRate != 4.0/6.0 {
+		t.Errorf("hit rate mismatch: want=%g got=%g", 4.0/6.0, hitRate)
+	}
+}
@@ -0,0 +1,148 @@
+package parquet
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// checkTimestampsFunc is a function type used to validate that the time.Time
+// values held in Go values can be written to the TIMESTAMP columns of a schema
+// without losing precision.
+type checkTimestampsFunc func(reflect.Value) error
+
+// checkTimestampsFuncOf generates a checkTimestampsFunc for the Go type t and
+// the column at the given path of the schema.
+//
+// The function returns nil if t contains no time.Time values that could be
+// truncated when written to their columns, which allows the writers to skip
+// the check entirely.
+func checkTimestampsFuncOf(t reflect.Type, schema *Schema, path columnPath) checkTimestampsFunc {
+	if t == reflect.TypeOf(time.Time{}) {
+		return checkTimestampsFuncOfTime(schema, path)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return checkTimestampsFuncOfPointer(t, schema, path)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return checkTimestampsFuncOfSlice(t, schema, path)
+		}
+	case reflect.Struct:
+		return checkTimestampsFuncOfStruct(t, schema, path)
+	case reflect.Map:
+		return checkTimestampsFuncOfMap(t, schema, path)
+	}
+
+	return nil
+}
+
+func checkTimestampsFuncOfTime(schema *Schema, path columnPath) checkTimestampsFunc {
+	col, ok := schema.Lookup(path...)
+	if !ok {
+		return nil
+	}
+	lt := col.Node.Type().LogicalType()
+	if lt == nil || lt.Timestamp == nil || lt.Timestamp.Unit.Nanos != nil {
+		return nil
+	}
+
+	unit := lt.Timestamp.Unit
+	precision := timeUnitDuration(unit)
+	columnName := path.String()
+
+	return func(v reflect.Value) error {
+		t := v.Interface().(time.Time)
+		if t.Nanosecond()%int(precision) != 0 {
+			return fmt.Errorf("%w: %s written to column %s of unit %s", ErrTimestampTruncated, t.Format(time.RFC3339Nano), columnName, &unit)
+		}
+		return nil
+	}
+}
+
+func checkTimestampsFuncOfPointer(t reflect.Type, schema *Schema, path columnPath) checkTimestampsFunc {
+	checkElem := checkTimestampsFuncOf(t.Elem(), schema, path)
+	if checkElem == nil {
+		return nil
+	}
+	return func(v reflect.Value) error {
+		if v.IsNil() {
+			return nil
+		}
+		return checkElem(v.Elem())
+	}
+}
+
+func checkTimestampsFuncOfSlice(t reflect.Type, schema *Schema, path columnPath) checkTimestampsFunc {
+	checkElem := checkTimestampsFuncOf(t.Elem(), schema, path)
+	if checkElem == nil {
+		return nil
+	}
+	return func(v reflect.Value) error {
+		for i, n := 0, v.Len(); i < n; i++ {
+			if err := checkElem(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func checkTimestampsFuncOfStruct(t reflect.Type, schema *Schema, path columnPath) checkTimestampsFunc {
+	type field struct {
+		index []int
+		check checkTimestampsFunc
+	}
+
+	var fields []field
+
+	for _, f := range structFieldsOf(t) {
+		columnPath := path.append(f.Name)
+		forEachStructTagOption(f, func(_ reflect.Type, option, _ string) {
+			if option == "list" {
+				columnPath = columnPath.append("list", "element")
+			}
+		})
+
+		if check := checkTimestampsFuncOf(f.Type, schema, columnPath); check != nil {
+			fields = append(fields, field{index: f.Index, check: check})
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return func(v reflect.Value) error {
+		for _, f := range fields {
+			if err := f.check(v.FieldByIndex(f.index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func checkTimestampsFuncOfMap(t reflect.Type, schema *Schema, path columnPath) checkTimestampsFunc {
+	checkKey := checkTimestampsFuncOf(t.Key(), schema, path.append("key_value", "key"))
+	checkValue := checkTimestampsFuncOf(t.Elem(), schema, path.append("key_value", "value"))
+	if checkKey == nil && checkValue == nil {
+		return nil
+	}
+	return func(v reflect.Value) error {
+		for it := v.MapRange(); it.Next(); {
+			if checkKey != nil {
+				if err := checkKey(it.Key()); err != nil {
+					return err
+				}
+			}
+			if checkValue != nil {
+				if err := checkValue(it.Value()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
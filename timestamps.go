@@ -0,0 +1,206 @@
+package parquet
+
+import (
+	"reflect"
+	"time"
+)
+
+// localizeTimestampsFunc is a function type used to move the time.Time values
+// held in Go values read from TIMESTAMP columns with local semantics
+// (isAdjustedToUTC is false) to the location configured on readers.
+type localizeTimestampsFunc func(reflect.Value)
+
+// localizeTimestampsFuncOf generates a localizeTimestampsFunc for the Go type t
+// read with the read schema from a file with the given schema.
+//
+// Values of columns with local semantics are read as the UTC representation of
+// their wall clock, the function reinterprets the wall clock in loc, yielding
+// the instant that the timestamp represents in this location.
+//
+// The columns of the file are looked up with the paths of the columns of the
+// read schema that the fields of t are matched with, which may differ from the
+// names of the fields when the schema was renamed after the file columns, see
+// MatchColumnNames and ColumnAliases.
+//
+// The function returns nil if t contains no time.Time values read from columns
+// with local semantics, which allows the readers to skip the conversion
+// entirely.
+func localizeTimestampsFuncOf(t reflect.Type, read, file *Schema, loc *time.Location) localizeTimestampsFunc {
+	paths := make(map[string]columnPath)
+	matchedColumnPaths(read, nil, nil, paths)
+	return localizeTimestampsFuncOfPath(t, file, nil, paths, loc)
+}
+
+// matchedColumnPaths records in paths the paths of the columns of node, keyed
+// by the paths that the columns had before being renamed to match the columns
+// of a file.
+func matchedColumnPaths(node Node, path, matched columnPath, paths map[string]columnPath) {
+	for _, field := range node.Fields() {
+		name := field.Name()
+		if renamed, ok := field.(*renamedField); ok {
+			name = renamed.Field.Name()
+		}
+		fieldPath, matchedPath := path.append(name), matched.append(field.Name())
+		paths[fieldPath.String()] = matchedPath
+		if !field.Leaf() {
+			matchedColumnPaths(field, fieldPath, matchedPath, paths)
+		}
+	}
+}
+
+func localizeTimestampsFuncOfPath(t reflect.Type, file *Schema, path columnPath, paths map[string]columnPath, loc *time.Location) localizeTimestampsFunc {
+	if t == reflect.TypeOf(time.Time{}) {
+		return localizeTimestampsFuncOfTime(file, path, paths, loc)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return localizeTimestampsFuncOfPointer(t, file, path, paths, loc)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return localizeTimestampsFuncOfSlice(t, file, path, paths, loc)
+		}
+	case reflect.Struct:
+		return localizeTimestampsFuncOfStruct(t, file, path, paths, loc)
+	case reflect.Map:
+		return localizeTimestampsFuncOfMap(t, file, path, paths, loc)
+	}
+
+	return nil
+}
+
+func localizeTimestampsFuncOfTime(file *Schema, path columnPath, paths map[string]columnPath, loc *time.Location) localizeTimestampsFunc {
+	if matched, ok := paths[path.String()]; ok {
+		path = matched
+	}
+	col, ok := file.Lookup(path...)
+	if !ok {
+		return nil
+	}
+	lt := col.Node.Type().LogicalType()
+	if lt == nil || lt.Timestamp == nil || lt.Timestamp.IsAdjustedToUTC {
+		return nil
+	}
+
+	return func(v reflect.Value) {
+		t := v.Interface().(time.Time).UTC()
+		if !t.IsZero() {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+			v.Set(reflect.ValueOf(t))
+		}
+	}
+}
+
+func localizeTimestampsFuncOfPointer(t reflect.Type, file *Schema, path columnPath, paths map[string]columnPath, loc *time.Location) localizeTimestampsFunc {
+	localizeElem := localizeTimestampsFuncOfPath(t.Elem(), file, path, paths, loc)
+	if localizeElem == nil {
+		return nil
+	}
+	return func(v reflect.Value) {
+		if !v.IsNil() {
+			localizeElem(v.Elem())
+		}
+	}
+}
+
+func localizeTimestampsFuncOfSlice(t reflect.Type, file *Schema, path columnPath, paths map[string]columnPath, loc *time.Location) localizeTimestampsFunc {
+	localizeElem := localizeTimestampsFuncOfPath(t.Elem(), file, path, paths, loc)
+	if localizeElem == nil {
+		return nil
+	}
+	return func(v reflect.Value) {
+		for i, n := 0, v.Len(); i < n; i++ {
+			localizeElem(v.Index(i))
+		}
+	}
+}
+
+func localizeTimestampsFuncOfStruct(t reflect.Type, file *Schema, path columnPath, paths map[string]columnPath, loc *time.Location) localizeTimestampsFunc {
+	type field struct {
+		index    []int
+		localize localizeTimestampsFunc
+	}
+
+	var fields []field
+
+	for _, f := range structFieldsOf(t) {
+		columnPath := path.append(f.Name)
+		forEachStructTagOption(f, func(_ reflect.Type, option, _ string) {
+			if option == "list" {
+				columnPath = columnPath.append("list", "element")
+			}
+		})
+
+		if localize := localizeTimestampsFuncOfPath(f.Type, file, columnPath, paths, loc); localize != nil {
+			fields = append(fields, field{index: f.Index, localize: localize})
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return func(v reflect.Value) {
+		for _, f := range fields {
+			f.localize(v.FieldByIndex(f.index))
+		}
+	}
+}
+
+func localizeTimestampsFuncOfMap(t reflect.Type, file *Schema, path columnPath, paths map[string]columnPath, loc *time.Location) localizeTimestampsFunc {
+	localizeValue := localizeTimestampsFuncOfPath(t.Elem(), file, path.append("key_value", "value"), paths, loc)
+	if localizeValue == nil {
+		return nil
+	}
+	return func(v reflect.Value) {
+		// Map values are not addressable, they are localized in a copy which
+		// is written back to the map. Keys cannot be modified in place, time
+		// values used as keys keep the location that they were read with.
+		value := reflect.New(t.Elem()).Elem()
+		for it := v.MapRange(); it.Next(); {
+			value.Set(it.Value())
+			localizeValue(value)
+			v.SetMapIndex(it.Key(), value)
+		}
+	}
+}
+
+// int96TimestampsSchema returns a schema where the INT96 columns of schema are
+// replaced with TIMESTAMP(NANOS) columns, or schema itself if it has none.
+func int96TimestampsSchema(schema *Schema) *Schema {
+	fields, converted := int96TimestampFields(schema.Fields())
+	if !converted {
+		return schema
+	}
+	return NewSchema(schema.Name(), &renamedGroup{Node: schema, fields: fields})
+}
+
+func int96TimestampFields(fields []Field) ([]Field, bool) {
+	timestamps := make([]Field, len(fields))
+	converted := false
+
+	for i, field := range fields {
+		timestamps[i] = field
+		if field.Leaf() {
+			if field.Type().Kind() == Int96 {
+				timestamps[i] = &int96TimestampField{Field: field}
+				converted = true
+			}
+		} else if children, ok := int96TimestampFields(field.Fields()); ok {
+			timestamps[i] = &renamedField{Field: field, name: field.Name(), fields: children}
+			converted = true
+		}
+	}
+
+	return timestamps, converted
+}
+
+// int96TimestampField wraps an INT96 field to expose it as a TIMESTAMP(NANOS)
+// field; conversions from INT96 to TIMESTAMP values interpret the INT96 values
+// as the legacy timestamps holding a Julian day and the nanoseconds since
+// midnight.
+type int96TimestampField struct{ Field }
+
+func (f *int96TimestampField) String() string { return sprint(f.Name(), f) }
+
+func (f *int96TimestampField) Type() Type { return Timestamp(Nanosecond).Type() }
@@ -0,0 +1,194 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestReaderTimestampLocation(t *testing.T) {
+	type Event struct {
+		Local time.Time `parquet:"local,timestamp(millisecond)"`
+		UTC   time.Time `parquet:"utc,timestamp(millisecond)"`
+	}
+
+	schema := NewSchema("event", Group{
+		"local": Leaf(&timestampType{IsAdjustedToUTC: false, Unit: Millisecond.TimeUnit()}),
+		"utc":   Leaf(&timestampType{IsAdjustedToUTC: true, Unit: Millisecond.TimeUnit()}),
+	})
+
+	wallClock := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	instant := time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC)
+
+	buffer := new(bytes.Buffer)
+	writer := NewWriter(buffer, schema)
+	if _, err := writer.WriteRows([]Row{{
+		Int64Value(wallClock.UnixMilli()).Level(0, 0, 0),
+		Int64Value(instant.UnixMilli()).Level(0, 0, 1),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	loc := time.FixedZone("UTC-4", -4*3600)
+
+	for _, test := range []struct {
+		scenario string
+		options  []ReaderOption
+		local    time.Time
+	}{
+		{
+			scenario: "without location",
+			local:    wallClock,
+		},
+		{
+			scenario: "with location",
+			options:  []ReaderOption{TimestampLocation(loc)},
+			local:    time.Date(2023, 6, 1, 12, 0, 0, 0, loc),
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			check := func(t *testing.T, event Event) {
+				if !event.Local.Equal(test.local) || event.Local.Location().String() != test.local.Location().String() {
+					t.Errorf("local timestamp mismatch: want=%v got=%v", test.local, event.Local)
+				}
+				if !event.UTC.Equal(instant) || event.UTC.Location() != time.UTC {
+					t.Errorf("utc timestamp mismatch: want=%v got=%v", instant, event.UTC)
+				}
+			}
+
+			t.Run("GenericReader", func(t *testing.T) {
+				reader := NewGenericReader[Event](bytes.NewReader(buffer.Bytes()), test.options...)
+				defer reader.Close()
+
+				events := make([]Event, 2)
+				n, err := reader.Read(events)
+				if err != io.EOF {
+					t.Fatalf("error mismatch: want=%v got=%v", io.EOF, err)
+				}
+				if n != 1 {
+					t.Fatalf("number of rows mismatch: want=1 got=%d", n)
+				}
+				check(t, events[0])
+			})
+
+			t.Run("Reader", func(t *testing.T) {
+				reader := NewReader(bytes.NewReader(buffer.Bytes()), test.options...)
+				defer reader.Close()
+
+				var event Event
+				if err := reader.Read(&event); err != nil {
+					t.Fatal(err)
+				}
+				check(t, event)
+			})
+
+			t.Run("RowGroupReader", func(t *testing.T) {
+				f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				reader := NewRowGroupReader(f.RowGroups()[0], test.options...)
+				defer reader.Close()
+
+				var event Event
+				if err := reader.Read(&event); err != nil {
+					t.Fatal(err)
+				}
+				check(t, event)
+			})
+		})
+	}
+}
+
+func TestReaderTimestampLocationMatchedColumns(t *testing.T) {
+	schema := NewSchema("event", Group{
+		"event_time": Leaf(&timestampType{IsAdjustedToUTC: false, Unit: Millisecond.TimeUnit()}),
+		"local":      Leaf(&timestampType{IsAdjustedToUTC: false, Unit: Millisecond.TimeUnit()}),
+	})
+
+	wallClock := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	buffer := new(bytes.Buffer)
+	writer := NewWriter(buffer, schema)
+	if _, err := writer.WriteRows([]Row{{
+		Int64Value(wallClock.UnixMilli()).Level(0, 0, 0),
+		Int64Value(wallClock.UnixMilli()).Level(0, 0, 1),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fields are matched with the columns of the file by normalizing their
+	// names and by alias, their names do not exist in the file schema.
+	type Event struct {
+		EventTime time.Time `parquet:"EventTime,timestamp(millisecond)"`
+		Wall      time.Time `parquet:"wall,timestamp(millisecond)"`
+	}
+
+	loc := time.FixedZone("UTC-4", -4*3600)
+	want := time.Date(2023, 6, 1, 12, 0, 0, 0, loc)
+
+	options := []ReaderOption{
+		MatchColumnNames(NormalizeName),
+		ColumnAliases(map[string]string{"local": "wall"}),
+		TimestampLocation(loc),
+	}
+
+	read := func(t *testing.T, reader *Reader) Event {
+		defer reader.Close()
+		event := Event{}
+		if err := reader.Read(&event); err != nil {
+			t.Fatal(err)
+		}
+		return event
+	}
+
+	for _, test := range []struct {
+		scenario string
+		read     func(*testing.T) Event
+	}{
+		{
+			scenario: "generic reader",
+			read: func(t *testing.T) Event {
+				reader := NewGenericReader[Event](bytes.NewReader(buffer.Bytes()), options...)
+				defer reader.Close()
+				events := make([]Event, 1)
+				if n, err := reader.Read(events); n != 1 {
+					t.Fatalf("reading rows: n=%d err=%v", n, err)
+				}
+				return events[0]
+			},
+		},
+		{
+			scenario: "reader",
+			read: func(t *testing.T) Event {
+				return read(t, NewReader(bytes.NewReader(buffer.Bytes()), options...))
+			},
+		},
+		{
+			scenario: "row group reader",
+			read: func(t *testing.T) Event {
+				f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return read(t, NewRowGroupReader(f.RowGroups()[0], options...))
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			event := test.read(t)
+			for _, got := range []time.Time{event.EventTime, event.Wall} {
+				if !got.Equal(want) || got.Location().String() != loc.String() {
+					t.Errorf("local timestamp mismatch: want=%v got=%v", want, got)
+				}
+			}
+		})
+	}
+}
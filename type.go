@@ -2027,6 +2027,9 @@ func (t *timestampType) ConvertValue(val Value, typ Type) (Value, error) {
 	case *dateType:
 		return convertDateToTimestamp(val, t.Unit, t.tz())
 	}
+	if typ.Kind() == Int96 {
+		return convertInt96ToTimestamp(val, t.Unit)
+	}
 	return int64Type{}.ConvertValue(val, typ)
 }
 
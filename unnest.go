@@ -0,0 +1,244 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnnestRowReader returns a row reader which produces one row for each element
+// of the repeated field at the given dot-separated path of schema, in which the
+// values of the other columns are duplicated, like with a relational UNNEST.
+//
+// The field is either a repeated field or a group annotated with the LIST
+// logical type, and must not be nested in another repeated field. It is
+// replaced by an optional field holding the element in the schema of the
+// returned reader. Rows where the field is null or empty produce a single row
+// where the element is null.
+//
+// Unlike FlattenRowReader, the other columns keep their nesting, and rows are
+// split on the repetition levels of the unnested columns without having to
+// reconstruct the elements of repeated fields.
+func UnnestRowReader(rows RowReader, schema *Schema, path string) (RowReaderWithSchema, error) {
+	u := &unnestedRows{rows: rows}
+	names := strings.Split(path, ".")
+	root, err := u.init(schema, names, 0)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unnest column %q: %w", path, err)
+	}
+	u.schema = NewSchema(schema.Name(), root)
+
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		nested := len(leaf.path) > len(names) && columnPath(leaf.path[:len(names)]).equal(names)
+		if nested {
+			leaf.path = columnPath(names).append(leaf.path[len(names)+u.skip:]...)
+		}
+		column, _ := u.schema.Lookup(leaf.path...)
+		u.columns = append(u.columns, unnestColumn{
+			index:  column.ColumnIndex,
+			nested: nested,
+		})
+	})
+
+	u.sourceColumns = make([]int, len(u.columns))
+	for i, c := range u.columns {
+		u.sourceColumns[c.index] = i
+		if c.nested && u.firstColumn < 0 {
+			u.firstColumn = i
+		}
+	}
+	u.values = make([][]Value, len(u.columns))
+	u.offsets = make([]int, len(u.columns))
+	return u, nil
+}
+
+type unnestedRows struct {
+	rows   RowReader
+	schema *Schema
+	// Definition level of the parent of the unnested field, and the number of
+	// definition levels of the field which are replaced by the optional field.
+	definitionLevel byte
+	cut             byte
+	// Number of path elements removed from the columns of the unnested field.
+	skip          int
+	columns       []unnestColumn
+	sourceColumns []int
+	firstColumn   int
+	values        [][]Value
+	offsets       []int
+	source        []Row
+	buffer        []Row
+	pending       []Row
+	err           error
+}
+
+type unnestColumn struct {
+	// Index of the column in the schema of the unnested rows.
+	index  int
+	nested bool
+}
+
+func (u *unnestedRows) init(node Node, names []string, definitionLevel byte) (Node, error) {
+	if len(names) == 0 {
+		u.definitionLevel = definitionLevel
+		u.firstColumn = -1
+		switch {
+		case node.Repeated():
+			u.cut = 1
+			return Optional(node), nil
+		case isList(node):
+			fields := node.Fields()
+			if len(fields) != 1 || !fields[0].Repeated() {
+				return nil, fmt.Errorf("LIST group does not have a single repeated field")
+			}
+			u.cut = 1
+			if node.Optional() {
+				u.cut++
+			}
+			list := fields[0]
+			if list.Leaf() || len(list.Fields()) != 1 {
+				// Legacy two-level list where the repeated field is the element.
+				u.skip = 1
+				return Optional(list), nil
+			}
+			element := list.Fields()[0]
+			if element.Optional() {
+				u.cut++
+			}
+			u.skip = 2
+			return Optional(element), nil
+		default:
+			return nil, fmt.Errorf("not a repeated field nor a list")
+		}
+	}
+
+	if node.Leaf() {
+		return nil, fmt.Errorf("%q is not a group", names[0])
+	}
+	if node.Repeated() {
+		return nil, fmt.Errorf("nested in a repeated field")
+	}
+	if node.Optional() {
+		definitionLevel++
+	}
+
+	found := false
+	group := make(Group)
+	for _, field := range node.Fields() {
+		if field.Name() != names[0] {
+			group[field.Name()] = field
+			continue
+		}
+		unnested, err := u.init(field, names[1:], definitionLevel)
+		if err != nil {
+			return nil, err
+		}
+		group[field.Name()] = unnested
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("%q is not a field of the schema", names[0])
+	}
+	if node.Optional() {
+		return Optional(group), nil
+	}
+	return group, nil
+}
+
+func (u *unnestedRows) ReadRows(rows []Row) (int, error) {
+	for len(u.pending) == 0 {
+		if u.err != nil {
+			return 0, u.err
+		}
+		// Rows are only read from the underlying reader when all the unnested
+		// rows were returned, since their values may reference buffers that
+		// get reused on the next read.
+		size := max(len(rows), 1)
+		if len(u.source) < size {
+			u.source = make([]Row, size)
+		}
+		n, err := u.rows.ReadRows(u.source[:size])
+		u.err = err
+		u.buffer = u.buffer[:0]
+		for _, row := range u.source[:n] {
+			u.unnest(row)
+		}
+		u.pending = u.buffer
+	}
+
+	n := min(len(rows), len(u.pending))
+	for i := range rows[:n] {
+		rows[i] = append(rows[i][:0], u.pending[i]...)
+	}
+	u.pending = u.pending[n:]
+	return n, nil
+}
+
+func (u *unnestedRows) Schema() *Schema { return u.schema }
+
+func (u *unnestedRows) unnest(row Row) {
+	for i := range u.values {
+		u.values[i] = nil
+		u.offsets[i] = 0
+	}
+	row.Range(func(columnIndex int, columnValues []Value) bool {
+		if columnIndex < len(u.values) {
+			u.values[columnIndex] = columnValues
+		}
+		return true
+	})
+
+	// Each element of the unnested field starts with a value of repetition
+	// level 0 or 1 in all its columns.
+	numElements := 0
+	for _, v := range u.values[u.firstColumn] {
+		if v.repetitionLevel <= 1 {
+			numElements++
+		}
+	}
+
+	for k := 0; k < numElements; k++ {
+		n := len(u.buffer)
+		if n < cap(u.buffer) {
+			u.buffer = u.buffer[:n+1]
+		} else {
+			u.buffer = append(u.buffer, nil)
+		}
+		out := u.buffer[n][:0]
+
+		for columnIndex, sourceIndex := range u.sourceColumns {
+			values := u.values[sourceIndex]
+			if !u.columns[sourceIndex].nested {
+				for _, v := range values {
+					out = append(out, v.Level(int(v.repetitionLevel), int(v.definitionLevel), columnIndex))
+				}
+				continue
+			}
+
+			i := u.offsets[sourceIndex]
+			j := i + 1
+			for j < len(values) && values[j].repetitionLevel > 1 {
+				j++
+			}
+			u.offsets[sourceIndex] = j
+
+			for _, v := range values[i:j] {
+				repetitionLevel := 0
+				if v.repetitionLevel > 1 {
+					repetitionLevel = int(v.repetitionLevel) - 1
+				}
+				definitionLevel := v.definitionLevel
+				switch {
+				case definitionLevel < u.definitionLevel:
+				case definitionLevel < u.definitionLevel+u.cut:
+					// The unnested field or the element is null or empty.
+					definitionLevel = u.definitionLevel
+				default:
+					definitionLevel = definitionLevel - u.cut + 1
+				}
+				out = append(out, v.Level(repetitionLevel, int(definitionLevel), columnIndex))
+			}
+		}
+
+		u.buffer[n] = out
+	}
+}
@@ -0,0 +1,116 @@
+package parquet_test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestUnnestRowReader(t *testing.T) {
+	type Item struct {
+		SKU      string `parquet:"sku"`
+		Quantity int32  `parquet:"quantity"`
+	}
+	type User struct {
+		Name   string   `parquet:"name"`
+		Emails []string `parquet:"emails,list"`
+	}
+	type Order struct {
+		ID    int64  `parquet:"id"`
+		Items []Item `parquet:"items"`
+		User  *User  `parquet:"user,optional"`
+	}
+
+	orders := []Order{
+		{ID: 1, Items: []Item{{"a", 1}, {"b", 2}}, User: &User{Name: "u1", Emails: []string{"x@a", "y@a"}}},
+		{ID: 2, User: &User{Name: "u2"}},
+		{ID: 3, Items: []Item{{"c", 3}}},
+	}
+
+	buffer := parquet.NewGenericBuffer[Order]()
+	if _, err := buffer.Write(orders); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("repeated group", func(t *testing.T) {
+		type Unnested struct {
+			ID    int64 `parquet:"id"`
+			Items *Item `parquet:"items,optional"`
+			User  *User `parquet:"user,optional"`
+		}
+
+		values := unnestRows[Unnested](t, buffer, "items")
+		expected := []Unnested{
+			{ID: 1, Items: &Item{"a", 1}, User: orders[0].User},
+			{ID: 1, Items: &Item{"b", 2}, User: orders[0].User},
+			{ID: 2, User: &User{Name: "u2", Emails: []string{}}},
+			{ID: 3, Items: &Item{"c", 3}},
+		}
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", expected, values)
+		}
+	})
+
+	t.Run("nested list", func(t *testing.T) {
+		type UnnestedUser struct {
+			Emails *string `parquet:"emails,optional"`
+			Name   string  `parquet:"name"`
+		}
+		type Unnested struct {
+			ID    int64         `parquet:"id"`
+			Items []Item        `parquet:"items"`
+			User  *UnnestedUser `parquet:"user,optional"`
+		}
+
+		str := func(s string) *string { return &s }
+		values := unnestRows[Unnested](t, buffer, "user.emails")
+		expected := []Unnested{
+			{ID: 1, Items: orders[0].Items, User: &UnnestedUser{Name: "u1", Emails: str("x@a")}},
+			{ID: 1, Items: orders[0].Items, User: &UnnestedUser{Name: "u1", Emails: str("y@a")}},
+			{ID: 2, Items: []Item{}, User: &UnnestedUser{Name: "u2"}},
+			{ID: 3, Items: orders[2].Items},
+		}
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", expected, values)
+		}
+	})
+
+	for _, path := range []string{"id", "user.name", "user.phone", "id.x"} {
+		if _, err := parquet.UnnestRowReader(buffer.Rows(), buffer.Schema(), path); err == nil {
+			t.Errorf("expected an error when unnesting column %q", path)
+		}
+	}
+}
+
+func unnestRows[T any](t *testing.T, rowGroup parquet.RowGroup, path string) []T {
+	t.Helper()
+	rows := rowGroup.Rows()
+	defer rows.Close()
+
+	unnested, err := parquet.UnnestRowReader(rows, rowGroup.Schema(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := parquet.SchemaOf(new(T))
+	values := []T{}
+	buf := make([]parquet.Row, 1)
+	for {
+		n, err := unnested.ReadRows(buf)
+		for _, row := range buf[:n] {
+			var value T
+			if err := schema.Reconstruct(&value, row); err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, value)
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			return values
+		}
+	}
+}
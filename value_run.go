@@ -0,0 +1,105 @@
+package parquet
+
+// ValueRun represents a sequence of consecutive values which are equal,
+// including their repetition and definition levels.
+//
+// Runs are useful to consume columns holding long sequences of repeated values
+// (for example, time series labels) without having to expand each value.
+type ValueRun struct {
+	Value Value
+	Count int64
+}
+
+// ValueRunReader is an interface implemented by types that support reading
+// batches of value runs.
+type ValueRunReader interface {
+	// Read value runs into the buffer passed as argument and return the number
+	// of runs read. When all values have been read, the error will be io.EOF.
+	//
+	// Two consecutive runs returned by the reader are never equal; a run
+	// spanning across multiple calls to ReadValueRuns is only returned after
+	// its last value was seen.
+	ReadValueRuns([]ValueRun) (int, error)
+}
+
+// ValueRunsOf returns a ValueRunReader which coalesces consecutive equal values
+// read from the reader passed as argument into runs.
+//
+// Values are compared with DeepEqual, which means that values with different
+// repetition or definition levels are always part of different runs.
+//
+// Values of the returned runs may reference the internal buffers of the reader
+// and are only valid until the next call to ReadValueRuns; programs must use
+// Value.Clone to retain them longer. For this reason, ReadValueRuns may return
+// fewer runs than the length of the buffer and a nil error, when the reader
+// needs to read more values from the underlying reader, and returns no runs and
+// a nil error when the underlying reader returned no values and a nil error.
+func ValueRunsOf(values ValueReader) ValueRunReader {
+	return &valueRunReader{
+		base:   values,
+		buffer: make([]Value, defaultValueBufferSize),
+	}
+}
+
+type valueRunReader struct {
+	base   ValueReader
+	buffer []Value
+	offset int
+	length int
+	run    ValueRun
+	err    error
+}
+
+func (r *valueRunReader) ReadValueRuns(runs []ValueRun) (n int, err error) {
+	for n < len(runs) {
+		if r.offset == r.length {
+			if r.err != nil {
+				break
+			}
+			if n > 0 {
+				// The runs read by this call may reference memory owned by
+				// the buffer that is about to be overwritten, they are
+				// returned before reading more values.
+				return n, nil
+			}
+			if r.run.Count > 0 {
+				// The pending run value may reference memory owned by the
+				// buffer that is about to be overwritten.
+				r.run.Value = r.run.Value.Clone()
+			}
+			clearValues(r.buffer[:r.length])
+			r.offset = 0
+			r.length, r.err = r.base.ReadValues(r.buffer)
+			if r.length == 0 && r.err == nil {
+				// Like io.Reader, the underlying reader returned no values
+				// without reaching the end; let the caller retry.
+				return n, nil
+			}
+			continue
+		}
+
+		v := r.buffer[r.offset]
+		r.offset++
+
+		switch {
+		case r.run.Count == 0:
+			r.run = ValueRun{Value: v, Count: 1}
+		case DeepEqual(r.run.Value, v):
+			r.run.Count++
+		default:
+			runs[n] = r.run
+			r.run = ValueRun{Value: v, Count: 1}
+			n++
+		}
+	}
+
+	if n < len(runs) {
+		if r.run.Count > 0 {
+			runs[n] = r.run
+			r.run = ValueRun{}
+			n++
+		}
+		err = r.err
+	}
+	return n, err
+}
@@ -2,7 +2,9 @@ package parquet_test
 
 import (
 	"bytes"
+	"io"
 	"math"
+	"strings"
 	"testing"
 	"time"
 	"unsafe"
@@ -150,3 +152,148 @@ func TestZeroValue(t *testing.T) {
 		t.Errorf("byte array not zero value: got=%#v", v.ByteArray())
 	}
 }
+
+func TestValueRunsOf(t *testing.T) {
+	values := make([]parquet.Value, 0, 1000)
+	for i := 0; i < 400; i++ {
+		values = append(values, parquet.ByteArrayValue([]byte("A")))
+	}
+	values = append(values, parquet.ByteArrayValue([]byte("B")))
+	for i := 0; i < 599; i++ {
+		values = append(values, parquet.ByteArrayValue([]byte("C")))
+	}
+
+	buffer := parquet.ByteArrayType.NewColumnBuffer(0, len(values))
+	if _, err := buffer.WriteValues(values); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.ValueRunsOf(buffer.Page().Values())
+	runs := make([]parquet.ValueRun, 0, 3)
+	buf := make([]parquet.ValueRun, 2)
+	for {
+		n, err := reader.ReadValueRuns(buf)
+		for _, run := range buf[:n] {
+			runs = append(runs, parquet.ValueRun{Value: run.Value.Clone(), Count: run.Count})
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	want := []parquet.ValueRun{
+		{Value: parquet.ByteArrayValue([]byte("A")), Count: 400},
+		{Value: parquet.ByteArrayValue([]byte("B")), Count: 1},
+		{Value: parquet.ByteArrayValue([]byte("C")), Count: 599},
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("wrong number of runs: want=%d got=%d", len(want), len(runs))
+	}
+	for i := range want {
+		if !parquet.Equal(runs[i].Value, want[i].Value) || runs[i].Count != want[i].Count {
+			t.Errorf("run %d mismatch: want=%v*%d got=%v*%d", i, want[i].Value, want[i].Count, runs[i].Value, runs[i].Count)
+		}
+	}
+}
+
+// scratchValueReader returns BYTE_ARRAY values referencing a scratch buffer
+// which is overwritten by each call to ReadValues, like readers which decode
+// values into internal buffers.
+type scratchValueReader struct {
+	data    string
+	batch   int
+	scratch []byte
+}
+
+func (r *scratchValueReader) ReadValues(values []parquet.Value) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := r.batch
+	if n > len(values) {
+		n = len(values)
+	}
+	if n > len(r.data) {
+		n = len(r.data)
+	}
+	r.scratch = append(r.scratch[:0], r.data[:n]...)
+	for i := range values[:n] {
+		values[i] = parquet.ByteArrayValue(r.scratch[i : i+1])
+	}
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestValueRunsOfReusedBuffers(t *testing.T) {
+	const data = "AABCCCDEEF"
+	reader := parquet.ValueRunsOf(&scratchValueReader{data: data, batch: 3})
+
+	var got string
+	buf := make([]parquet.ValueRun, 10)
+	for {
+		n, err := reader.ReadValueRuns(buf)
+		// The values of runs are only valid until the next call, they must be
+		// consumed before reading more runs.
+		for _, run := range buf[:n] {
+			got += strings.Repeat(string(run.Value.ByteArray()), int(run.Count))
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	if got != data {
+		t.Errorf("values mismatch: want=%q got=%q", data, got)
+	}
+}
+
+// emptyReadsValueReader returns no values and a nil error on every other call
+// to ReadValues.
+type emptyReadsValueReader struct {
+	base  parquet.ValueReader
+	empty bool
+}
+
+func (r *emptyReadsValueReader) ReadValues(values []parquet.Value) (int, error) {
+	if r.empty = !r.empty; r.empty {
+		return 0, nil
+	}
+	return r.base.ReadValues(values)
+}
+
+func TestValueRunsOfEmptyReads(t *testing.T) {
+	const data = "AABCCCDEEF"
+	reader := parquet.ValueRunsOf(&emptyReadsValueReader{
+		base: &scratchValueReader{data: data, batch: 3},
+	})
+
+	var got string
+	var empty int
+	buf := make([]parquet.ValueRun, 10)
+	for {
+		n, err := reader.ReadValueRuns(buf)
+		if n == 0 && err == nil {
+			empty++
+		}
+		for _, run := range buf[:n] {
+			got += strings.Repeat(string(run.Value.ByteArray()), int(run.Count))
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	if got != data {
+		t.Errorf("values mismatch: want=%q got=%q", data, got)
+	}
+	if empty == 0 {
+		t.Error("empty reads of the underlying reader were not passed through")
+	}
+}
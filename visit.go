@@ -0,0 +1,104 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ColumnVisitor is the type of callbacks receiving the values of a column,
+// see VisitColumns.
+//
+// Values are passed in batches decoded from the pages of the column, with
+// their repetition and definition levels set, so programs can tell null values
+// and the boundaries of repeated values apart without assembling rows. Values
+// may reference memory of the page they were read from and are only valid
+// until the visitor returns; programs that need to retain them must use
+// Value.Clone.
+//
+// Returning an error stops the visit, the error is returned by VisitColumns.
+type ColumnVisitor func(values []Value) error
+
+// VisitColumns reads the column chunks of rowGroup and calls the visitor of
+// each column with the values decoded from its pages, which is useful to
+// compute aggregates (e.g. sums or distinct counts) at a lower cost than
+// reading rows.
+//
+// The visitors map is keyed by the dot-separated paths of leaf columns.
+// Columns which have no visitor are not read. Columns are visited one after
+// the other, in the order that they appear in the schema.
+//
+// The function returns an error if one of the paths is not the path of a leaf
+// column of the row group.
+func VisitColumns(rowGroup RowGroup, visitors map[string]ColumnVisitor) error {
+	type columnVisitor struct {
+		columnIndex int
+		visit       ColumnVisitor
+	}
+
+	schema := rowGroup.Schema()
+	columns := make([]columnVisitor, 0, len(visitors))
+
+	for path, visit := range visitors {
+		leaf, ok := schema.Lookup(strings.Split(path, ".")...)
+		if !ok {
+			return fmt.Errorf("cannot visit column %q which is not a leaf column of the row group", path)
+		}
+		columns = append(columns, columnVisitor{columnIndex: leaf.ColumnIndex, visit: visit})
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].columnIndex < columns[j].columnIndex
+	})
+
+	columnChunks := rowGroup.ColumnChunks()
+	buffer := make([]Value, defaultValueBufferSize)
+
+	for _, column := range columns {
+		if err := visitColumnChunk(columnChunks[column.columnIndex], buffer, column.visit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func visitColumnChunk(columnChunk ColumnChunk, buffer []Value, visit ColumnVisitor) error {
+	pages := columnChunk.Pages()
+	defer pages.Close()
+
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return err
+		}
+		err = visitValues(p.Values(), buffer, visit)
+		Release(p)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func visitValues(values ValueReader, buffer []Value, visit ColumnVisitor) error {
+	for {
+		n, err := values.ReadValues(buffer)
+
+		if n > 0 {
+			if err := visit(buffer[:n]); err != nil {
+				return err
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestVisitColumns(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Score *float64 `parquet:"score,optional"`
+		Tags  []string `parquet:"tags,list"`
+	}
+
+	score := func(f float64) *float64 { return &f }
+	rows := []Row{
+		{ID: 1, Score: score(1.5), Tags: []string{"a", "b"}},
+		{ID: 2},
+		{ID: 3, Score: score(2.5), Tags: []string{"c"}},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(16)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sum float64
+	var nulls, tags, lists int
+
+	err = parquet.VisitColumns(f.RowGroups()[0], map[string]parquet.ColumnVisitor{
+		"score": func(values []parquet.Value) error {
+			for _, v := range values {
+				if v.IsNull() {
+					nulls++
+				} else {
+					sum += v.Double()
+				}
+			}
+			return nil
+		},
+		"tags.list.element": func(values []parquet.Value) error {
+			for _, v := range values {
+				if v.RepetitionLevel() == 0 {
+					lists++
+				}
+				if !v.IsNull() {
+					tags++
+				}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sum != 4 {
+		t.Errorf("sum mismatch: want=%v got=%v", 4.0, sum)
+	}
+	if nulls != 1 {
+		t.Errorf("nulls mismatch: want=%d got=%d", 1, nulls)
+	}
+	if tags != 3 || lists != 3 {
+		t.Errorf("tags mismatch: want=%d/%d got=%d/%d", 3, 3, tags, lists)
+	}
+
+	stop := errors.New("stop")
+	err = parquet.VisitColumns(f.RowGroups()[0], map[string]parquet.ColumnVisitor{
+		"id": func([]parquet.Value) error { return stop },
+	})
+	if err != stop {
+		t.Errorf("error mismatch: want=%v got=%v", stop, err)
+	}
+
+	err = parquet.VisitColumns(f.RowGroups()[0], map[string]parquet.ColumnVisitor{
+		"tags": func([]parquet.Value) error { return nil },
+	})
+	if err == nil {
+		t.Error("expected an error when visiting a column which is not a leaf")
+	}
+}
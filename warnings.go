@@ -0,0 +1,126 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Warning describes a non-fatal issue found in a parquet file, see
+// File.Warnings.
+type Warning struct {
+	// Path of the column that the issue applies to, nil if it applies to the
+	// whole file.
+	Path []string
+	// Description of the issue.
+	Message string
+	// Number of times that the issue was found, for example the number of
+	// column chunks or pages that it applies to.
+	Count int
+}
+
+// String returns a human-readable representation of the warning.
+func (w Warning) String() string {
+	s := w.Message
+	if w.Path != nil {
+		s = strings.Join(w.Path, ".") + ": " + s
+	}
+	if w.Count > 1 {
+		s += fmt.Sprintf(" (x%d)", w.Count)
+	}
+	return s
+}
+
+// Warnings returns the non-fatal issues found in f, allowing data quality
+// tools to report them without failing to read the file.
+//
+// The issues found in the metadata, such as columns annotated with deprecated
+// converted types and no logical types, or column chunks which have values but
+// no statistics, are listed first. They are followed by the recoveries made
+// while reading pages of files opened with the DetectCompression or
+// ReconcileLevelCounts options, in the order that they were first made; this
+// part of the list grows as pages are read. Issues found multiple times in the
+// same column are reported once, with their number of occurrences.
+//
+// The returned slice is a copy, programs may retain and modify it.
+func (f *File) Warnings() []Warning {
+	f.metadataWarningsOnce.Do(f.initMetadataWarnings)
+	warnings := make([]Warning, 0, len(f.metadataWarnings))
+	warnings = append(warnings, f.metadataWarnings...)
+	return f.readWarnings.appendTo(warnings)
+}
+
+func (f *File) initMetadataWarnings() {
+	var warnings warningSet
+
+	forEachColumn(f.root, func(c *Column) {
+		if c.schema.ConvertedType != nil && c.schema.LogicalType == nil && c != f.root {
+			warnings.add(c.Path(), fmt.Sprintf("deprecated converted type %s is used without a logical type", *c.schema.ConvertedType))
+		}
+	})
+
+	f.root.forEachLeaf(func(c *Column) {
+		for _, rowGroup := range f.rowGroups {
+			chunk := rowGroup.ColumnChunks()[c.index].(*fileColumnChunk)
+			if err := chunk.load(); err != nil {
+				warnings.add(c.Path(), fmt.Sprintf("column chunk metadata cannot be decoded: %v", err))
+				continue
+			}
+			if metadata := &chunk.chunk.MetaData; !hasStatistics(metadata.NumValues, &metadata.Statistics) {
+				warnings.add(c.Path(), "column chunk has values but no statistics")
+			}
+		}
+	})
+
+	f.metadataWarnings = warnings.list
+}
+
+func forEachColumn(c *Column, do func(*Column)) {
+	do(c)
+	for _, child := range c.columns {
+		forEachColumn(child, do)
+	}
+}
+
+// hasStatistics returns true if the statistics of a column chunk with the
+// given number of values record bounds, or if the column chunk has only nulls.
+func hasStatistics(numValues int64, stats *format.Statistics) bool {
+	switch {
+	case numValues == 0 || stats.NullCount == numValues:
+		return true
+	case stats.MinValue != nil || stats.MaxValue != nil:
+		return true
+	default:
+		return stats.Min != nil || stats.Max != nil
+	}
+}
+
+// warningSet collects warnings, merging those which have the same path and
+// message. The zero-value is an empty set, and the methods are safe to use
+// concurrently from multiple goroutines.
+type warningSet struct {
+	mutex sync.Mutex
+	list  []Warning
+}
+
+func (s *warningSet) add(path []string, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := range s.list {
+		if w := &s.list[i]; w.Message == message && stringsAreEqual(w.Path, path) {
+			w.Count++
+			return
+		}
+	}
+
+	s.list = append(s.list, Warning{Path: path, Message: message, Count: 1})
+}
+
+func (s *warningSet) appendTo(warnings []Warning) []Warning {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append(warnings, s.list...)
+}
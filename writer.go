@@ -3,6 +3,7 @@ package parquet
 import (
 	"bufio"
 	"bytes"
+	"encoding/base64"
 	"encoding/binary"
 	"fmt"
 	"hash/crc32"
@@ -11,11 +12,16 @@ import (
 	"math/bits"
 	"reflect"
 	"sort"
+	"strconv"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/parquet-go/parquet-go/compress"
 	"github.com/parquet-go/parquet-go/encoding"
 	"github.com/parquet-go/parquet-go/encoding/plain"
 	"github.com/parquet-go/parquet-go/format"
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/parquet-go/parquet-go/sparse"
 	"github.com/segmentio/encoding/thrift"
 )
 
@@ -70,6 +76,10 @@ type GenericWriter[T any] struct {
 	write writeFunc[T]
 	// This field is used to leverage the optimized writeRowsFunc algorithms.
 	columns []ColumnBuffer
+	// When the writer is configured with strict timestamps, this function
+	// validates the rows prior to writing them. It is nil if the rows do not
+	// contain time values which could be truncated.
+	checkTimestamps checkTimestampsFunc
 }
 
 // NewGenericWriter is like NewWriter but returns a GenericWriter[T] suited to
@@ -90,10 +100,18 @@ type GenericWriter[T any] struct {
 // columns. See SortingWriter[T] for a writer which handles reordering rows
 // based on the configured sorting columns.
 func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *GenericWriter[T] {
-	config, err := NewWriterConfig(options...)
+	w, err := newGenericWriter[T](output, options...)
 	if err != nil {
 		panic(err)
 	}
+	return w
+}
+
+func newGenericWriter[T any](output io.Writer, options ...WriterOption) (*GenericWriter[T], error) {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		return nil, err
+	}
 
 	schema := config.Schema
 	t := typeOf[T]()
@@ -106,16 +124,37 @@ func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *Generic
 	if config.Schema == nil {
 		panic("generic writer must be instantiated with schema or concrete type.")
 	}
+	// The configuration is validated again because some options, like seeded
+	// dictionaries, can only be checked against the schema.
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	var checkTimestamps checkTimestampsFunc
+	if config.StrictTimestamps && t != nil {
+		checkTimestamps = checkTimestampsFuncOf(t, config.Schema, nil)
+	}
+
+	writer := newWriter(output, config)
+	write := writeFuncOf[T](t, config.Schema)
+	if (writer.validateValues || config.Sorting.EnforceSortOrder) && t != nil {
+		// Validating values and replacing them with nulls requires access to
+		// their definition levels, and comparing rows requires their values,
+		// which can only be done on deconstructed rows. Rows are deconstructed
+		// once and validated before any of their values are buffered.
+		write = (*GenericWriter[T]).writeRows
+	}
 
 	return &GenericWriter[T]{
 		base: Writer{
 			output: output,
 			config: config,
 			schema: schema,
-			writer: newWriter(output, config),
+			writer: writer,
 		},
-		write: writeFuncOf[T](t, config.Schema),
-	}
+		write:           write,
+		checkTimestamps: checkTimestamps,
+	}, nil
 }
 
 type writeFunc[T any] func(*GenericWriter[T], []T) (int, error)
@@ -163,6 +202,12 @@ func (w *GenericWriter[T]) Close() error {
 	return w.base.Close()
 }
 
+// Abort discards the rows buffered by w without writing the parquet footer,
+// see Writer.Abort.
+func (w *GenericWriter[T]) Abort() {
+	w.base.Abort()
+}
+
 func (w *GenericWriter[T]) Flush() error {
 	return w.base.Flush()
 }
@@ -171,8 +216,30 @@ func (w *GenericWriter[T]) Reset(output io.Writer) {
 	w.base.Reset(output)
 }
 
+// Write writes the rows passed as argument to w, returning the number of rows
+// written.
+//
+// When T is a struct type, or a pointer to a struct, rows are written in
+// batches: the values of each field are extracted from the whole slice of rows
+// and appended to the buffer of their column in a single operation, amortizing
+// the cost of reflection and type dispatch across the batch. Programs writing
+// large numbers of rows should call Write with slices of rows rather than one
+// row at a time. Writers configured to validate values, to replace non-finite
+// floats with nulls, or to enforce the order of sorting columns deconstruct
+// rows one at a time.
+//
+// The rows slice is not retained by the writer and may be reused by the caller
+// after Write returns.
 func (w *GenericWriter[T]) Write(rows []T) (int, error) {
 	return w.base.writer.writeRows(len(rows), func(i, j int) (int, error) {
+		if w.checkTimestamps != nil {
+			for k := i; k < j; k++ {
+				if err := w.checkTimestamps(reflect.ValueOf(&rows[k]).Elem()); err != nil {
+					return 0, err
+				}
+			}
+		}
+
 		n, err := w.write(w, rows[i:j:j])
 		if err != nil {
 			return n, err
@@ -211,6 +278,12 @@ func (w *GenericWriter[T]) SetKeyValueMetadata(key, value string) {
 	w.base.SetKeyValueMetadata(key, value)
 }
 
+// SetRowGroupKeyValueMetadata sets a key/value pair in the metadata of the
+// row group currently being written, see Writer.SetRowGroupKeyValueMetadata.
+func (w *GenericWriter[T]) SetRowGroupKeyValueMetadata(key, value string) {
+	w.base.SetRowGroupKeyValueMetadata(key, value)
+}
+
 func (w *GenericWriter[T]) ReadRowsFrom(rows RowReader) (int64, error) {
 	return w.base.ReadRowsFrom(rows)
 }
@@ -232,12 +305,27 @@ func (w *GenericWriter[T]) writeRows(rows []T) (int, error) {
 		w.base.rowbuf[i] = schema.Deconstruct(w.base.rowbuf[i], &rows[i])
 	}
 
-	return w.base.WriteRows(w.base.rowbuf)
+	// The rows are already accounted for by the call to writeRows in Write.
+	return w.base.writer.writeRowValues(w.base.rowbuf)
 }
 
 func (w *GenericWriter[T]) writeAny(rows []T) (n int, err error) {
+	if cap(w.base.rowbuf) == 0 {
+		w.base.rowbuf = make([]Row, 1)
+	} else {
+		w.base.rowbuf = w.base.rowbuf[:1]
+	}
+	defer clearRows(w.base.rowbuf)
+
 	for i := range rows {
-		if err = w.base.Write(rows[i]); err != nil {
+		if w.base.config.StrictTimestamps {
+			if err = w.base.checkRowTimestamps(rows[i]); err != nil {
+				return n, err
+			}
+		}
+		w.base.rowbuf[0] = w.base.schema.Deconstruct(w.base.rowbuf[0][:0], rows[i])
+		// The rows are already accounted for by the call to writeRows in Write.
+		if _, err = w.base.writer.writeRowValues(w.base.rowbuf); err != nil {
 			return n, err
 		}
 		n++
@@ -291,6 +379,11 @@ type Writer struct {
 	schema *Schema
 	writer *writer
 	rowbuf []Row
+
+	// Cache of the function validating timestamps of the last type of Go
+	// values written when the writer is configured with strict timestamps.
+	timestampsType  reflect.Type
+	checkTimestamps checkTimestampsFunc
 }
 
 // NewWriter constructs a parquet writer writing a file to the given io.Writer.
@@ -318,18 +411,24 @@ func NewWriter(output io.Writer, options ...WriterOption) *Writer {
 		output: output,
 		config: config,
 	}
-	if config.Schema != nil {
-		w.configure(config.Schema)
+	if err := w.configure(config.Schema); err != nil {
+		panic(err)
 	}
 	return w
 }
 
-func (w *Writer) configure(schema *Schema) {
+func (w *Writer) configure(schema *Schema) error {
 	if schema != nil {
+		// The configuration is validated again because some options, like
+		// seeded dictionaries, can only be checked against the schema.
 		w.config.Schema = schema
+		if err := w.config.Validate(); err != nil {
+			return err
+		}
 		w.schema = schema
 		w.writer = newWriter(w.output, w.config)
 	}
+	return nil
 }
 
 // Close must be called after all values were produced to the writer in order to
@@ -341,6 +440,20 @@ func (w *Writer) Close() error {
 	return nil
 }
 
+// Abort discards the rows buffered by w and releases the page buffers that it
+// holds, without writing the parquet footer. Writers must not be used after
+// being aborted, unless they are reset to write another file.
+//
+// The output of the writer may contain the beginning of the file and the row
+// groups that were flushed before Abort was called, but never a footer: readers
+// fail to open the partially written file. Applications writing to the local
+// file system can use an AtomicFile as output to also discard these bytes.
+func (w *Writer) Abort() {
+	if w.writer != nil {
+		w.writer.abort()
+	}
+}
+
 // Flush flushes all buffers into a row group to the underlying io.Writer.
 //
 // Flush is called automatically on Close, it is only useful to call explicitly
@@ -375,7 +488,9 @@ func (w *Writer) Reset(output io.Writer) {
 // be a struct or pointer to struct.
 func (w *Writer) Write(row interface{}) error {
 	if w.schema == nil {
-		w.configure(SchemaOf(row))
+		if err := w.configure(SchemaOf(row)); err != nil {
+			return err
+		}
 	}
 	if cap(w.rowbuf) == 0 {
 		w.rowbuf = make([]Row, 1)
@@ -383,11 +498,31 @@ func (w *Writer) Write(row interface{}) error {
 		w.rowbuf = w.rowbuf[:1]
 	}
 	defer clearRows(w.rowbuf)
+	if w.config.StrictTimestamps {
+		if err := w.checkRowTimestamps(row); err != nil {
+			return err
+		}
+	}
 	w.rowbuf[0] = w.schema.Deconstruct(w.rowbuf[0][:0], row)
 	_, err := w.WriteRows(w.rowbuf)
 	return err
 }
 
+func (w *Writer) checkRowTimestamps(row interface{}) error {
+	v := reflect.ValueOf(row)
+	if !v.IsValid() {
+		return nil
+	}
+	if t := v.Type(); t != w.timestampsType {
+		w.timestampsType = t
+		w.checkTimestamps = checkTimestampsFuncOf(t, w.schema, nil)
+	}
+	if w.checkTimestamps == nil {
+		return nil
+	}
+	return w.checkTimestamps(v)
+}
+
 // WriteRows is called to write rows to the parquet file.
 //
 // The Writer must have been given a schema when NewWriter was called, otherwise
@@ -412,7 +547,9 @@ func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	case rowGroupSchema == nil:
 		return 0, ErrRowGroupSchemaMissing
 	case w.schema == nil:
-		w.configure(rowGroupSchema)
+		if err := w.configure(rowGroupSchema); err != nil {
+			return 0, err
+		}
 	case !nodesAreEqual(w.schema, rowGroupSchema):
 		return 0, ErrRowGroupSchemaMismatch
 	}
@@ -437,7 +574,9 @@ func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 func (w *Writer) ReadRowsFrom(rows RowReader) (written int64, err error) {
 	if w.schema == nil {
 		if r, ok := rows.(RowReaderWithSchema); ok {
-			w.configure(r.Schema())
+			if err := w.configure(r.Schema()); err != nil {
+				return 0, err
+			}
 		}
 	}
 	if cap(w.rowbuf) < defaultRowBufferSize {
@@ -463,17 +602,36 @@ func (w *Writer) Schema() *Schema { return w.schema }
 // cause some key/value pairs to be lost when open parquet files written with
 // repeated keys. We can revisit this decision if it ever becomes a blocker.
 func (w *Writer) SetKeyValueMetadata(key, value string) {
-	for i, kv := range w.writer.metadata {
+	w.writer.metadata = setKeyValueMetadata(w.writer.metadata, key, value)
+}
+
+// SetRowGroupKeyValueMetadata sets a key/value pair in the metadata of the
+// row group currently being written, for example to record the identifier of
+// the batch of data that the rows were produced from. The metadata is cleared
+// after the row group is flushed.
+//
+// The parquet format does not have key/value metadata on row groups, by
+// convention the writer records it in the key/value metadata of the first
+// column chunk of the row group. Row groups of files opened with OpenFile
+// implement RowGroupKeyValueMetadata to expose it:
+//
+//	rowGroup := f.RowGroups()[0].(parquet.RowGroupKeyValueMetadata)
+//	batchID, ok := rowGroup.Lookup("batch_id")
+//
+// Like with SetKeyValueMetadata, keys are assumed to be unique and the last
+// value set for a key is retained.
+func (w *Writer) SetRowGroupKeyValueMetadata(key, value string) {
+	w.writer.rowGroupMetadata = setKeyValueMetadata(w.writer.rowGroupMetadata, key, value)
+}
+
+func setKeyValueMetadata(metadata []format.KeyValue, key, value string) []format.KeyValue {
+	for i, kv := range metadata {
 		if kv.Key == key {
-			kv.Value = value
-			w.writer.metadata[i] = kv
-			return
+			metadata[i].Value = value
+			return metadata
 		}
 	}
-	w.writer.metadata = append(w.writer.metadata, format.KeyValue{
-		Key:   key,
-		Value: value,
-	})
+	return append(metadata, format.KeyValue{Key: key, Value: value})
 }
 
 type writer struct {
@@ -483,14 +641,38 @@ type writer struct {
 	numRows int64
 	maxRows int64
 
+	// Block size that row groups are aligned on, and maximum number of bytes
+	// of padding written to align them.
+	blockSize  int64
+	maxPadding int64
+
 	createdBy string
 	metadata  []format.KeyValue
+	// Key/value metadata of the row group being written, recorded in the
+	// first column chunk of the row group.
+	rowGroupMetadata []format.KeyValue
+
+	// Set when the writer must reject BYTE_ARRAY values exceeding the maximum
+	// length configured on the columns, or non-finite floating point values.
+	validateValues bool
+	// Set when the writer must replace non-finite floating point values with
+	// nulls.
+	nullNonFinite bool
+	// Set when the writer must verify that rows are written in the order of
+	// the sorting columns; lastRow holds a copy of the last row written to the
+	// current row group.
+	compareRows func(Row, Row) int
+	lastRow     Row
 
 	columns     []*writerColumn
 	columnChunk []format.ColumnChunk
 	columnIndex []format.ColumnIndex
 	offsetIndex []format.OffsetIndex
 
+	// Scratch buffers of the goroutines flushing the columns of row groups,
+	// nil when columns are flushed sequentially.
+	flushBuffers []*writerBuffers
+
 	columnOrders   []format.ColumnOrder
 	schemaElements []format.SchemaElement
 	rowGroups      []format.RowGroup
@@ -508,7 +690,14 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		w.writer.Reset(w.buffer)
 	}
 	w.maxRows = config.MaxRowsPerRowGroup
+	w.blockSize = config.RowGroupAlignment
+	w.maxPadding = config.MaxRowGroupPadding
 	w.createdBy = config.CreatedBy
+	w.validateValues = (config.MaxByteArrayLength > 0 && !config.TruncateByteArrays) || config.NonFiniteFloats != AllowNonFinite
+	w.nullNonFinite = config.NonFiniteFloats == NullNonFinite
+	if config.Sorting.EnforceSortOrder && len(config.Sorting.SortingColumns) > 0 {
+		w.compareRows = config.Schema.Comparator(config.Sorting.SortingColumns...)
+	}
 	w.metadata = make([]format.KeyValue, 0, len(config.KeyValueMetadata))
 	for k, v := range config.KeyValueMetadata {
 		w.metadata = append(w.metadata, format.KeyValue{Key: k, Value: v})
@@ -565,9 +754,14 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 	// Those buffers are scratch space used to generate the page header and
 	// content, they are shared by all column chunks because they are only
 	// used during calls to writeDictionaryPage or writeDataPage, which are
-	// not done concurrently.
+	// not done concurrently, except when flushing row groups with a flush
+	// concurrency greater than one, where each goroutine has its own buffers.
 	buffers := new(writerBuffers)
 
+	// Codecs configured with compression levels are shared by the columns
+	// using the same codec and level, so they also share the codec buffers.
+	leveledCodecs := make(compressionLevelCache)
+
 	forEachLeafColumnOf(config.Schema, func(leaf leafColumn) {
 		encoding := encodingOf(leaf.node)
 		dictionary := Dictionary(nil)
@@ -579,6 +773,21 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			compression = defaultCompression
 		}
 
+		if level, ok := searchCompressionLevel(config.CompressionLevels, leaf.path); ok {
+			compression = leveledCodecs.get(compression, level)
+		}
+
+		if dict, ok := config.CompressionDictionaries[leaf.path.String()]; ok {
+			compression = withCompressionDictionary(compression, dict)
+		}
+
+		dictionarySeed, hasDictionarySeed := config.Dictionaries[leaf.path.String()]
+		if hasDictionarySeed {
+			if !isDictionaryEncoding(encoding) {
+				encoding = &RLEDictionary
+			}
+		}
+
 		if isDictionaryEncoding(encoding) {
 			dictBuffer := columnType.NewValues(
 				make([]byte, 0, defaultDictBufferSize),
@@ -588,6 +797,9 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			columnType = dictionary.Type()
 		}
 
+		transform := searchPageTransform(config.PageTransforms, leaf.path)
+		omitBounds := transform != nil || searchOmitStatistics(config.OmitStatistics, leaf.path)
+
 		c := &writerColumn{
 			buffers:            buffers,
 			pool:               config.ColumnPageBuffers,
@@ -595,14 +807,17 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			columnType:         columnType,
 			columnIndex:        columnType.NewColumnIndexer(config.ColumnIndexSizeLimit),
 			columnFilter:       searchBloomFilterColumn(config.BloomFilters, leaf.path),
+			transform:          transform,
 			compression:        compression,
 			dictionary:         dictionary,
+			dictionarySeed:     dictionarySeed,
 			dataPageType:       dataPageType,
 			maxRepetitionLevel: leaf.maxRepetitionLevel,
 			maxDefinitionLevel: leaf.maxDefinitionLevel,
 			bufferIndex:        int32(leaf.columnIndex),
 			bufferSize:         int32(float64(config.PageBufferSize) * 0.98),
-			writePageStats:     config.DataPageStatistics,
+			writePageStats:     config.DataPageStatistics && !omitBounds,
+			omitBounds:         omitBounds,
 			encodings:          make([]format.Encoding, 0, 3),
 			// Data pages in version 2 can omit compression when dictionary
 			// encoding is employed; only the dictionary page needs to be
@@ -613,6 +828,29 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		}
 
 		c.header.encoder.Reset(c.header.protocol.NewWriter(&buffers.header))
+		c.seedDictionary()
+
+		if leaf.node.Type().Kind() == ByteArray {
+			c.truncateUTF8 = isUTF8Type(leaf.node.Type())
+			c.statisticsTruncateLength = config.StatisticsTruncateLength
+			if config.MaxByteArrayLength > 0 {
+				c.maxByteArrayLength = config.MaxByteArrayLength
+				c.truncateByteArrays = config.TruncateByteArrays
+			}
+		}
+
+		switch leaf.node.Type().Kind() {
+		case Float, Double:
+			switch config.NonFiniteFloats {
+			case NullNonFinite:
+				// Non-finite values can only be replaced with nulls in
+				// optional columns, they are rejected by other columns.
+				c.nullNonFinite = leaf.node.Optional()
+				c.rejectNonFinite = !c.nullNonFinite
+			case RejectNonFinite:
+				c.rejectNonFinite = true
+			}
+		}
 
 		if leaf.maxDefinitionLevel > 0 {
 			c.encodings = addEncoding(c.encodings, format.RLE)
@@ -673,6 +911,14 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		w.columnOrders[i] = *c.columnType.ColumnOrder()
 	}
 
+	if concurrency := min(config.FlushConcurrency, len(w.columns)); concurrency > 1 {
+		w.flushBuffers = make([]*writerBuffers, concurrency)
+		w.flushBuffers[0] = buffers
+		for i := 1; i < concurrency; i++ {
+			w.flushBuffers[i] = new(writerBuffers)
+		}
+	}
+
 	return w
 }
 
@@ -685,10 +931,13 @@ func (w *writer) reset(writer io.Writer) {
 	}
 	for _, c := range w.columns {
 		c.reset()
+		c.truncatedValues = 0
 	}
 	for i := range w.rowGroups {
 		w.rowGroups[i] = format.RowGroup{}
 	}
+	w.lastRow = w.lastRow[:0]
+	w.rowGroupMetadata = w.rowGroupMetadata[:0]
 	for i := range w.columnIndexes {
 		w.columnIndexes[i] = nil
 	}
@@ -700,6 +949,13 @@ func (w *writer) reset(writer io.Writer) {
 	w.offsetIndexes = w.offsetIndexes[:0]
 }
 
+func (w *writer) abort() {
+	w.reset(nil)
+	// Detaching the output makes any further attempt to write the file fail
+	// with io.ErrClosedPipe.
+	w.writer.Reset(nil)
+}
+
 func (w *writer) close() error {
 	if err := w.writeFileHeader(); err != nil {
 		return err
@@ -759,6 +1015,12 @@ func (w *writer) writeFileFooter() error {
 	for i, columnIndexes := range w.columnIndexes {
 		rowGroup := &w.rowGroups[i]
 		for j := range columnIndexes {
+			if w.columns[j].omitBounds {
+				// The bounds of pages would expose the values of columns
+				// that the application chose to transform or to omit the
+				// statistics of.
+				continue
+			}
 			column := &rowGroup.Columns[j]
 			column.ColumnIndexOffset = w.writer.offset
 			if err := encoder.Encode(&columnIndexes[j]); err != nil {
@@ -785,12 +1047,37 @@ func (w *writer) writeFileFooter() error {
 		numRows += w.rowGroups[rowGroupIndex].NumRows
 	}
 
+	metadata := w.metadata
+	for _, c := range w.columns {
+		if c.truncatedValues > 0 {
+			if len(metadata) == len(w.metadata) {
+				metadata = append([]format.KeyValue{}, w.metadata...)
+			}
+			metadata = append(metadata, format.KeyValue{
+				Key:   truncatedValuesKeyPrefix + c.columnPath.String(),
+				Value: strconv.FormatInt(c.truncatedValues, 10),
+			})
+		}
+		if dict := compressionDictionaryOf(c.compression); len(dict) > 0 {
+			if len(metadata) == len(w.metadata) {
+				metadata = append([]format.KeyValue{}, w.metadata...)
+			}
+			metadata = append(metadata, format.KeyValue{
+				Key:   zstdDictionaryKeyPrefix + c.columnPath.String(),
+				Value: base64.StdEncoding.EncodeToString(dict),
+			})
+		}
+	}
+	if len(metadata) != len(w.metadata) {
+		sortKeyValueMetadata(metadata)
+	}
+
 	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &format.FileMetaData{
 		Version:          1,
 		Schema:           w.schemaElements,
 		NumRows:          numRows,
 		RowGroups:        w.rowGroups,
-		KeyValueMetadata: w.metadata,
+		KeyValueMetadata: metadata,
 		CreatedBy:        w.createdBy,
 		ColumnOrders:     w.columnOrders,
 	})
@@ -807,6 +1094,30 @@ func (w *writer) writeFileFooter() error {
 	return err
 }
 
+// writePadding writes zero bytes up to the next block boundary if the writer
+// aligns row groups and the space left in the current block is small enough.
+func (w *writer) writePadding() error {
+	if w.blockSize <= 0 {
+		return nil
+	}
+	remaining := w.blockSize - w.writer.offset%w.blockSize
+	if remaining == w.blockSize || remaining > w.maxPadding {
+		return nil
+	}
+	var zero [4096]byte
+	for remaining > 0 {
+		n := int64(len(zero))
+		if n > remaining {
+			n = remaining
+		}
+		if _, err := w.writer.Write(zero[:n]); err != nil {
+			return err
+		}
+		remaining -= n
+	}
+	return nil
+}
+
 func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []SortingColumn) (int64, error) {
 	numRows := w.columns[0].totalRowCount()
 	if numRows == 0 {
@@ -819,6 +1130,8 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 
 	defer func() {
 		w.numRows = 0
+		w.lastRow = w.lastRow[:0]
+		w.rowGroupMetadata = w.rowGroupMetadata[:0]
 		for _, c := range w.columns {
 			c.reset()
 		}
@@ -827,36 +1140,42 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		}
 	}()
 
-	for _, c := range w.columns {
-		if err := c.flush(); err != nil {
-			return 0, err
-		}
-		if err := c.flushFilterPages(); err != nil {
-			return 0, err
-		}
+	if err := w.flushColumns(); err != nil {
+		return 0, err
 	}
 
 	if err := w.writeFileHeader(); err != nil {
 		return 0, err
 	}
-	fileOffset := w.writer.offset
+	if err := w.writePadding(); err != nil {
+		return 0, err
+	}
 
+	// Bloom filters are written before the pages of the row group, with the
+	// length of the header and bitset recorded in the column metadata so
+	// readers like parquet-mr (used by Spark) can load them in a single read.
 	for _, c := range w.columns {
 		if len(c.filter) > 0 {
-			c.columnChunk.MetaData.BloomFilterOffset = w.writer.offset
+			offset := w.writer.offset
 			if err := c.writeBloomFilter(&w.writer); err != nil {
 				return 0, err
 			}
+			c.columnChunk.MetaData.BloomFilterOffset = offset
+			c.columnChunk.MetaData.BloomFilterLength = int32(w.writer.offset - offset)
 		}
 	}
 
+	// The file offset of the row group is the offset of its first page, which
+	// readers use to assign row groups to splits of the file.
+	fileOffset := w.writer.offset
+
 	for i, c := range w.columns {
 		w.columnIndex[i] = format.ColumnIndex(c.columnIndex.ColumnIndex())
 
-		if c.dictionary != nil {
+		if c.dictionaryPage != nil {
 			c.columnChunk.MetaData.DictionaryPageOffset = w.writer.offset
-			if err := c.writeDictionaryPage(&w.writer, c.dictionary); err != nil {
-				return 0, fmt.Errorf("writing dictionary page of row group colum %d: %w", i, err)
+			if _, err := io.Copy(&w.writer, c.dictionaryPage); err != nil {
+				return 0, fmt.Errorf("writing dictionary page of row group column %d: %w", i, err)
 			}
 		}
 
@@ -910,6 +1229,14 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		c := &columns[i]
 		c.MetaData.EncodingStats = make([]format.PageEncodingStats, len(c.MetaData.EncodingStats))
 		copy(c.MetaData.EncodingStats, w.columnChunk[i].MetaData.EncodingStats)
+		w.columns[i].truncateStatistics(&c.MetaData.Statistics)
+	}
+
+	if len(w.rowGroupMetadata) > 0 {
+		metadata := make([]format.KeyValue, len(w.rowGroupMetadata))
+		copy(metadata, w.rowGroupMetadata)
+		sortKeyValueMetadata(metadata)
+		columns[0].MetaData.KeyValueMetadata = metadata
 	}
 
 	for i := range offsetIndex {
@@ -933,36 +1260,149 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	return numRows, nil
 }
 
+// flushColumns writes the values buffered in the columns to their last data
+// page, and encodes the bloom filters and dictionary pages of the columns, so
+// the column chunks of the row group are ready to be written to the output.
+//
+// When the writer was configured with a flush concurrency greater than one,
+// the columns are distributed to as many goroutines, each using its own
+// scratch buffers. The error returned is the one of the first column that
+// failed, which does not depend on the scheduling of goroutines.
+func (w *writer) flushColumns() error {
+	if len(w.flushBuffers) == 0 {
+		for i := range w.columns {
+			if err := w.flushColumn(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	columns := make(chan int, len(w.columns))
+	for i := range w.columns {
+		columns <- i
+	}
+	close(columns)
+
+	errs := make([]error, len(w.columns))
+	wg := sync.WaitGroup{}
+
+	for _, buffers := range w.flushBuffers {
+		wg.Add(1)
+		go func(buffers *writerBuffers) {
+			defer wg.Done()
+			for i := range columns {
+				w.columns[i].setBuffers(buffers)
+				errs[i] = w.flushColumn(i)
+			}
+		}(buffers)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *writer) flushColumn(i int) error {
+	c := w.columns[i]
+	if err := c.flush(); err != nil {
+		return err
+	}
+	if err := c.flushFilterPages(); err != nil {
+		return err
+	}
+	if c.dictionary != nil {
+		if err := c.writeDictionaryPage(c.dictionary); err != nil {
+			return fmt.Errorf("writing dictionary page of row group column %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 func (w *writer) WriteRows(rows []Row) (int, error) {
 	return w.writeRows(len(rows), func(start, end int) (int, error) {
-		defer func() {
-			for i, values := range w.values {
-				clearValues(values)
-				w.values[i] = values[:0]
-			}
-		}()
+		return w.writeRowValues(rows[start:end])
+	})
+}
 
-		// TODO: if an error occurs in this method the writer may be left in an
-		// partially functional state. Applications are not expected to continue
-		// using the writer after getting an error, but maybe we could ensure that
-		// we are preventing further use as well?
-		for _, row := range rows[start:end] {
-			row.Range(func(columnIndex int, columnValues []Value) bool {
-				w.values[columnIndex] = append(w.values[columnIndex], columnValues...)
-				return true
-			})
+// writeRowValues writes the values of rows to the columns of the writer. The
+// method does not account for the rows in the current row group, it must be
+// called from a function passed to writeRows.
+func (w *writer) writeRowValues(rows []Row) (int, error) {
+	defer func() {
+		for i, values := range w.values {
+			clearValues(values)
+			w.values[i] = values[:0]
+		}
+	}()
+
+	if w.compareRows != nil {
+		if err := w.checkSortOrder(rows); err != nil {
+			return 0, err
 		}
+	}
 
+	// TODO: if an error occurs in this method the writer may be left in an
+	// partially functional state. Applications are not expected to continue
+	// using the writer after getting an error, but maybe we could ensure that
+	// we are preventing further use as well?
+	for _, row := range rows {
+		row.Range(func(columnIndex int, columnValues []Value) bool {
+			w.values[columnIndex] = append(w.values[columnIndex], columnValues...)
+			return true
+		})
+	}
+
+	if w.nullNonFinite {
 		for i, values := range w.values {
-			if len(values) > 0 {
-				if err := w.columns[i].writeRows(values); err != nil {
-					return 0, err
-				}
+			w.columns[i].nullNonFiniteValues(values)
+		}
+	}
+
+	if w.validateValues {
+		for i, values := range w.values {
+			if err := w.columns[i].checkValues(values); err != nil {
+				return 0, err
 			}
 		}
+	}
 
-		return end - start, nil
-	})
+	for i, values := range w.values {
+		if len(values) > 0 {
+			if err := w.columns[i].writeRows(values); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if w.compareRows != nil && len(rows) > 0 {
+		// The values may reference memory owned by the application, they are
+		// copied to be compared with the next rows written.
+		w.lastRow = w.lastRow[:0]
+		for _, v := range rows[len(rows)-1] {
+			w.lastRow = append(w.lastRow, v.Clone())
+		}
+	}
+	return len(rows), nil
+}
+
+// checkSortOrder returns ErrRowsNotSorted if rows are not ordered by the
+// sorting columns of the writer, starting with the last row written to the
+// current row group.
+func (w *writer) checkSortOrder(rows []Row) error {
+	prev := w.lastRow
+	for _, row := range rows {
+		if len(prev) > 0 && w.compareRows(prev, row) > 0 {
+			return ErrRowsNotSorted
+		}
+		prev = row
+	}
+	return nil
 }
 
 func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int, error) {
@@ -1105,6 +1545,18 @@ func (wb *writerBuffers) compress(codec compress.Codec) (err error) {
 	return err
 }
 
+// transform applies the page transform to the levels and data of the page,
+// which are all held in the page buffer after the call.
+func (wb *writerBuffers) transform(t PageTransform) (err error) {
+	wb.scratch = append(wb.scratch[:0], wb.repetitions...)
+	wb.scratch = append(wb.scratch, wb.definitions...)
+	wb.scratch = append(wb.scratch, wb.page...)
+	wb.repetitions = wb.repetitions[:0]
+	wb.definitions = wb.definitions[:0]
+	wb.page, err = t.Encode(wb.page[:0], wb.scratch)
+	return err
+}
+
 func (wb *writerBuffers) swapPageAndScratchBuffers() {
 	wb.page, wb.scratch = wb.scratch, wb.page[:0]
 }
@@ -1118,10 +1570,33 @@ type writerColumn struct {
 	columnIndex  ColumnIndexer
 	columnBuffer ColumnBuffer
 	columnFilter BloomFilterColumn
+	transform    PageTransform
 	encoding     encoding.Encoding
 	compression  compress.Codec
 	dictionary   Dictionary
 
+	// Values inserted in the dictionary at the beginning of each row group.
+	dictionarySeed []Value
+
+	// Maximum length of BYTE_ARRAY values, and number of values truncated to
+	// this length when the writer is configured to truncate them.
+	maxByteArrayLength int
+	truncateByteArrays bool
+	truncateUTF8       bool
+	truncatedValues    int64
+
+	// Policy applied to NaN and infinite values of FLOAT and DOUBLE columns.
+	nullNonFinite   bool
+	rejectNonFinite bool
+
+	// The min and max values of the column are not recorded when omitBounds
+	// is true, either because the column was configured to omit statistics or
+	// because its pages are transformed. Otherwise, the min and max values of
+	// BYTE_ARRAY columns are truncated to statisticsTruncateLength when it is
+	// not zero.
+	omitBounds               bool
+	statisticsTruncateLength int
+
 	dataPageType       format.PageType
 	maxRepetitionLevel byte
 	maxDefinitionLevel byte
@@ -1143,6 +1618,10 @@ type writerColumn struct {
 
 	columnChunk *format.ColumnChunk
 	offsetIndex *format.OffsetIndex
+
+	// Buffer holding the dictionary page of the column chunk, which is encoded
+	// when the row group is flushed.
+	dictionaryPage io.ReadWriteSeeker
 }
 
 func (c *writerColumn) reset() {
@@ -1154,6 +1633,7 @@ func (c *writerColumn) reset() {
 	}
 	if c.dictionary != nil {
 		c.dictionary.Reset()
+		c.seedDictionary()
 	}
 	for _, page := range c.pages {
 		c.pool.PutBuffer(page)
@@ -1162,6 +1642,10 @@ func (c *writerColumn) reset() {
 		c.pages[i] = nil
 	}
 	c.pages = c.pages[:0]
+	if c.dictionaryPage != nil {
+		c.pool.PutBuffer(c.dictionaryPage)
+		c.dictionaryPage = nil
+	}
 	// Bloom filters may change in size between row groups, but we retain the
 	// buffer to avoid reallocating large memory blocks.
 	c.filter = c.filter[:0]
@@ -1176,9 +1660,25 @@ func (c *writerColumn) reset() {
 	c.columnChunk.MetaData.Statistics = format.Statistics{}
 	c.columnChunk.MetaData.EncodingStats = c.columnChunk.MetaData.EncodingStats[:0]
 	c.columnChunk.MetaData.BloomFilterOffset = 0
+	c.columnChunk.MetaData.BloomFilterLength = 0
 	c.offsetIndex.PageLocations = c.offsetIndex.PageLocations[:0]
 }
 
+func (c *writerColumn) seedDictionary() {
+	if len(c.dictionarySeed) > 0 {
+		indexes := make([]int32, len(c.dictionarySeed))
+		c.dictionary.Insert(indexes, c.dictionarySeed)
+	}
+}
+
+// setBuffers changes the scratch buffers that c uses to encode pages.
+func (c *writerColumn) setBuffers(buffers *writerBuffers) {
+	if c.buffers != buffers {
+		c.buffers = buffers
+		c.header.encoder.Reset(c.header.protocol.NewWriter(&buffers.header))
+	}
+}
+
 func (c *writerColumn) totalRowCount() int64 {
 	n := c.numRows
 	if c.columnBuffer != nil {
@@ -1265,6 +1765,14 @@ func (c *writerColumn) flushFilterPages() error {
 		if _, err := io.ReadFull(rbuf, pbuf.data); err != nil {
 			return err
 		}
+		if c.transform != nil {
+			decoded, err := decodePage(&buffers, c.transform, pbuf)
+			if err != nil {
+				return err
+			}
+			pbuf.unref()
+			pbuf = decoded
+		}
 		if _, err := p.Seek(0, io.SeekStart); err != nil {
 			return err
 		}
@@ -1304,6 +1812,14 @@ func (c *writerColumn) resizeBloomFilter(numValues int64) {
 
 func (c *writerColumn) newColumnBuffer() ColumnBuffer {
 	column := c.columnType.NewColumnBuffer(int(c.bufferIndex), c.columnType.EstimateNumValues(int(c.bufferSize)))
+	if c.maxByteArrayLength > 0 && c.truncateByteArrays {
+		column = &truncatingColumnBuffer{
+			ColumnBuffer: column,
+			limit:        c.maxByteArrayLength,
+			utf8:         c.truncateUTF8,
+			truncated:    &c.truncatedValues,
+		}
+	}
 	switch {
 	case c.maxRepetitionLevel > 0:
 		column = newRepeatedColumnBuffer(column, c.maxRepetitionLevel, c.maxDefinitionLevel, nullsGoLast)
@@ -1313,6 +1829,57 @@ func (c *writerColumn) newColumnBuffer() ColumnBuffer {
 	return column
 }
 
+func (c *writerColumn) checkValues(values []Value) error {
+	for _, v := range values {
+		if err := c.checkValue(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *writerColumn) checkValue(v Value) error {
+	switch v.Kind() {
+	case ByteArray:
+		if c.maxByteArrayLength > 0 && !c.truncateByteArrays {
+			if n := len(v.byteArray()); n > c.maxByteArrayLength {
+				return fmt.Errorf("%w: value of length %d written to column %s exceeds the limit of %d bytes", ErrByteArrayTooLong, n, c.columnPath, c.maxByteArrayLength)
+			}
+		}
+	case Float, Double:
+		if c.rejectNonFinite && isNonFinite(v) {
+			return fmt.Errorf("%w: %v written to column %s", ErrNonFiniteFloat, v, c.columnPath)
+		}
+	}
+	return nil
+}
+
+// nullNonFiniteValues replaces the NaN and infinite values with nulls when the
+// column is configured to do so.
+func (c *writerColumn) nullNonFiniteValues(values []Value) {
+	if !c.nullNonFinite {
+		return
+	}
+	for i, v := range values {
+		if !v.IsNull() && isNonFinite(v) {
+			values[i] = Value{}.Level(v.RepetitionLevel(), v.DefinitionLevel()-1, v.Column())
+		}
+	}
+}
+
+func isNonFinite(v Value) bool {
+	var f float64
+	switch v.Kind() {
+	case Float:
+		f = float64(v.Float())
+	case Double:
+		f = v.Double()
+	default:
+		return false
+	}
+	return math.IsNaN(f) || math.IsInf(f, 0)
+}
+
 func (c *writerColumn) writeRows(rows []Value) error {
 	if c.columnBuffer == nil {
 		// Lazily create the row group column so we don't need to allocate it if
@@ -1366,7 +1933,7 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 		return 0, fmt.Errorf("encoding parquet data page: %w", err)
 	}
 	if c.dataPageType == format.DataPage {
-		buf.prependLevelsToDataPageV1(c.maxDefinitionLevel, c.maxDefinitionLevel)
+		buf.prependLevelsToDataPageV1(c.maxRepetitionLevel, c.maxDefinitionLevel)
 	}
 
 	uncompressedPageSize := buf.size()
@@ -1379,6 +1946,16 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 		}
 	}
 
+	// The lengths of levels are recorded in the header of data pages v2, they
+	// are captured before the levels are transformed with the rest of the page.
+	repetitionLevelsByteLength := len(buf.repetitions)
+	definitionLevelsByteLength := len(buf.definitions)
+	if c.transform != nil {
+		if err := buf.transform(c.transform); err != nil {
+			return 0, fmt.Errorf("transforming parquet data page: %w", err)
+		}
+	}
+
 	if page.Dictionary() == nil && len(c.filter) > 0 {
 		// When the writer knows the number of values in advance (e.g. when
 		// writing a full row group), the filter encoding is set and the page
@@ -1419,8 +1996,8 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 			NumNulls:                   int32(numNulls),
 			NumRows:                    int32(numRows),
 			Encoding:                   c.encoding.Encoding(),
-			DefinitionLevelsByteLength: int32(len(buf.definitions)),
-			RepetitionLevelsByteLength: int32(len(buf.repetitions)),
+			DefinitionLevelsByteLength: int32(definitionLevelsByteLength),
+			RepetitionLevelsByteLength: int32(repetitionLevelsByteLength),
 			IsCompressed:               &c.isCompressed,
 			Statistics:                 statistics,
 		}
@@ -1459,7 +2036,7 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 	return numValues, nil
 }
 
-func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (err error) {
+func (c *writerColumn) writeDictionaryPage(dict Dictionary) (err error) {
 	buf := c.buffers
 	buf.reset()
 
@@ -1476,6 +2053,11 @@ func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (e
 			return fmt.Errorf("copmressing parquet dictionary page: %w", err)
 		}
 	}
+	if c.transform != nil {
+		if err := buf.transform(c.transform); err != nil {
+			return fmt.Errorf("transforming parquet dictionary page: %w", err)
+		}
+	}
 
 	pageHeader := &format.PageHeader{
 		Type:                 format.DictionaryPage,
@@ -1489,18 +2071,31 @@ func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (e
 		},
 	}
 
-	header := &c.buffers.header
-	header.Reset()
+	buf.header.Reset()
 	if err := c.header.encoder.Encode(pageHeader); err != nil {
 		return err
 	}
-	if _, err := output.Write(header.Bytes()); err != nil {
-		return err
-	}
-	if _, err := output.Write(buf.page); err != nil {
+
+	size := int64(buf.header.Len()) + int64(len(buf.page))
+	buffer, err := c.bufferPage(size, func(output io.Writer) (written int64, err error) {
+		for _, data := range [...][]byte{
+			buf.header.Bytes(),
+			buf.page,
+		} {
+			wn, err := output.Write(data)
+			written += int64(wn)
+			if err != nil {
+				return written, err
+			}
+		}
+		return written, nil
+	})
+	if err != nil {
 		return err
 	}
-	c.recordPageStats(int32(header.Len()), pageHeader, nil)
+
+	c.dictionaryPage = buffer
+	c.recordPageStats(int32(buf.header.Len()), pageHeader, nil)
 	return nil
 }
 
@@ -1512,6 +2107,17 @@ func (w *writerColumn) writePageToFilter(page Page) (err error) {
 }
 
 func (c *writerColumn) writePageTo(size int64, writeTo func(io.Writer) (int64, error)) error {
+	buffer, err := c.bufferPage(size, writeTo)
+	if err != nil {
+		return err
+	}
+	c.pages = append(c.pages, buffer)
+	return nil
+}
+
+// bufferPage writes a page of the given size to a buffer obtained from the
+// pool of c, returning the buffer positioned at the start of the page.
+func (c *writerColumn) bufferPage(size int64, writeTo func(io.Writer) (int64, error)) (io.ReadWriteSeeker, error) {
 	buffer := c.pool.GetBuffer()
 	defer func() {
 		if buffer != nil {
@@ -1520,20 +2126,21 @@ func (c *writerColumn) writePageTo(size int64, writeTo func(io.Writer) (int64, e
 	}()
 	written, err := writeTo(buffer)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if written != size {
-		return fmt.Errorf("writing parquet column page expected %dB but got %dB: %w", size, written, io.ErrShortWrite)
+		return nil, fmt.Errorf("writing parquet column page expected %dB but got %dB: %w", size, written, io.ErrShortWrite)
 	}
 	offset, err := buffer.Seek(0, io.SeekStart)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if offset != 0 {
-		return fmt.Errorf("resetting parquet page buffer to the start expected offset zero but got %d", offset)
+		return nil, fmt.Errorf("resetting parquet page buffer to the start expected offset zero but got %d", offset)
 	}
-	c.pages, buffer = append(c.pages, buffer), nil
-	return nil
+	page := buffer
+	buffer = nil
+	return page, nil
 }
 
 func (c *writerColumn) makePageStatistics(page Page) format.Statistics {
@@ -1541,13 +2148,81 @@ func (c *writerColumn) makePageStatistics(page Page) format.Statistics {
 	minValue, maxValue, _ := page.Bounds()
 	minValueBytes := minValue.Bytes()
 	maxValueBytes := maxValue.Bytes()
-	return format.Statistics{
-		Min:       minValueBytes, // deprecated
-		Max:       maxValueBytes, // deprecated
+	stats := format.Statistics{
 		NullCount: numNulls,
 		MinValue:  minValueBytes,
 		MaxValue:  maxValueBytes,
 	}
+	c.truncateStatistics(&stats)
+	stats.Min = stats.MinValue // deprecated
+	stats.Max = stats.MaxValue // deprecated
+	return stats
+}
+
+// truncateStatistics truncates the min and max values of stats to the limit
+// configured on the column, recording whether the values are exact.
+func (c *writerColumn) truncateStatistics(stats *format.Statistics) {
+	limit := c.statisticsTruncateLength
+	if limit <= 0 || stats.MaxValue == nil {
+		return
+	}
+	var minExact, maxExact bool
+	stats.MinValue, minExact = truncateMinStatistic(stats.MinValue, limit, c.truncateUTF8)
+	stats.MaxValue, maxExact = truncateMaxStatistic(stats.MaxValue, limit, c.truncateUTF8)
+	stats.IsMinValueExact, stats.IsMaxValueExact = &minExact, &maxExact
+}
+
+// truncateMinStatistic returns a prefix of value of at most limit bytes, and
+// whether the value was returned unchanged.
+func truncateMinStatistic(value []byte, limit int, isUTF8 bool) ([]byte, bool) {
+	if len(value) <= limit {
+		return value, true
+	}
+	n := truncatedLength(value, limit, isUTF8)
+	return append([]byte{}, value[:n]...), false
+}
+
+// truncateMaxStatistic returns a value of at most limit bytes which is greater
+// than value, and whether value was returned unchanged because no such value
+// could be constructed.
+func truncateMaxStatistic(value []byte, limit int, isUTF8 bool) ([]byte, bool) {
+	if len(value) <= limit {
+		return value, true
+	}
+	max := append([]byte{}, value[:truncatedLength(value, limit, isUTF8)]...)
+	// Increment the last byte which does not overflow, dropping the bytes
+	// after it, so the result is the smallest prefix greater than value.
+	for i := len(max) - 1; i >= 0; i-- {
+		if max[i]++; max[i] != 0 {
+			max = max[:i+1]
+			if isUTF8 && !utf8.Valid(max) {
+				break
+			}
+			return max, false
+		}
+	}
+	return value, true
+}
+
+// truncatedLength returns limit, shortened to the previous character boundary
+// of value if it holds UTF8 text.
+func truncatedLength(value []byte, limit int, isUTF8 bool) int {
+	n := limit
+	if isUTF8 {
+		for n > 0 && !utf8.RuneStart(value[n]) {
+			n--
+		}
+	}
+	return n
+}
+
+func searchOmitStatistics(omit map[string]bool, path columnPath) bool {
+	for n := len(path); n >= 0 && len(omit) > 0; n-- {
+		if omit[path[:n].String()] {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *writerColumn) recordPageStats(headerSize int32, header *format.PageHeader, page Page) {
@@ -1562,7 +2237,7 @@ func (c *writerColumn) recordPageStats(headerSize int32, header *format.PageHead
 		c.columnChunk.MetaData.NumValues += numValues
 		c.columnChunk.MetaData.Statistics.NullCount += numNulls
 
-		if pageHasBounds {
+		if pageHasBounds && !c.omitBounds {
 			var existingMaxValue, existingMinValue Value
 
 			if c.columnChunk.MetaData.Statistics.MaxValue != nil && c.columnChunk.MetaData.Statistics.MinValue != nil {
@@ -1692,3 +2367,100 @@ var (
 	_ io.ReaderFrom   = (*offsetTrackingWriter)(nil)
 	_ io.StringWriter = (*offsetTrackingWriter)(nil)
 )
+
+// Prefix of the key/value metadata keys where writers record the number of
+// values truncated in each column.
+const truncatedValuesKeyPrefix = "parquet-go.truncated_values."
+
+// truncatingColumnBuffer wraps the column buffers of BYTE_ARRAY columns to
+// truncate values exceeding a maximum length before they are written to the
+// buffer.
+type truncatingColumnBuffer struct {
+	ColumnBuffer
+	limit     int
+	utf8      bool
+	truncated *int64
+	values    []Value
+	strings   []string
+}
+
+func (col *truncatingColumnBuffer) Clone() ColumnBuffer {
+	return &truncatingColumnBuffer{
+		ColumnBuffer: col.ColumnBuffer.Clone(),
+		limit:        col.limit,
+		utf8:         col.utf8,
+		truncated:    col.truncated,
+	}
+}
+
+func (col *truncatingColumnBuffer) WriteValues(values []Value) (int, error) {
+	truncated := false
+
+	for i := range values {
+		v := &values[i]
+		if v.Kind() != ByteArray || int(v.u64) <= col.limit {
+			continue
+		}
+		if !truncated {
+			truncated = true
+			col.values = append(col.values[:0], values...)
+		}
+		col.values[i].u64 = uint64(col.truncate(v.byteArray()))
+		*col.truncated++
+	}
+
+	if truncated {
+		defer clearValues(col.values)
+		values = col.values
+	}
+	return col.ColumnBuffer.WriteValues(values)
+}
+
+func (col *truncatingColumnBuffer) writeValues(rows sparse.Array, levels columnLevels) {
+	truncated := false
+
+	for i, n := 0, rows.Len(); i < n; i++ {
+		s := *(*string)(rows.Index(i))
+		if len(s) <= col.limit {
+			continue
+		}
+		if !truncated {
+			truncated = true
+			col.strings = col.strings[:0]
+			for j := 0; j < n; j++ {
+				col.strings = append(col.strings, *(*string)(rows.Index(j)))
+			}
+		}
+		col.strings[i] = s[:col.truncate(unsafecast.StringToBytes(s))]
+		*col.truncated++
+	}
+
+	if truncated {
+		defer func() {
+			for i := range col.strings {
+				col.strings[i] = ""
+			}
+		}()
+		rows = makeArrayString(col.strings)
+	}
+	col.ColumnBuffer.writeValues(rows, levels)
+}
+
+// truncate returns the length of b truncated to the limit of the column, which
+// is shortened to the previous character boundary for UTF8 columns.
+func (col *truncatingColumnBuffer) truncate(b []byte) int {
+	n := col.limit
+	if col.utf8 {
+		for n > 0 && !utf8.RuneStart(b[n]) {
+			n--
+		}
+	}
+	return n
+}
+
+func isUTF8Type(t Type) bool {
+	if lt := t.LogicalType(); lt != nil && (lt.UTF8 != nil || lt.Enum != nil || lt.Json != nil) {
+		return true
+	}
+	return isStringConvertedType(t)
+}
@@ -2,14 +2,19 @@ package parquet_test
 
 import (
 	"bytes"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
 	"os"
 	"os/exec"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hexops/gotextdiff"
@@ -18,6 +23,8 @@ import (
 
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 const (
@@ -1119,6 +1126,127 @@ func TestWriterMaxRowsPerRowGroup(t *testing.T) {
 	}
 }
 
+func TestWriterDataPageV1OptionalColumns(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	name := "name"
+	rows := []Row{
+		{ID: 1, Name: &name, Tags: []string{"a", "b"}},
+		{ID: 2, Tags: []string{}},
+		{ID: 3, Name: &name, Tags: []string{"c"}},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.DataPageVersion(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestGenericWriterOptionalByteArray(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Data []byte `parquet:"data,optional"`
+	}
+
+	rows := []Row{
+		{ID: 1, Data: []byte("hello")},
+		{ID: 2},
+		{ID: 3, Data: []byte("world")},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make([]parquet.Row, len(rows))
+	if n, err := f.RowGroups()[0].Rows().ReadRows(values); n != len(rows) {
+		t.Fatalf("reading rows: n=%d err=%v", n, err)
+	}
+	for i, row := range values {
+		data := row[1]
+		if isNull := rows[i].Data == nil; data.IsNull() != isNull {
+			t.Errorf("row %d: null mismatch: want=%t got=%t", i, isNull, data.IsNull())
+		} else if !isNull && !bytes.Equal(data.ByteArray(), rows[i].Data) {
+			t.Errorf("row %d: value mismatch: want=%q got=%q", i, rows[i].Data, data.ByteArray())
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+func TestWriteMaxRowsPerRowGroup(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+	rows := make([]Row, 100)
+
+	for _, test := range []struct {
+		scenario string
+		write    func(io.Writer) error
+	}{
+		{
+			scenario: "parquet.Write",
+			write: func(w io.Writer) error {
+				return parquet.Write(w, rows, parquet.MaxRowsPerRowGroup(10))
+			},
+		},
+		{
+			scenario: "WriterConfig",
+			write: func(w io.Writer) error {
+				config, err := parquet.NewWriterConfig(parquet.MaxRowsPerRowGroup(10))
+				if err != nil {
+					return err
+				}
+				writer := parquet.NewGenericWriter[Row](w, config)
+				if _, err := writer.Write(rows); err != nil {
+					return err
+				}
+				return writer.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			if err := test.write(buffer); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(f.RowGroups()); n != 10 {
+				t.Errorf("wrong number of row groups in parquet file: want=10 got=%d", n)
+			}
+		})
+	}
+}
+
 func TestSetKeyValueMetadata(t *testing.T) {
 	testKey := "test-key"
 	testValue := "test-value"
@@ -1203,3 +1331,910 @@ func TestSetKeyValueMetadataOverwritesExisting(t *testing.T) {
 		t.Errorf("expected %q, got %q", testValue, value)
 	}
 }
+
+func TestSetRowGroupKeyValueMetadata(t *testing.T) {
+	type Row struct {
+		A int64 `parquet:"a"`
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](b)
+
+	for i, batch := range []string{"batch-1", "batch-2"} {
+		w.SetRowGroupKeyValueMetadata("batch_id", batch)
+		w.SetRowGroupKeyValueMetadata("index", strconv.Itoa(i))
+		if _, err := w.Write([]Row{{A: int64(i)}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w.Write([]Row{{A: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lazy := range []bool{false, true} {
+		f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()), parquet.LazyColumnMetadata(lazy))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rowGroups := f.RowGroups()
+		if len(rowGroups) != 3 {
+			t.Fatalf("want=3 got=%d", len(rowGroups))
+		}
+
+		for i, want := range []string{"batch-1", "batch-2", ""} {
+			rowGroup, ok := rowGroups[i].(parquet.RowGroupKeyValueMetadata)
+			if !ok {
+				t.Fatalf("row group %d does not implement parquet.RowGroupKeyValueMetadata", i)
+			}
+			got, ok := rowGroup.Lookup("batch_id")
+			if ok != (want != "") || got != want {
+				t.Errorf("row group %d: want=%q got=%q (ok=%t)", i, want, got, ok)
+			}
+		}
+
+		// The metadata is recorded on the first column chunk of the row group.
+		metadata := f.Metadata()
+		if keyValueMetadata := metadata.RowGroups[0].Columns[0].MetaData.KeyValueMetadata; !reflect.DeepEqual(keyValueMetadata, []format.KeyValue{
+			{Key: "batch_id", Value: "batch-1"},
+			{Key: "index", Value: "0"},
+		}) {
+			t.Errorf("key/value metadata of the first column chunk mismatch: %+v", keyValueMetadata)
+		}
+
+		if _, ok := f.Lookup("batch_id"); ok {
+			t.Error("row group metadata must not be recorded in the file metadata")
+		}
+	}
+}
+
+func TestWriterAbort(t *testing.T) {
+	type Row struct {
+		A int64 `parquet:"a"`
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](b)
+	if _, err := w.Write([]Row{{A: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]Row{{A: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	size := b.Len()
+
+	w.Abort()
+	if err := w.Close(); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("want=%v got=%v", io.ErrClosedPipe, err)
+	}
+	if b.Len() != size {
+		t.Errorf("bytes written after abort: want=%d got=%d", size, b.Len())
+	}
+	if bytes.HasSuffix(b.Bytes(), []byte("PAR1")) {
+		t.Error("aborted file must not end with the footer magic")
+	}
+	if _, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len())); err == nil {
+		t.Error("opening an aborted file must fail")
+	}
+
+	b.Reset()
+	w.Reset(b)
+	if _, err := w.Write([]Row{{A: 3}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := parquet.Read[Row](bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Row{{A: 3}}; !reflect.DeepEqual(want, rows) {
+		t.Errorf("want=%+v got=%+v", want, rows)
+	}
+}
+
+func TestWriterStrictTimestamps(t *testing.T) {
+	type Item struct {
+		At time.Time `parquet:"at,timestamp(microsecond)"`
+	}
+
+	type Event struct {
+		Name   string    `parquet:"name"`
+		Millis time.Time `parquet:"millis,timestamp(millisecond)"`
+		Nanos  time.Time `parquet:"nanos,timestamp(nanosecond)"`
+		Items  []Item    `parquet:"items"`
+	}
+
+	exact := time.Date(2023, 1, 2, 3, 4, 5, 6e6, time.UTC)
+	precise := exact.Add(123 * time.Nanosecond)
+
+	for _, test := range []struct {
+		scenario string
+		strict   bool
+		event    Event
+		err      error
+	}{
+		{
+			scenario: "exact timestamps",
+			strict:   true,
+			event:    Event{Millis: exact, Nanos: precise, Items: []Item{{exact}}},
+		},
+		{
+			scenario: "truncated millisecond timestamp",
+			strict:   true,
+			event:    Event{Millis: precise, Nanos: precise},
+			err:      parquet.ErrTimestampTruncated,
+		},
+		{
+			scenario: "truncated microsecond timestamp in slice",
+			strict:   true,
+			event:    Event{Millis: exact, Items: []Item{{exact}, {precise}}},
+			err:      parquet.ErrTimestampTruncated,
+		},
+		{
+			scenario: "truncation allowed",
+			strict:   false,
+			event:    Event{Millis: precise, Nanos: precise, Items: []Item{{precise}}},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			t.Run("GenericWriter", func(t *testing.T) {
+				w := parquet.NewGenericWriter[Event](io.Discard, parquet.StrictTimestamps(test.strict))
+				_, err := w.Write([]Event{test.event})
+				if !errors.Is(err, test.err) {
+					t.Fatalf("error mismatch: want=%v got=%v", test.err, err)
+				}
+			})
+
+			t.Run("Writer", func(t *testing.T) {
+				w := parquet.NewWriter(io.Discard, parquet.StrictTimestamps(test.strict))
+				err := w.Write(&test.event)
+				if !errors.Is(err, test.err) {
+					t.Fatalf("error mismatch: want=%v got=%v", test.err, err)
+				}
+			})
+
+			t.Run("Write", func(t *testing.T) {
+				// The last option takes precedence over the earlier ones.
+				err := parquet.Write(io.Discard, []Event{test.event},
+					parquet.StrictTimestamps(!test.strict),
+					parquet.StrictTimestamps(test.strict),
+				)
+				if !errors.Is(err, test.err) {
+					t.Fatalf("error mismatch: want=%v got=%v", test.err, err)
+				}
+			})
+		})
+	}
+
+	t.Run("nanosecond round trip", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		event := Event{Name: "A", Millis: exact, Nanos: precise}
+		if err := parquet.Write(buffer, []Event{event}, parquet.StrictTimestamps(true)); err != nil {
+			t.Fatal(err)
+		}
+		events, err := parquet.Read[Event](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !events[0].Nanos.Equal(precise) {
+			t.Errorf("nanosecond timestamp mismatch: want=%v got=%v", precise, events[0].Nanos)
+		}
+	})
+}
+
+func TestWriterCompressionLevel(t *testing.T) {
+	type Row struct {
+		A string `parquet:"a,gzip"`
+		B string `parquet:"b,zstd"`
+		C string `parquet:"c,snappy"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		s := strings.Repeat(fmt.Sprintf("value-%d,", i%10), 10)
+		rows[i] = Row{A: s, B: s, C: s}
+	}
+
+	columnSizes := func(t *testing.T, options ...parquet.WriterOption) []int64 {
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows, options...); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes := make([]int64, 3)
+		for i, col := range f.Metadata().RowGroups[0].Columns {
+			sizes[i] = col.MetaData.TotalCompressedSize
+		}
+		return sizes
+	}
+
+	defaultSizes := columnSizes(t)
+	storedSizes := columnSizes(t, parquet.CompressionLevel(0))
+	columnLevelSizes := columnSizes(t,
+		parquet.CompressionLevel(0),
+		parquet.ColumnCompressionLevel(9, "a"),
+	)
+
+	if storedSizes[0] <= defaultSizes[0] {
+		t.Errorf("gzip column must be larger when stored without compression: default=%d stored=%d", defaultSizes[0], storedSizes[0])
+	}
+	if storedSizes[2] != defaultSizes[2] {
+		t.Errorf("snappy column must not be affected by compression levels: want=%d got=%d", defaultSizes[2], storedSizes[2])
+	}
+	if columnLevelSizes[0] >= storedSizes[0] {
+		t.Errorf("column compression level must override the default level: default=%d column=%d", storedSizes[0], columnLevelSizes[0])
+	}
+	if columnLevelSizes[1] != storedSizes[1] {
+		t.Errorf("column compression level must only apply to its column: want=%d got=%d", storedSizes[1], columnLevelSizes[1])
+	}
+}
+
+func TestWriterColumnCompressionDictionary(t *testing.T) {
+	type Row struct {
+		ID  int64  `parquet:"id"`
+		URL string `parquet:"url,zstd"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	paths := []string{"users", "orders", "products", "invoices", "sessions"}
+	rows := make([]Row, 2000)
+	samples := make([][]byte, len(rows))
+	for i := range rows {
+		rows[i] = Row{
+			ID:  int64(i),
+			URL: fmt.Sprintf("https://api.example.com/v1/%s/%d?format=json", paths[prng.Intn(len(paths))], prng.Intn(1e6)),
+		}
+		samples[i] = []byte(rows[i].URL)
+	}
+	dict := zstd.TrainDictionary(samples, 4096)
+
+	writeFile := func(t *testing.T, options ...parquet.WriterOption) *parquet.File {
+		buffer := new(bytes.Buffer)
+		// Small pages are the ones benefiting the most from dictionaries.
+		options = append(options, parquet.PageBufferSize(256))
+		if err := parquet.Write(buffer, rows, options...); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	readRows := func(t *testing.T, f *parquet.File) {
+		got := make([]Row, f.NumRows())
+		n, err := parquet.NewGenericReader[Row](f).Read(got)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		if n != len(rows) || !reflect.DeepEqual(got[:n], rows) {
+			t.Fatal("rows mismatch after reading the file")
+		}
+	}
+
+	plain := writeFile(t)
+	f := writeFile(t, parquet.ColumnCompressionDictionary(dict, "url"))
+	readRows(t, f)
+
+	value, ok := f.Lookup("parquet-go.zstd_dictionary.url")
+	if !ok {
+		t.Fatal("zstd dictionary not found in the key/value metadata of the file")
+	}
+	if value != base64.StdEncoding.EncodeToString(dict) {
+		t.Error("zstd dictionary mismatch in the key/value metadata of the file")
+	}
+	if _, ok := f.Lookup("parquet-go.zstd_dictionary.id"); ok {
+		t.Error("zstd dictionary found for a column configured without dictionary")
+	}
+
+	plainSize := plain.Metadata().RowGroups[0].Columns[1].MetaData.TotalCompressedSize
+	dictSize := f.Metadata().RowGroups[0].Columns[1].MetaData.TotalCompressedSize
+	if dictSize >= plainSize {
+		t.Errorf("column compressed with a dictionary must be smaller: plain=%d dictionary=%d", plainSize, dictSize)
+	}
+
+	t.Run("recompress", func(t *testing.T) {
+		data, err := io.ReadAll(parquet.Recompress(f, &parquet.Snappy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		readRows(t, f)
+	})
+}
+
+func TestWriterColumnDictionary(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Role string `parquet:"role"`
+	}
+
+	rows := []Row{
+		{ID: 0, Role: "member"},
+		{ID: 1, Role: "admin"},
+		{ID: 2, Role: "guest"},
+		{ID: 3, Role: "member"},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer,
+		parquet.MaxRowsPerRowGroup(2),
+		parquet.ColumnDictionary([]string{"role"},
+			parquet.ValueOf("owner"),
+			parquet.ValueOf("admin"),
+			parquet.ValueOf("member"),
+		),
+	)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, values)
+	}
+
+	for i, want := range [][]string{
+		{"owner", "admin", "member"},
+		{"owner", "admin", "member", "guest"},
+	} {
+		pages := f.RowGroups()[i].ColumnChunks()[1].Pages()
+		page, err := pages.ReadPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dict := page.Dictionary()
+		if dict == nil {
+			t.Fatalf("row group %d: column is not dictionary encoded", i)
+		}
+		got := make([]string, dict.Len())
+		for j := range got {
+			got[j] = dict.Index(int32(j)).String()
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("row group %d: dictionary mismatch: want=%q got=%q", i, want, got)
+		}
+
+		parquet.Release(page)
+		pages.Close()
+	}
+
+	seed := parquet.ColumnDictionary([]string{"role"}, parquet.ValueOf(42))
+
+	if err := parquet.Write(new(bytes.Buffer), rows, seed); err == nil {
+		t.Error("seeding a dictionary with values of the wrong type must fail")
+	}
+	if err := parquet.NewWriter(new(bytes.Buffer), seed).Write(&rows[0]); err == nil {
+		t.Error("seeding a dictionary with values of the wrong type must fail")
+	}
+	if _, err := parquet.NewWriterConfig(parquet.SchemaOf(Row{}), seed); err == nil {
+		t.Error("seeding a dictionary with values of the wrong type must fail")
+	}
+}
+
+func TestWriterStatisticsTruncateLength(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+		Text string `parquet:"text"`
+		Data []byte `parquet:"data"`
+		Code string `parquet:"code"`
+	}
+
+	rows := []Row{
+		{Name: "apple pie", Text: "héllo", Data: []byte{0xFF, 0xFF, 0xFF, 0x01}, Code: "ab"},
+		{Name: "zebra crossing", Text: "hz", Data: []byte{0x00}, Code: "cd"},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.StatisticsTruncateLength(4)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []struct {
+		min, max           string
+		minExact, maxExact bool
+	}{
+		{min: "appl", max: "zebs", minExact: false, maxExact: false},
+		// The max value is truncated on the character boundary after "hél".
+		{min: "hz", max: "hém", minExact: true, maxExact: false},
+		// The max value cannot be truncated since all its bytes are 0xFF.
+		{min: "\x00", max: "\xff\xff\xff\x01", minExact: true, maxExact: true},
+		{min: "ab", max: "cd", minExact: true, maxExact: true},
+	} {
+		stats := f.Metadata().RowGroups[0].Columns[i].MetaData.Statistics
+		if string(stats.MinValue) != want.min || string(stats.MaxValue) != want.max {
+			t.Errorf("column %d: bounds mismatch: want=[%q,%q] got=[%q,%q]", i, want.min, want.max, stats.MinValue, stats.MaxValue)
+		}
+		if stats.IsMinValueExact == nil || stats.IsMaxValueExact == nil {
+			t.Errorf("column %d: exactness of statistics is not recorded", i)
+		} else if *stats.IsMinValueExact != want.minExact || *stats.IsMaxValueExact != want.maxExact {
+			t.Errorf("column %d: exactness mismatch: want=(%t,%t) got=(%t,%t)", i, want.minExact, want.maxExact, *stats.IsMinValueExact, *stats.IsMaxValueExact)
+		}
+	}
+}
+
+func TestWriterOmitStatistics(t *testing.T) {
+	type Contact struct {
+		Email string `parquet:"email"`
+		Phone string `parquet:"phone"`
+	}
+	type Row struct {
+		ID      int64   `parquet:"id"`
+		Contact Contact `parquet:"contact"`
+	}
+
+	rows := []Row{
+		{ID: 1, Contact: Contact{Email: "luke@example.com", Phone: "555-0100"}},
+		{ID: 2, Contact: Contact{Email: "leia@example.com", Phone: "555-0199"}},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.OmitStatistics("contact"), parquet.DataPageStatistics(true)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := f.PruningMetadata()
+
+	for i, omitted := range []bool{false, true, true} {
+		if _, _, ok := metadata.Bounds(0, i); ok == omitted {
+			t.Errorf("column %d: bounds mismatch: omitted=%t ok=%t", i, omitted, ok)
+		}
+		columnChunk := f.Metadata().RowGroups[0].Columns[i]
+		if hasColumnIndex := columnChunk.ColumnIndexOffset != 0; hasColumnIndex == omitted {
+			t.Errorf("column %d: column index mismatch: omitted=%t offset=%d", i, omitted, columnChunk.ColumnIndexOffset)
+		}
+		if stats := columnChunk.MetaData.Statistics; stats.NullCount != 0 || (stats.MinValue == nil) != omitted {
+			t.Errorf("column %d: statistics mismatch: omitted=%t stats=%+v", i, omitted, stats)
+		}
+	}
+
+	values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, values)
+	}
+}
+
+func TestWriterMaxByteArrayLength(t *testing.T) {
+	type Row struct {
+		Name string   `parquet:"name"`
+		Role string   `parquet:"role,dict"`
+		Tags []string `parquet:"tags"`
+		Note *string  `parquet:"note,optional"`
+		Data []byte   `parquet:"data"`
+	}
+
+	note := "a rather long note"
+	rows := []Row{
+		{Name: "abcéf", Role: "administrator", Tags: []string{"a", "abcdef"}, Note: &note, Data: []byte("0123456789")},
+		{Name: "ok", Role: "dev", Data: []byte("0123")},
+	}
+
+	t.Run("truncate", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows, parquet.MaxByteArrayLength(4, true)); err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		truncatedNote := "a ra"
+		want := []Row{
+			{Name: "abc", Role: "admi", Tags: []string{"a", "abcd"}, Note: &truncatedNote, Data: []byte("0123")},
+			{Name: "ok", Role: "dev", Tags: []string{}, Data: []byte("0123")},
+		}
+		if !reflect.DeepEqual(values, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, values)
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, want := range map[string]string{
+			"parquet-go.truncated_values.name":              "1",
+			"parquet-go.truncated_values.role":              "1",
+			"parquet-go.truncated_values.tags":              "1",
+			"parquet-go.truncated_values.note":              "1",
+			"parquet-go.truncated_values.data":              "1",
+			"parquet-go.truncated_values.does-not-truncate": "",
+		} {
+			if got, _ := f.Lookup(key); got != want {
+				t.Errorf("metadata %q mismatch: want=%q got=%q", key, want, got)
+			}
+		}
+	})
+
+	t.Run("truncate rows", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewWriter(buffer, parquet.MaxByteArrayLength(4, true))
+		if err := writer.Write(&rows[0]); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values) != 1 || values[0].Name != "abc" || values[0].Role != "admi" || *values[0].Note != "a ra" {
+			t.Errorf("rows mismatch: got=%+v", values)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		writer := parquet.NewGenericWriter[Row](new(bytes.Buffer), parquet.MaxByteArrayLength(4, false))
+		if n, err := writer.Write(rows[1:]); err != nil || n != 1 {
+			t.Fatalf("writing valid rows: n=%d err=%v", n, err)
+		}
+		if n, err := writer.Write(rows); !errors.Is(err, parquet.ErrByteArrayTooLong) || n != 0 {
+			t.Errorf("error mismatch: want=%v got=%v (n=%d)", parquet.ErrByteArrayTooLong, err, n)
+		}
+
+		legacy := parquet.NewWriter(new(bytes.Buffer), parquet.MaxByteArrayLength(4, false))
+		if err := legacy.Write(&rows[0]); !errors.Is(err, parquet.ErrByteArrayTooLong) {
+			t.Errorf("error mismatch: want=%v got=%v", parquet.ErrByteArrayTooLong, err)
+		}
+
+		// The last option takes precedence over the earlier ones.
+		err := parquet.Write(new(bytes.Buffer), rows,
+			parquet.MaxByteArrayLength(4, true),
+			parquet.MaxByteArrayLength(4, false),
+		)
+		if !errors.Is(err, parquet.ErrByteArrayTooLong) {
+			t.Errorf("error mismatch: want=%v got=%v", parquet.ErrByteArrayTooLong, err)
+		}
+	})
+}
+
+func TestWriterNonFiniteFloats(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Ratio *float32 `parquet:"ratio,optional"`
+		Score *float64 `parquet:"score,optional"`
+		Value float64  `parquet:"value"`
+	}
+
+	nan, inf := math.NaN(), float32(math.Inf(-1))
+	half, one := float32(0.5), 1.0
+	finite := []Row{{ID: 0, Ratio: &half, Score: &one, Value: 2}}
+	optional := []Row{{ID: 1, Ratio: &inf, Score: &nan, Value: 3}}
+	required := []Row{{ID: 2, Value: math.Inf(1)}}
+
+	t.Run("allow", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, append(optional, required...)); err != nil {
+			t.Fatal(err)
+		}
+		values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !math.IsNaN(*values[0].Score) || !math.IsInf(float64(*values[0].Ratio), -1) || !math.IsInf(values[1].Value, 1) {
+			t.Errorf("non-finite values were not preserved: %+v", values)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewGenericWriter[Row](buffer, parquet.NonFiniteFloats(parquet.NullNonFinite))
+		if _, err := writer.Write(append(finite, optional...)); err != nil {
+			t.Fatal(err)
+		}
+		if n, err := writer.Write(required); !errors.Is(err, parquet.ErrNonFiniteFloat) || n != 0 {
+			t.Errorf("error mismatch: want=%v got=%v (n=%d)", parquet.ErrNonFiniteFloat, err, n)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Row{finite[0], {ID: 1, Value: 3}}
+		if !reflect.DeepEqual(values, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, values)
+		}
+	})
+
+	t.Run("null across row groups", func(t *testing.T) {
+		rows := make([]Row, 10)
+		for i := range rows {
+			rows[i] = Row{ID: int64(i), Score: &nan, Value: float64(i)}
+		}
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewGenericWriter[Row](buffer,
+			parquet.NonFiniteFloats(parquet.NullNonFinite),
+			parquet.MaxRowsPerRowGroup(3),
+		)
+		if n, err := writer.Write(rows); err != nil || n != len(rows) {
+			t.Fatalf("writing rows: n=%d err=%v", n, err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var numRows []int64
+		for _, rowGroup := range f.RowGroups() {
+			numRows = append(numRows, rowGroup.NumRows())
+		}
+		if want := []int64{3, 3, 3, 1}; !reflect.DeepEqual(numRows, want) {
+			t.Errorf("rows per row group mismatch: want=%v got=%v", want, numRows)
+		}
+
+		values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range rows {
+			rows[i].Score = nil
+		}
+		if !reflect.DeepEqual(values, rows) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, values)
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		writer := parquet.NewGenericWriter[Row](new(bytes.Buffer), parquet.NonFiniteFloats(parquet.RejectNonFinite))
+		if n, err := writer.Write(finite); err != nil || n != 1 {
+			t.Fatalf("writing valid rows: n=%d err=%v", n, err)
+		}
+		for _, rows := range [][]Row{optional, required} {
+			if n, err := writer.Write(rows); !errors.Is(err, parquet.ErrNonFiniteFloat) || n != 0 {
+				t.Errorf("error mismatch: want=%v got=%v (n=%d)", parquet.ErrNonFiniteFloat, err, n)
+			}
+		}
+
+		legacy := parquet.NewWriter(new(bytes.Buffer), parquet.NonFiniteFloats(parquet.RejectNonFinite))
+		if err := legacy.Write(&required[0]); !errors.Is(err, parquet.ErrNonFiniteFloat) {
+			t.Errorf("error mismatch: want=%v got=%v", parquet.ErrNonFiniteFloat, err)
+		}
+	})
+}
+
+func TestWriterAlignRowGroups(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i)}
+	}
+
+	const blockSize = 4096
+
+	for _, test := range []struct {
+		scenario   string
+		maxPadding int64
+		aligned    bool
+	}{
+		{scenario: "padding", maxPadding: blockSize - 1, aligned: true},
+		{scenario: "no padding", maxPadding: 1, aligned: false},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			writer := parquet.NewGenericWriter[Row](buffer,
+				parquet.MaxRowsPerRowGroup(25),
+				parquet.AlignRowGroups(blockSize, test.maxPadding),
+			)
+			if _, err := writer.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rowGroups := f.Metadata().RowGroups
+			if len(rowGroups) != 4 {
+				t.Fatalf("number of row groups mismatch: want=4 got=%d", len(rowGroups))
+			}
+			for i, rowGroup := range rowGroups {
+				if aligned := rowGroup.FileOffset%blockSize == 0; aligned != test.aligned {
+					t.Errorf("row group %d at offset %d: want aligned=%t", i, rowGroup.FileOffset, test.aligned)
+				}
+			}
+
+			values, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(values, rows) {
+				t.Error("rows mismatch")
+			}
+		})
+	}
+
+	if _, err := parquet.NewWriterConfig(parquet.AlignRowGroups(blockSize, blockSize)); err == nil {
+		t.Error("expected an error when the maximum padding is not smaller than the block size")
+	}
+}
+
+func TestWriterFlushConcurrency(t *testing.T) {
+	type Row struct {
+		ID      int64    `parquet:"id,delta"`
+		Name    string   `parquet:"name,zstd"`
+		Role    string   `parquet:"role,dict,gzip"`
+		Score   *float64 `parquet:"score,optional,snappy"`
+		Tags    []string `parquet:"tags,list,dict"`
+		Enabled bool     `parquet:"enabled"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:      int64(i),
+			Name:    fmt.Sprintf("name-%d", i),
+			Role:    fmt.Sprintf("role-%d", i%7),
+			Tags:    []string{"a", "b", "c"}[:i%4],
+			Enabled: i%3 == 0,
+		}
+		if i%5 != 0 {
+			score := float64(i) / 10
+			rows[i].Score = &score
+		}
+	}
+
+	write := func(t *testing.T, options ...parquet.WriterOption) []byte {
+		buffer := new(bytes.Buffer)
+		options = append(options,
+			parquet.MaxRowsPerRowGroup(300),
+			parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+		)
+		writer := parquet.NewGenericWriter[Row](buffer, options...)
+		if _, err := writer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buffer.Bytes()
+	}
+
+	want := write(t)
+
+	for _, concurrency := range []int{2, 4, 16, 0} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			got := write(t, parquet.FlushConcurrency(concurrency))
+			if !bytes.Equal(want, got) {
+				t.Fatal("files written with and without concurrency are not identical")
+			}
+
+			values, err := parquet.Read[Row](bytes.NewReader(got), int64(len(got)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(values, rows) {
+				t.Error("rows mismatch")
+			}
+		})
+	}
+}
+
+func TestWriterEnforceSortOrder(t *testing.T) {
+	type Row struct {
+		Group *int64 `parquet:"group,optional"`
+		ID    int64  `parquet:"id"`
+	}
+
+	one, two := int64(1), int64(2)
+	sorting := parquet.SortingWriterConfig(
+		parquet.SortingColumns(
+			parquet.NullsFirst(parquet.Descending("group")),
+			parquet.NullsLast(parquet.Ascending("id")),
+		),
+		parquet.EnforceSortOrder(true),
+	)
+
+	t.Run("sorted", func(t *testing.T) {
+		rows := []Row{{ID: 1}, {ID: 2}, {Group: &two, ID: 1}, {Group: &one, ID: 0}, {Group: &one, ID: 3}}
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewGenericWriter[Row](buffer, sorting)
+		for i := range rows {
+			if _, err := writer.Write(rows[i : i+1]); err != nil {
+				t.Fatalf("writing row %d: %v", i, err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []format.SortingColumn{
+			{ColumnIdx: 0, Descending: true, NullsFirst: true},
+			{ColumnIdx: 1, Descending: false, NullsFirst: false},
+		}
+		if got := f.Metadata().RowGroups[0].SortingColumns; !reflect.DeepEqual(got, want) {
+			t.Errorf("sorting columns mismatch:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		for _, rows := range [][]Row{
+			{{Group: &one, ID: 1}, {ID: 2}},
+			{{Group: &one, ID: 1}, {Group: &two, ID: 2}},
+			{{Group: &one, ID: 2}, {Group: &one, ID: 1}},
+		} {
+			writer := parquet.NewGenericWriter[Row](new(bytes.Buffer), sorting)
+			if _, err := writer.Write(rows[:1]); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := writer.Write(rows[1:]); !errors.Is(err, parquet.ErrRowsNotSorted) {
+				t.Errorf("error mismatch: want=%v got=%v", parquet.ErrRowsNotSorted, err)
+			}
+
+			legacy := parquet.NewWriter(new(bytes.Buffer), parquet.SchemaOf(Row{}), sorting)
+			if _, err := legacy.WriteRows([]parquet.Row{
+				parquet.SchemaOf(Row{}).Deconstruct(nil, &rows[0]),
+				parquet.SchemaOf(Row{}).Deconstruct(nil, &rows[1]),
+			}); !errors.Is(err, parquet.ErrRowsNotSorted) {
+				t.Errorf("error mismatch: want=%v got=%v", parquet.ErrRowsNotSorted, err)
+			}
+		}
+	})
+
+	t.Run("row groups", func(t *testing.T) {
+		rows := []Row{{Group: &one}, {Group: &two}, {Group: &one}}
+		writer := parquet.NewGenericWriter[Row](new(bytes.Buffer), sorting, parquet.MaxRowsPerRowGroup(1))
+		if _, err := writer.Write(rows); err != nil {
+			t.Errorf("rows of different row groups must not be compared: %v", err)
+		}
+	})
+}